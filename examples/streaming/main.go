@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/providers/openai"
+)
+
+func main() {
+	// Initialize client from OPENAI_API_KEY environment variable
+	client, err := openai.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Create request
+	req := &aisdk.CreateResponseRequest{
+		Model: "gpt-4o",
+		Input: "Say 'double bubble bath' ten times fast.",
+	}
+
+	// Cancelling the context terminates the stream
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamResponse(ctx, req)
+	if err != nil {
+		log.Fatalf("Request failed: %v", err)
+	}
+	// Always close the stream to release the HTTP connection
+	defer stream.Close()
+
+	for {
+		event, err := stream.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Stream failed: %v", err)
+		}
+
+		switch event.Type {
+		case aisdk.EventOutputTextDelta:
+			fmt.Print(event.Delta)
+		case aisdk.EventResponseCompleted:
+			fmt.Printf("\nTokens used: %d\n", event.Usage.TotalTokens)
+		case aisdk.EventError, aisdk.EventResponseFailed:
+			log.Fatalf("Stream error: %s", event.Error.Message)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package history remembers recent conversations by response ID so that
+// providers with stateless APIs can emulate PreviousResponseID chaining by
+// replaying prior turns.
+package history
+
+import (
+	"sync"
+)
+
+// History holds up to a fixed number of conversations of provider wire
+// messages M, evicting the oldest first. A History is safe for concurrent use.
+type History[M any] struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string][]M
+	order   []string
+}
+
+// New creates a History holding up to size conversations.
+// A size of 0 disables it.
+func New[M any](size int) *History[M] {
+	return &History[M]{
+		size:    size,
+		entries: make(map[string][]M),
+	}
+}
+
+// Get returns the conversation that ended with responseID.
+func (h *History[M]) Get(responseID string) ([]M, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	conversation, ok := h.entries[responseID]
+	return conversation, ok
+}
+
+// Put records the full conversation (request messages followed by the
+// model's reply) that produced responseID.
+func (h *History[M]) Put(responseID string, conversation []M) {
+	if h.size == 0 || responseID == "" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.entries[responseID]; !ok {
+		h.order = append(h.order, responseID)
+	}
+	h.entries[responseID] = conversation
+
+	for len(h.order) > h.size {
+		delete(h.entries, h.order[0])
+		h.order = h.order[1:]
+	}
+}
@@ -0,0 +1,66 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		puts []string
+		want map[string]bool
+	}{
+		{
+			name: "disabled",
+			size: 0,
+			puts: []string{"resp_1"},
+			want: map[string]bool{"resp_1": false},
+		},
+		{
+			name: "within size",
+			size: 2,
+			puts: []string{"resp_1", "resp_2"},
+			want: map[string]bool{"resp_1": true, "resp_2": true},
+		},
+		{
+			name: "oldest evicted first",
+			size: 2,
+			puts: []string{"resp_1", "resp_2", "resp_3"},
+			want: map[string]bool{"resp_1": false, "resp_2": true, "resp_3": true},
+		},
+		{
+			name: "replaced entry keeps its place",
+			size: 2,
+			puts: []string{"resp_1", "resp_2", "resp_1", "resp_3"},
+			want: map[string]bool{"resp_1": false, "resp_2": true, "resp_3": true},
+		},
+		{
+			name: "empty response ID ignored",
+			size: 2,
+			puts: []string{""},
+			want: map[string]bool{"": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New[string](tt.size)
+			for _, id := range tt.puts {
+				h.Put(id, []string{"user " + id, "assistant " + id})
+			}
+
+			for id, want := range tt.want {
+				got, ok := h.Get(id)
+				if ok != want {
+					t.Errorf("Get(%q) found = %v, want %v", id, ok, want)
+					continue
+				}
+				if ok && !reflect.DeepEqual(got, []string{"user " + id, "assistant " + id}) {
+					t.Errorf("Get(%q) = %q", id, got)
+				}
+			}
+		})
+	}
+}
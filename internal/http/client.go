@@ -1,8 +1,10 @@
 package http
 
 import (
+	"crypto/tls"
 	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -17,22 +19,70 @@ type HTTPClient struct {
 func NewHTTPClient(timeout time.Duration) *HTTPClient {
 	return &HTTPClient{
 		client: &http.Client{
-			Timeout: timeout,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-				TLSHandshakeTimeout: 10 * time.Second,
-				DialContext: (&net.Dialer{
-					Timeout:   30 * time.Second,
-					KeepAlive: 30 * time.Second,
-				}).DialContext,
-			},
+			Timeout:   timeout,
+			Transport: NewTransport(TransportSettings{}),
 		},
 	}
 }
 
+// TransportSettings customizes the transport created by NewTransport.
+type TransportSettings struct {
+	// Proxy selects the proxy for a request (default: http.ProxyFromEnvironment)
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// TLSConfig is the TLS client configuration (default: Go's)
+	TLSConfig *tls.Config
+
+	// MaxIdleConnsPerHost bounds idle connections per host (default: 10)
+	MaxIdleConnsPerHost int
+}
+
+// NewTransport creates the pooled transport used by NewHTTPClient.
+func NewTransport(settings TransportSettings) *http.Transport {
+	proxy := settings.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	maxIdleConnsPerHost := settings.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost == 0 {
+		maxIdleConnsPerHost = 10
+	}
+
+	return &http.Transport{
+		Proxy:               proxy,
+		TLSClientConfig:     settings.TLSConfig,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+	}
+}
+
+// WrapHTTPClient wraps a caller-supplied http.Client, whose Transport, Jar
+// and CheckRedirect are used as-is. timeout applies when the client sets none.
+func WrapHTTPClient(client *http.Client, timeout time.Duration) *HTTPClient {
+	wrapped := *client
+	if wrapped.Timeout == 0 {
+		wrapped.Timeout = timeout
+	}
+	return &HTTPClient{client: &wrapped}
+}
+
 // Client returns the underlying http.Client
 func (c *HTTPClient) Client() *http.Client {
 	return c.client
 }
+
+// Streaming returns a client sharing the same transport but without an
+// overall timeout. http.Client.Timeout also bounds reading the response body,
+// which would cut long-lived SSE streams short; callers rely on the request
+// context for cancellation instead.
+func (c *HTTPClient) Streaming() *HTTPClient {
+	client := *c.client
+	client.Timeout = 0
+	return &HTTPClient{client: &client}
+}
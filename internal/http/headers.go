@@ -1,52 +1,146 @@
 package http
 
 import (
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // RateLimitInfo contains rate limit state extracted from HTTP headers.
+// Limit, Remaining and ResetAt describe the request budget; the Tokens
+// fields describe the token budget. Zero limits mean a dimension was not reported.
 type RateLimitInfo struct {
 	Limit      int
 	Remaining  int
 	ResetAt    time.Time
 	RetryAfter time.Duration
+
+	LimitTokens     int
+	RemainingTokens int
+	ResetTokensAt   time.Time
 }
 
+// RetryDelay returns how long to wait before retrying: RetryAfter when the
+// server sent one, otherwise the time until the exhausted request or token
+// budget resets. Returns 0 when neither is known.
+func (i *RateLimitInfo) RetryDelay() time.Duration {
+	if i.RetryAfter > 0 {
+		return i.RetryAfter
+	}
+
+	var resetAt time.Time
+	if i.Limit > 0 && i.Remaining <= 0 {
+		resetAt = i.ResetAt
+	}
+	if i.LimitTokens > 0 && i.RemainingTokens <= 0 && i.ResetTokensAt.After(resetAt) {
+		resetAt = i.ResetTokensAt
+	}
+	if d := time.Until(resetAt); !resetAt.IsZero() && d > 0 {
+		return d
+	}
+	return 0
+}
+
+// unixTimestampThreshold separates Unix timestamps from relative seconds in
+// numeric reset headers (any value past 2001-09-09 is a timestamp)
+const unixTimestampThreshold = 1e9
+
 // ExtractRateLimitHeaders parses rate limit information from HTTP response headers.
-// Extracts X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, and Retry-After.
+// Understands OpenAI's per-dimension headers (x-ratelimit-{limit,remaining,reset}-{requests,tokens},
+// resets as durations such as "6m0s" or "20ms"), the generic X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset (Unix seconds), Anthropic's
+// anthropic-ratelimit-{requests,tokens}-* (RFC 3339 resets), and Retry-After
+// as seconds or an HTTP date, with retry-after-ms taking precedence.
 // Reference: data-model.md Entity #6 (RateLimitInfo)
 func ExtractRateLimitHeaders(headers http.Header) *RateLimitInfo {
+	return extractRateLimitHeaders(headers, time.Now())
+}
+
+// extractRateLimitHeaders is ExtractRateLimitHeaders relative to now.
+func extractRateLimitHeaders(headers http.Header, now time.Time) *RateLimitInfo {
 	info := &RateLimitInfo{}
 
-	// Extract X-RateLimit-Limit
-	if limit := headers.Get("X-RateLimit-Limit"); limit != "" {
-		if val, err := strconv.Atoi(limit); err == nil {
-			info.Limit = val
+	// Request dimension, falling back to the generic headers
+	info.Limit = headerInt(headers, "X-RateLimit-Limit-Requests", "Anthropic-RateLimit-Requests-Limit", "X-RateLimit-Limit")
+	info.Remaining = headerInt(headers, "X-RateLimit-Remaining-Requests", "Anthropic-RateLimit-Requests-Remaining", "X-RateLimit-Remaining")
+	info.ResetAt = headerReset(headers, now, "X-RateLimit-Reset-Requests", "Anthropic-RateLimit-Requests-Reset", "X-RateLimit-Reset")
+
+	// Token dimension
+	info.LimitTokens = headerInt(headers, "X-RateLimit-Limit-Tokens", "Anthropic-RateLimit-Tokens-Limit")
+	info.RemainingTokens = headerInt(headers, "X-RateLimit-Remaining-Tokens", "Anthropic-RateLimit-Tokens-Remaining")
+	info.ResetTokensAt = headerReset(headers, now, "X-RateLimit-Reset-Tokens", "Anthropic-RateLimit-Tokens-Reset")
+
+	info.RetryAfter = retryAfter(headers, now)
+
+	return info
+}
+
+// headerInt returns the first of keys present as an integer, or 0.
+func headerInt(headers http.Header, keys ...string) int {
+	for _, key := range keys {
+		if value := headers.Get(key); value != "" {
+			if val, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				return val
+			}
 		}
 	}
+	return 0
+}
 
-	// Extract X-RateLimit-Remaining
-	if remaining := headers.Get("X-RateLimit-Remaining"); remaining != "" {
-		if val, err := strconv.Atoi(remaining); err == nil {
-			info.Remaining = val
+// headerReset returns the first of keys present as an absolute reset time.
+// Values may be Go-style durations ("6m0s", "20ms", "1.5s"), relative
+// seconds, Unix timestamps, or RFC 3339 timestamps.
+func headerReset(headers http.Header, now time.Time, keys ...string) time.Time {
+	for _, key := range keys {
+		value := strings.TrimSpace(headers.Get(key))
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err == nil {
+			return now.Add(d)
+		}
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			if seconds >= unixTimestampThreshold {
+				sec, frac := math.Modf(seconds)
+				return time.Unix(int64(sec), int64(frac*1e9))
+			}
+			return now.Add(secondsDuration(seconds))
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
 		}
 	}
+	return time.Time{}
+}
 
-	// Extract X-RateLimit-Reset (Unix timestamp)
-	if reset := headers.Get("X-RateLimit-Reset"); reset != "" {
-		if timestamp, err := strconv.ParseInt(reset, 10, 64); err == nil {
-			info.ResetAt = time.Unix(timestamp, 0)
+// retryAfter parses retry-after-ms (milliseconds) or Retry-After (seconds
+// or HTTP date). Returns 0 when neither is present or the date has passed.
+func retryAfter(headers http.Header, now time.Time) time.Duration {
+	if value := strings.TrimSpace(headers.Get("Retry-After-Ms")); value != "" {
+		if ms, err := strconv.ParseFloat(value, 64); err == nil && ms > 0 {
+			return time.Duration(ms * float64(time.Millisecond))
 		}
 	}
 
-	// Extract Retry-After (seconds)
-	if retryAfter := headers.Get("Retry-After"); retryAfter != "" {
-		if seconds, err := strconv.Atoi(retryAfter); err == nil {
-			info.RetryAfter = time.Duration(seconds) * time.Second
+	value := strings.TrimSpace(headers.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds > 0 {
+			return secondsDuration(seconds)
 		}
+		return 0
 	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
 
-	return info
+// secondsDuration converts fractional seconds to a Duration.
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
 }
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExtractRateLimitHeaders(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    RateLimitInfo
+	}{
+		{
+			name: "no headers",
+			want: RateLimitInfo{},
+		},
+		{
+			name: "openai per-dimension headers",
+			headers: map[string]string{
+				"x-ratelimit-limit-requests":     "500",
+				"x-ratelimit-remaining-requests": "499",
+				"x-ratelimit-reset-requests":     "120ms",
+				"x-ratelimit-limit-tokens":       "30000",
+				"x-ratelimit-remaining-tokens":   "29000",
+				"x-ratelimit-reset-tokens":       "6m0s",
+			},
+			want: RateLimitInfo{
+				Limit:           500,
+				Remaining:       499,
+				ResetAt:         now.Add(120 * time.Millisecond),
+				LimitTokens:     30000,
+				RemainingTokens: 29000,
+				ResetTokensAt:   now.Add(6 * time.Minute),
+			},
+		},
+		{
+			name: "generic headers with unix reset",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "60",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1714564860",
+			},
+			want: RateLimitInfo{
+				Limit:   60,
+				ResetAt: time.Unix(1714564860, 0),
+			},
+		},
+		{
+			name: "generic headers with relative seconds",
+			headers: map[string]string{
+				"X-RateLimit-Reset": "1.5",
+			},
+			want: RateLimitInfo{ResetAt: now.Add(1500 * time.Millisecond)},
+		},
+		{
+			name: "anthropic headers",
+			headers: map[string]string{
+				"anthropic-ratelimit-requests-limit":     "50",
+				"anthropic-ratelimit-requests-remaining": "49",
+				"anthropic-ratelimit-requests-reset":     "2024-05-01T12:01:00Z",
+				"anthropic-ratelimit-tokens-limit":       "40000",
+				"anthropic-ratelimit-tokens-remaining":   "0",
+				"anthropic-ratelimit-tokens-reset":       "2024-05-01T12:00:30Z",
+			},
+			want: RateLimitInfo{
+				Limit:           50,
+				Remaining:       49,
+				ResetAt:         now.Add(time.Minute),
+				LimitTokens:     40000,
+				RemainingTokens: 0,
+				ResetTokensAt:   now.Add(30 * time.Second),
+			},
+		},
+		{
+			name:    "retry-after seconds",
+			headers: map[string]string{"Retry-After": "20"},
+			want:    RateLimitInfo{RetryAfter: 20 * time.Second},
+		},
+		{
+			name:    "retry-after http date",
+			headers: map[string]string{"Retry-After": "Wed, 01 May 2024 12:00:45 GMT"},
+			want:    RateLimitInfo{RetryAfter: 45 * time.Second},
+		},
+		{
+			name:    "retry-after date in the past",
+			headers: map[string]string{"Retry-After": "Wed, 01 May 2024 11:59:00 GMT"},
+			want:    RateLimitInfo{},
+		},
+		{
+			name:    "retry-after-ms takes precedence",
+			headers: map[string]string{"Retry-After": "20", "retry-after-ms": "250"},
+			want:    RateLimitInfo{RetryAfter: 250 * time.Millisecond},
+		},
+		{
+			name:    "malformed values are ignored",
+			headers: map[string]string{"X-RateLimit-Limit": "many", "X-RateLimit-Reset": "soon", "Retry-After": "later"},
+			want:    RateLimitInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for key, value := range tt.headers {
+				headers.Set(key, value)
+			}
+
+			got := extractRateLimitHeaders(headers, now)
+			if got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining ||
+				got.LimitTokens != tt.want.LimitTokens || got.RemainingTokens != tt.want.RemainingTokens ||
+				got.RetryAfter != tt.want.RetryAfter ||
+				!got.ResetAt.Equal(tt.want.ResetAt) || !got.ResetTokensAt.Equal(tt.want.ResetTokensAt) {
+				t.Errorf("extractRateLimitHeaders() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitInfoRetryDelay(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name string
+		info RateLimitInfo
+		want bool
+	}{
+		{"nothing known", RateLimitInfo{}, false},
+		{"retry-after wins", RateLimitInfo{RetryAfter: time.Second, Limit: 1, ResetAt: future}, true},
+		{"exhausted requests", RateLimitInfo{Limit: 1, ResetAt: future}, true},
+		{"requests left", RateLimitInfo{Limit: 2, Remaining: 1, ResetAt: future}, false},
+		{"exhausted tokens", RateLimitInfo{LimitTokens: 100, ResetTokensAt: future}, true},
+		{"reset in the past", RateLimitInfo{Limit: 1, ResetAt: time.Now().Add(-time.Second)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.info.RetryDelay()
+			if (got > 0) != tt.want {
+				t.Errorf("RetryDelay() = %v, want positive %v", got, tt.want)
+			}
+			if tt.info.RetryAfter > 0 && got != tt.info.RetryAfter {
+				t.Errorf("RetryDelay() = %v, want RetryAfter %v", got, tt.info.RetryAfter)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
 )
 
 // DoRequest executes an HTTP request with context support.
@@ -33,48 +35,144 @@ func (c *HTTPClient) DoRequest(ctx context.Context, req *http.Request) (*http.Re
 	return resp, nil
 }
 
-// DoRequestWithRetry is a helper that provides a simplified interface for retrying requests
-func (c *HTTPClient) DoRequestWithRetry(ctx context.Context, req *http.Request, maxRetries int, backoffFunc func(int) time.Duration) (*http.Response, error) {
-	var lastErr error
+// RetryPolicy controls DoRequestWithRetry.
+// Reference: research.md decision #5 (exponential backoff with jitter)
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt
+	MaxRetries int
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// Check context before attempting
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
-		}
+	// Backoff returns the delay before the retry following attempt (0-based).
+	// A server-provided delay (see RetryDelay) takes precedence.
+	Backoff func(attempt int) time.Duration
+
+	// MaxDelay caps the delay before a retry (0: no cap). A retryable
+	// response asking for a longer wait is returned instead of retried,
+	// since an earlier retry would be rejected again
+	MaxDelay time.Duration
+
+	// RetryDelay returns the delay the server asked for before retrying
+	// after resp, or 0 if none; it must leave resp.Body readable (default:
+	// Retry-After, or the reset of an exhausted rate-limit budget)
+	RetryDelay func(resp *http.Response) time.Duration
+
+	// RetryableStatus reports whether a response status is transient
+	// (default: middleware.IsRetryableStatus)
+	RetryableStatus func(statusCode int) bool
+
+	// BeforeAttempt is called before each attempt, e.g. to wait for a rate
+	// limiter; an error aborts the request (optional)
+	BeforeAttempt func(ctx context.Context) error
+
+	// OnAttempt is called before each attempt is sent (optional)
+	OnAttempt func(req *http.Request)
 
-		// Clone request for retry (body can only be read once)
-		reqClone := req.Clone(ctx)
+	// OnResponse is called for each response received, whatever its status (optional)
+	OnResponse func(resp *http.Response, duration time.Duration)
+
+	// SkipDelay reports whether the attempt following a retryable response
+	// may be sent at once, e.g. because it uses a different API key
+	// (optional)
+	SkipDelay func(resp *http.Response) bool
+
+	// OnRetry is called before sleeping for delay ahead of retry number
+	// attempt (1 for the first retry). Exactly one of resp and err is set;
+	// resp.Body is still readable and is closed afterwards (optional).
+	OnRetry func(attempt int, delay time.Duration, resp *http.Response, err error)
+}
+
+// DoRequestWithRetry sends req, retrying network errors and retryable statuses
+// with backoff. The request body is rebuilt from req.GetBody for every attempt
+// (set automatically by http.NewRequest for bytes/strings readers); requests
+// with a body but no GetBody are sent once.
+//
+// It returns the first non-retryable response, or the last response once
+// retries are exhausted or the server asks for a delay beyond MaxDelay,
+// leaving status handling to the caller. An error is returned only when no
+// response was received.
+func (c *HTTPClient) DoRequestWithRetry(ctx context.Context, req *http.Request, policy *RetryPolicy) (*http.Response, error) {
+	maxRetries := policy.MaxRetries
+	hasBody := req.Body != nil && req.Body != http.NoBody
+	if hasBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	retryable := policy.RetryableStatus
+	if retryable == nil {
+		retryable = middleware.IsRetryableStatus
+	}
+	serverDelay := policy.RetryDelay
+	if serverDelay == nil {
+		serverDelay = func(resp *http.Response) time.Duration {
+			return ExtractRateLimitHeaders(resp.Header).RetryDelay()
+		}
+	}
 
-		resp, err := c.DoRequest(ctx, reqClone)
-		if err == nil && resp.StatusCode < 500 && resp.StatusCode != 429 {
-			return resp, nil
+	for attempt := 0; ; attempt++ {
+		// Body can only be read once; rebuild it for each attempt
+		attemptReq := req.Clone(ctx)
+		if hasBody && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
 		}
 
-		// Store error for potential return
+		if policy.BeforeAttempt != nil {
+			if err := policy.BeforeAttempt(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(attemptReq)
+		}
+		start := time.Now()
+		resp, err := c.DoRequest(ctx, attemptReq)
 		if err != nil {
-			lastErr = err
+			if ctx.Err() != nil || attempt >= maxRetries {
+				return nil, err
+			}
 		} else {
-			resp.Body.Close() // Close before retry
-			lastErr = nil
+			if policy.OnResponse != nil {
+				policy.OnResponse(resp, time.Since(start))
+			}
+			if !retryable(resp.StatusCode) || attempt >= maxRetries {
+				return resp, nil
+			}
 		}
 
-		// Don't backoff on last attempt
-		if attempt < maxRetries {
-			delay := backoffFunc(attempt)
-			select {
-			case <-time.After(delay):
-				continue
-			case <-ctx.Done():
-				return nil, ctx.Err()
+		var delay time.Duration
+		if resp == nil || policy.SkipDelay == nil || !policy.SkipDelay(resp) {
+			if policy.Backoff != nil {
+				delay = policy.Backoff(attempt)
+			}
+			if resp != nil {
+				// Use server-provided retry-after, or wait for the exhausted budget to reset
+				if d := serverDelay(resp); d > 0 {
+					if policy.MaxDelay > 0 && d > policy.MaxDelay {
+						return resp, nil
+					}
+					delay = d
+				}
 			}
 		}
-	}
+		if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
 
-	if lastErr != nil {
-		return nil, lastErr
-	}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt+1, delay, resp, err)
+		}
+		if resp != nil {
+			resp.Body.Close() // Close before retry
+		}
 
-	// Retry one more time to get the final response
-	return c.DoRequest(ctx, req)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
 }
@@ -0,0 +1,276 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// reply is a canned response of the test server.
+type reply struct {
+	status  int
+	headers map[string]string
+}
+
+func TestDoRequestWithRetry(t *testing.T) {
+	backoff := func(attempt int) time.Duration {
+		return time.Duration(attempt+1) * 10 * time.Millisecond
+	}
+
+	tests := []struct {
+		name       string
+		replies    []reply
+		maxRetries int
+		maxDelay   time.Duration
+		skipDelay  bool
+		retryDelay func(resp *http.Response) time.Duration
+		noGetBody  bool
+
+		wantStatus   int
+		wantAttempts int
+		wantDelays   []time.Duration
+	}{
+		{
+			name:         "success",
+			replies:      []reply{{status: 200}},
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 1,
+		},
+		{
+			name:         "non-retryable status",
+			replies:      []reply{{status: 400}, {status: 200}},
+			maxRetries:   3,
+			wantStatus:   400,
+			wantAttempts: 1,
+		},
+		{
+			name:         "backoff until success",
+			replies:      []reply{{status: 503}, {status: 502}, {status: 200}},
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 3,
+			wantDelays:   []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		{
+			name:         "retries exhausted",
+			replies:      []reply{{status: 503}, {status: 503}, {status: 503}, {status: 200}},
+			maxRetries:   2,
+			wantStatus:   503,
+			wantAttempts: 3,
+			wantDelays:   []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		{
+			name:         "no retries",
+			replies:      []reply{{status: 503}, {status: 200}},
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+		{
+			name:         "body without GetBody is sent once",
+			replies:      []reply{{status: 503}, {status: 200}},
+			maxRetries:   3,
+			noGetBody:    true,
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+		{
+			name:         "retry-after replaces backoff",
+			replies:      []reply{{status: 429, headers: map[string]string{"retry-after-ms": "5"}}, {status: 200}},
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{5 * time.Millisecond},
+		},
+		{
+			name: "reset of exhausted budget replaces backoff",
+			replies: []reply{{status: 429, headers: map[string]string{
+				"x-ratelimit-limit-requests":     "10",
+				"x-ratelimit-remaining-requests": "0",
+				"x-ratelimit-reset-requests":     "30ms",
+			}}, {status: 200}},
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{30 * time.Millisecond},
+		},
+		{
+			name:         "backoff capped at max delay",
+			replies:      []reply{{status: 503}, {status: 503}, {status: 200}},
+			maxRetries:   3,
+			maxDelay:     15 * time.Millisecond,
+			wantStatus:   200,
+			wantAttempts: 3,
+			wantDelays:   []time.Duration{10 * time.Millisecond, 15 * time.Millisecond},
+		},
+		{
+			name: "token reset beyond max delay returns the response",
+			replies: []reply{{status: 429, headers: map[string]string{
+				"x-ratelimit-limit-tokens":     "30000",
+				"x-ratelimit-remaining-tokens": "0",
+				"x-ratelimit-reset-tokens":     "6m0s",
+			}}, {status: 200}},
+			maxRetries:   3,
+			maxDelay:     time.Second,
+			wantStatus:   429,
+			wantAttempts: 1,
+		},
+		{
+			name:         "skip delay",
+			replies:      []reply{{status: 429, headers: map[string]string{"retry-after": "60"}}, {status: 200}},
+			maxRetries:   3,
+			skipDelay:    true,
+			wantStatus:   200,
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{0},
+		},
+		{
+			name:       "custom retry delay",
+			replies:    []reply{{status: 503}, {status: 200}},
+			maxRetries: 3,
+			retryDelay: func(resp *http.Response) time.Duration {
+				return 7 * time.Millisecond
+			},
+			wantStatus:   200,
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{7 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var served atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if body, _ := io.ReadAll(r.Body); string(body) != "payload" {
+					t.Errorf("attempt body = %q, want %q", body, "payload")
+				}
+				reply := tt.replies[served.Add(1)-1]
+				for key, value := range reply.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(reply.status)
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.noGetBody {
+				req.Body = io.NopCloser(strings.NewReader("payload"))
+				req.GetBody = nil
+			}
+
+			var delays []time.Duration
+			policy := &RetryPolicy{
+				MaxRetries: tt.maxRetries,
+				Backoff:    backoff,
+				MaxDelay:   tt.maxDelay,
+				RetryDelay: tt.retryDelay,
+				SkipDelay: func(resp *http.Response) bool {
+					return tt.skipDelay
+				},
+				OnRetry: func(attempt int, delay time.Duration, resp *http.Response, err error) {
+					if attempt != len(delays)+1 {
+						t.Errorf("OnRetry attempt = %d, want %d", attempt, len(delays)+1)
+					}
+					delays = append(delays, delay)
+				},
+			}
+
+			resp, err := NewHTTPClient(5*time.Second).DoRequestWithRetry(context.Background(), req, policy)
+			if err != nil {
+				t.Fatalf("DoRequestWithRetry() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := int(served.Load()); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if len(delays) != len(tt.wantDelays) {
+				t.Fatalf("delays = %v, want %v", delays, tt.wantDelays)
+			}
+			for i, want := range tt.wantDelays {
+				// Reset-based delays count down from when the header was parsed
+				if delays[i] > want || delays[i] < want-10*time.Millisecond {
+					t.Errorf("delays[%d] = %v, want %v", i, delays[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDoRequestWithRetryErrors(t *testing.T) {
+	t.Run("network errors are retried", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		attempts := 0
+		policy := &RetryPolicy{
+			MaxRetries: 2,
+			OnAttempt: func(*http.Request) {
+				attempts++
+			},
+		}
+
+		if _, err := NewHTTPClient(time.Second).DoRequestWithRetry(context.Background(), req, policy); err == nil {
+			t.Fatal("DoRequestWithRetry() error = nil, want connection error")
+		}
+		if attempts != 3 {
+			t.Errorf("attempts = %d, want 3", attempts)
+		}
+	})
+
+	t.Run("context canceled during the delay", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("retry-after", "60")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		policy := &RetryPolicy{
+			MaxRetries: 3,
+			OnRetry: func(int, time.Duration, *http.Response, error) {
+				time.AfterFunc(10*time.Millisecond, cancel)
+			},
+		}
+
+		start := time.Now()
+		_, err := NewHTTPClient(time.Second).DoRequestWithRetry(ctx, req, policy)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("DoRequestWithRetry() error = %v, want context.Canceled", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("returned after %v, want soon after cancel", elapsed)
+		}
+	})
+
+	t.Run("before attempt error aborts", func(t *testing.T) {
+		errLimited := errors.New("limited")
+		req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:0", nil)
+		policy := &RetryPolicy{
+			MaxRetries: 3,
+			BeforeAttempt: func(context.Context) error {
+				return errLimited
+			},
+		}
+
+		if _, err := NewHTTPClient(time.Second).DoRequestWithRetry(context.Background(), req, policy); !errors.Is(err, errLimited) {
+			t.Errorf("DoRequestWithRetry() error = %v, want %v", err, errLimited)
+		}
+	})
+}
@@ -0,0 +1,256 @@
+// Package output assembles provider replies into aisdk output items and,
+// for streams, the Responses-style events describing them. Providers whose
+// wire format is not shaped like the Responses API feed text and tool calls
+// into a Builder in the order they arrive; streaming and non-streaming
+// responses then share the same item IDs and indexes.
+package output
+
+import (
+	"fmt"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// Output item and content types for reasoning text, which have no
+// dedicated aisdk constants
+const (
+	ItemTypeReasoning    = "reasoning"
+	ContentTypeReasoning = "reasoning_text"
+)
+
+// Kind is the kind of text a text item holds.
+type Kind int
+
+const (
+	// Text is answer text: a message item with an output_text part
+	Text Kind = iota
+
+	// Refusal is refusal text: a message item with a refusal part
+	Refusal
+
+	// Reasoning is reasoning or thinking text: a reasoning item
+	Reasoning
+)
+
+// Builder accumulates output items. Consecutive text of the same kind
+// shares an item; any other text or a tool call closes it. When streaming,
+// every change is also queued as a stream event for Pop.
+type Builder struct {
+	responseID string
+	stream     bool
+
+	items  []aisdk.OutputItem
+	kinds  []Kind
+	closed []bool
+
+	// openText is the index of the text item still receiving text, or -1
+	openText int
+
+	events   []*aisdk.StreamEvent
+	sequence int
+}
+
+// NewBuilder creates a Builder for the response with the given ID. When
+// stream is set, events are queued for Pop.
+func NewBuilder(responseID string, stream bool) *Builder {
+	return &Builder{responseID: responseID, stream: stream, openText: -1}
+}
+
+// ResponseID returns the ID of the response being built.
+func (b *Builder) ResponseID() string {
+	return b.responseID
+}
+
+// Len returns the number of items.
+func (b *Builder) Len() int {
+	return len(b.items)
+}
+
+// Text appends text of the given kind and returns the index of its item.
+// Empty text is ignored and returns -1.
+func (b *Builder) Text(kind Kind, text string) int {
+	if text == "" {
+		return -1
+	}
+
+	index := b.openText
+	if index < 0 || b.kinds[index] != kind {
+		b.closeText()
+
+		index = len(b.items)
+		item := aisdk.OutputItem{
+			ID:      fmt.Sprintf("%s_%d", b.responseID, index),
+			Type:    aisdk.OutputItemTypeMessage,
+			Role:    aisdk.RoleAssistant,
+			Status:  "in_progress",
+			Content: []aisdk.ContentPart{{}},
+		}
+		switch kind {
+		case Refusal:
+			item.Content[0].Type = aisdk.ContentTypeRefusal
+		case Reasoning:
+			item.Type, item.Role = ItemTypeReasoning, ""
+			item.Content[0].Type = ContentTypeReasoning
+		default:
+			item.Content[0].Type = aisdk.ContentTypeOutputText
+		}
+		b.append(item, kind)
+		b.openText = index
+	}
+
+	part := &b.items[index].Content[0]
+	eventType := aisdk.EventOutputTextDelta
+	switch kind {
+	case Refusal:
+		part.Refusal += text
+		eventType = aisdk.EventRefusalDelta
+	case Reasoning:
+		part.Text += text
+		eventType = aisdk.EventReasoningSummaryTextDelta
+	default:
+		part.Text += text
+	}
+	b.Emit(&aisdk.StreamEvent{Type: eventType, ItemID: b.items[index].ID, OutputIndex: index, Delta: text})
+	return index
+}
+
+// StartCall opens a function call item whose arguments follow through
+// Arguments, and returns its index. An empty id is replaced with a
+// generated one (see Item for the final CallID).
+func (b *Builder) StartCall(id, name string) int {
+	b.closeText()
+
+	index := len(b.items)
+	if id == "" {
+		id = fmt.Sprintf("%s_call_%d", b.responseID, index)
+	}
+	b.append(aisdk.OutputItem{
+		ID:     id,
+		Type:   aisdk.OutputItemTypeFunctionCall,
+		Status: "in_progress",
+		CallID: id,
+		Name:   name,
+	}, Text)
+	return index
+}
+
+// Arguments appends a fragment of a function call's JSON arguments.
+func (b *Builder) Arguments(index int, delta string) {
+	if delta == "" || index < 0 || index >= len(b.items) {
+		return
+	}
+	b.items[index].Arguments += delta
+	b.Emit(&aisdk.StreamEvent{Type: aisdk.EventFunctionCallArgumentsDelta, ItemID: b.items[index].ID, OutputIndex: index, Delta: delta})
+}
+
+// Call appends a complete function call and returns its index.
+func (b *Builder) Call(id, name, arguments string) int {
+	index := b.StartCall(id, name)
+	b.Arguments(index, arguments)
+	b.Close(index)
+	return index
+}
+
+// Close completes an item, queuing its *.done and output_item.done events.
+// Closing an item twice has no effect.
+func (b *Builder) Close(index int) {
+	if index < 0 || index >= len(b.items) || b.closed[index] {
+		return
+	}
+	b.closed[index] = true
+	if b.openText == index {
+		b.openText = -1
+	}
+
+	item := &b.items[index]
+	if item.Type == aisdk.OutputItemTypeFunctionCall && item.Arguments == "" {
+		item.Arguments = "{}"
+	}
+
+	if b.stream {
+		done := b.Item(index)
+		done.Status = "completed"
+
+		switch {
+		case done.Type == aisdk.OutputItemTypeFunctionCall:
+			b.Emit(&aisdk.StreamEvent{Type: aisdk.EventFunctionCallArgumentsDone, ItemID: done.ID, OutputIndex: index, Text: done.Arguments})
+		case b.kinds[index] == Refusal:
+			b.Emit(&aisdk.StreamEvent{Type: aisdk.EventRefusalDone, ItemID: done.ID, OutputIndex: index, Text: done.Content[0].Refusal})
+		case b.kinds[index] == Reasoning:
+			b.Emit(&aisdk.StreamEvent{Type: aisdk.EventReasoningSummaryTextDone, ItemID: done.ID, OutputIndex: index, Text: done.Content[0].Text})
+		default:
+			b.Emit(&aisdk.StreamEvent{Type: aisdk.EventOutputTextDone, ItemID: done.ID, OutputIndex: index, Text: done.Content[0].Text})
+		}
+		b.Emit(&aisdk.StreamEvent{Type: aisdk.EventOutputItemDone, ItemID: done.ID, OutputIndex: index, Output: &done})
+	}
+}
+
+// CloseAll completes every open item.
+func (b *Builder) CloseAll() {
+	for i := range b.items {
+		b.Close(i)
+	}
+}
+
+// Item returns a copy of the item at index.
+func (b *Builder) Item(index int) aisdk.OutputItem {
+	item := b.items[index]
+	item.Content = append([]aisdk.ContentPart(nil), item.Content...)
+	return item
+}
+
+// Output returns copies of all items with the given final status
+// ("completed" or "incomplete").
+func (b *Builder) Output(status string) []aisdk.OutputItem {
+	out := make([]aisdk.OutputItem, len(b.items))
+	for i := range b.items {
+		out[i] = b.Item(i)
+		out[i].Status = status
+		if out[i].Type == aisdk.OutputItemTypeFunctionCall && out[i].Arguments == "" {
+			out[i].Arguments = "{}"
+		}
+	}
+	return out
+}
+
+// Emit queues a stream event, stamping its sequence number and response ID.
+// It does nothing unless the Builder is streaming.
+func (b *Builder) Emit(event *aisdk.StreamEvent) {
+	if !b.stream {
+		return
+	}
+	event.SequenceNumber = b.sequence
+	event.ResponseID = b.responseID
+	b.sequence++
+	b.events = append(b.events, event)
+}
+
+// Pop removes and returns the oldest queued event, or nil if there is none.
+func (b *Builder) Pop() *aisdk.StreamEvent {
+	if len(b.events) == 0 {
+		return nil
+	}
+	event := b.events[0]
+	b.events = b.events[1:]
+	return event
+}
+
+// append adds a new item and queues its output_item.added event.
+func (b *Builder) append(item aisdk.OutputItem, kind Kind) {
+	index := len(b.items)
+	b.items = append(b.items, item)
+	b.kinds = append(b.kinds, kind)
+	b.closed = append(b.closed, false)
+
+	if b.stream {
+		added := b.Item(index)
+		b.Emit(&aisdk.StreamEvent{Type: aisdk.EventOutputItemAdded, ItemID: added.ID, OutputIndex: index, Output: &added})
+	}
+}
+
+// closeText closes the open text item, if any.
+func (b *Builder) closeText() {
+	if b.openText >= 0 {
+		b.Close(b.openText)
+	}
+}
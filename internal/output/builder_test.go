@@ -0,0 +1,85 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// popAll drains the queued events of b.
+func popAll(b *Builder) []*aisdk.StreamEvent {
+	var events []*aisdk.StreamEvent
+	for event := b.Pop(); event != nil; event = b.Pop() {
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestBuilderOutput(t *testing.T) {
+	b := NewBuilder("resp_1", false)
+	b.Text(Reasoning, "Compare ")
+	b.Text(Reasoning, "routes")
+	b.Text(Text, "Take ")
+	b.Text(Text, "the A1")
+	b.Text(Text, "")
+	call := b.StartCall("", "get_traffic")
+	b.Arguments(call, `{"road":`)
+	b.Arguments(call, `"A1"}`)
+	b.Call("call_2", "get_weather", "")
+	b.Text(Refusal, "No more.")
+	b.CloseAll()
+
+	got := b.Output("completed")
+	want := []aisdk.OutputItem{
+		{ID: "resp_1_0", Type: ItemTypeReasoning, Status: "completed", Content: []aisdk.ContentPart{{Type: ContentTypeReasoning, Text: "Compare routes"}}},
+		{ID: "resp_1_1", Type: aisdk.OutputItemTypeMessage, Role: aisdk.RoleAssistant, Status: "completed", Content: []aisdk.ContentPart{{Type: aisdk.ContentTypeOutputText, Text: "Take the A1"}}},
+		{ID: "resp_1_call_2", Type: aisdk.OutputItemTypeFunctionCall, Status: "completed", CallID: "resp_1_call_2", Name: "get_traffic", Arguments: `{"road":"A1"}`},
+		{ID: "call_2", Type: aisdk.OutputItemTypeFunctionCall, Status: "completed", CallID: "call_2", Name: "get_weather", Arguments: "{}"},
+		{ID: "resp_1_4", Type: aisdk.OutputItemTypeMessage, Role: aisdk.RoleAssistant, Status: "completed", Content: []aisdk.ContentPart{{Type: aisdk.ContentTypeRefusal, Refusal: "No more."}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Output() =\n%+v\nwant\n%+v", got, want)
+	}
+	if events := popAll(b); len(events) != 0 {
+		t.Errorf("non-streaming builder queued %d events", len(events))
+	}
+}
+
+func TestBuilderStreamEvents(t *testing.T) {
+	b := NewBuilder("resp_1", true)
+	b.Text(Text, "Hel")
+	b.Text(Text, "lo")
+	b.Call("call_1", "get_time", "{}")
+	b.Close(0)
+	b.CloseAll()
+
+	events := popAll(b)
+	var types []string
+	for i, event := range events {
+		types = append(types, event.Type)
+		if event.SequenceNumber != i || event.ResponseID != "resp_1" {
+			t.Errorf("event %d SequenceNumber = %d, ResponseID = %q", i, event.SequenceNumber, event.ResponseID)
+		}
+	}
+	want := []string{
+		aisdk.EventOutputItemAdded,
+		aisdk.EventOutputTextDelta,
+		aisdk.EventOutputTextDelta,
+		aisdk.EventOutputTextDone,
+		aisdk.EventOutputItemDone,
+		aisdk.EventOutputItemAdded,
+		aisdk.EventFunctionCallArgumentsDelta,
+		aisdk.EventFunctionCallArgumentsDone,
+		aisdk.EventOutputItemDone,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("event types = %v, want %v", types, want)
+	}
+	if done := events[3]; done.Text != "Hello" || done.OutputIndex != 0 {
+		t.Errorf("output_text.done = %+v, want Hello at index 0", done)
+	}
+	if done := events[4].Output; done.Status != "completed" || done.Content[0].Text != "Hello" {
+		t.Errorf("output_item.done item = %+v, want the completed message", done)
+	}
+}
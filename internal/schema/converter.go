@@ -1,16 +1,78 @@
 package schema
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Options controls JSON Schema generation.
+type Options struct {
+	// Strict produces schemas accepted by OpenAI Structured Outputs strict mode:
+	// every object sets additionalProperties: false, every property is listed in
+	// required, and optional fields (omitempty or pointer) are expressed as
+	// anyOf with {"type": "null"}. The result is checked with CheckStrict.
+	// Reference: docs/providers/openai.md lines 3459-3720 (supported schemas)
+	Strict bool
+}
+
+// Schemaer is implemented by types that provide their own JSON Schema.
+// It takes precedence over reflection, json.Marshaler and encoding.TextMarshaler.
+type Schemaer interface {
+	JSONSchema() map[string]interface{}
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	schemaerType      = reflect.TypeOf((*Schemaer)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// generator carries options and recursion state through schema conversion.
+type generator struct {
+	opts Options
+
+	// root is the top-level struct type; recursive references to it use "#"
+	root reflect.Type
+
+	// inProgress holds struct types currently being converted (cycle detection)
+	inProgress map[reflect.Type]bool
+
+	// defNames maps recursive struct types to their $defs name
+	defNames map[reflect.Type]string
+
+	// defs holds the schemas of recursive struct types
+	defs map[string]interface{}
+}
+
 // StructToJSONSchema converts a Go struct to JSON Schema format using reflection.
-// Supports basic types (string, int, float, bool), nested structs, and arrays.
+// Supports basic types (string, int, float, bool), nested and embedded structs,
+// arrays, map[string]T, time.Time, json.RawMessage, marshaler types and
+// recursive types (via $defs/$ref).
 // Reference: research.md decision #2 (Go struct tags → JSON Schema conversion)
 func StructToJSONSchema(v interface{}) (map[string]interface{}, error) {
+	return StructToJSONSchemaWithOptions(v, Options{})
+}
+
+// StrictStructToJSONSchema converts a Go struct to a strict-mode JSON Schema.
+// Equivalent to StructToJSONSchemaWithOptions(v, Options{Strict: true}).
+func StrictStructToJSONSchema(v interface{}) (map[string]interface{}, error) {
+	return StructToJSONSchemaWithOptions(v, Options{Strict: true})
+}
+
+// StructToJSONSchemaWithOptions converts a Go struct to JSON Schema format
+// using the given options.
+func StructToJSONSchemaWithOptions(v interface{}, opts Options) (map[string]interface{}, error) {
 	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("expected struct type, got nil")
+	}
 
 	// Handle pointer types
 	if t.Kind() == reflect.Ptr {
@@ -21,6 +83,118 @@ func StructToJSONSchema(v interface{}) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("expected struct type, got %v", t.Kind())
 	}
 
+	g := &generator{
+		opts:       opts,
+		root:       t,
+		inProgress: make(map[reflect.Type]bool),
+		defNames:   make(map[reflect.Type]string),
+		defs:       make(map[string]interface{}),
+	}
+	schema, err := g.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+
+	if opts.Strict {
+		if err := CheckStrict(schema); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// structField is a JSON-visible field, possibly promoted from an embedded struct.
+type structField struct {
+	name     string
+	field    reflect.StructField
+	optional bool
+}
+
+// collectFields returns t's JSON-visible fields in declaration order.
+// Fields of untagged embedded structs are promoted; as in encoding/json, a
+// shallower field hides a deeper one with the same name.
+func collectFields(t reflect.Type) []structField {
+	var fields []structField
+	depths := make(map[string]int)
+
+	var walk func(t reflect.Type, depth int, visited map[reflect.Type]bool)
+	walk = func(t reflect.Type, depth int, visited map[reflect.Type]bool) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		defer delete(visited, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			jsonTag := field.Tag.Get("json")
+
+			// Promote fields of untagged embedded structs
+			if field.Anonymous && jsonTag == "" {
+				ft := field.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, depth+1, visited)
+					continue
+				}
+			}
+
+			// Skip unexported fields
+			if !field.IsExported() {
+				continue
+			}
+
+			// Get JSON tag for field name
+			if jsonTag == "" || jsonTag == "-" {
+				continue
+			}
+
+			// Parse JSON tag (handle omitempty)
+			name := strings.Split(jsonTag, ",")[0]
+			if name == "" {
+				name = field.Name
+			}
+
+			if d, seen := depths[name]; seen {
+				if d <= depth {
+					continue
+				}
+				// Shallower field replaces the promoted one
+				for j := range fields {
+					if fields[j].name == name {
+						fields = append(fields[:j], fields[j+1:]...)
+						break
+					}
+				}
+			}
+			depths[name] = depth
+			fields = append(fields, structField{
+				name:     name,
+				field:    field,
+				optional: strings.Contains(jsonTag, "omitempty"),
+			})
+		}
+	}
+	walk(t, 0, make(map[reflect.Type]bool))
+
+	return fields
+}
+
+// structSchema converts a struct type to an object schema, or to a $ref if
+// the type is already being converted further up the stack.
+func (g *generator) structSchema(t reflect.Type) (map[string]interface{}, error) {
+	if g.inProgress[t] {
+		return g.ref(t), nil
+	}
+	g.inProgress[t] = true
+	defer delete(g.inProgress, t)
+
 	schema := map[string]interface{}{
 		"type":       "object",
 		"properties": make(map[string]interface{}),
@@ -30,44 +204,42 @@ func StructToJSONSchema(v interface{}) (map[string]interface{}, error) {
 	properties := schema["properties"].(map[string]interface{})
 	required := []string{}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
+	for _, sf := range collectFields(t) {
+		field := sf.field
 
-		// Skip unexported fields
-		if !field.IsExported() {
-			continue
+		// Get field schema
+		fieldSchema, err := g.typeSchema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("error converting field %s: %w", field.Name, err)
 		}
 
-		// Get JSON tag for field name
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" || jsonTag == "-" {
-			continue
+		tag, err := parseTag(field.Tag.Get("jsonschema"))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jsonschema tag on field %s: %w", field.Name, err)
 		}
 
-		// Parse JSON tag (handle omitempty)
-		fieldName := strings.Split(jsonTag, ",")[0]
-
-		// Get field schema
-		fieldSchema, err := typeToJSONSchema(field.Type)
-		if err != nil {
-			return nil, fmt.Errorf("error converting field %s: %w", field.Name, err)
+		// Value constraints apply to the field type (array items for slices)
+		if err := tag.applyConstraints(fieldSchema); err != nil {
+			return nil, fmt.Errorf("error applying jsonschema tag on field %s: %w", field.Name, err)
 		}
 
-		// Check for description in jsonschema tag
-		if desc := field.Tag.Get("jsonschema"); desc != "" {
-			parts := strings.Split(desc, ",")
-			for _, part := range parts {
-				if strings.HasPrefix(part, "description=") {
-					fieldSchema["description"] = strings.TrimPrefix(part, "description=")
-				}
+		// Strict mode requires every field; optional ones become nullable
+		if g.opts.Strict && (sf.optional || field.Type.Kind() == reflect.Ptr) {
+			fieldSchema = map[string]interface{}{
+				"anyOf": []interface{}{fieldSchema, map[string]interface{}{"type": "null"}},
 			}
 		}
 
-		properties[fieldName] = fieldSchema
+		// Annotations (description, default, examples) describe the property
+		if err := tag.applyAnnotations(fieldSchema, field.Type); err != nil {
+			return nil, fmt.Errorf("error applying jsonschema tag on field %s: %w", field.Name, err)
+		}
+
+		properties[sf.name] = fieldSchema
 
-		// Check if field is required (no omitempty tag)
-		if !strings.Contains(jsonTag, "omitempty") {
-			required = append(required, fieldName)
+		// Check if field is required (no omitempty tag, or strict mode)
+		if g.opts.Strict || !sf.optional {
+			required = append(required, sf.name)
 		}
 	}
 
@@ -75,16 +247,80 @@ func StructToJSONSchema(v interface{}) (map[string]interface{}, error) {
 		schema["required"] = required
 	}
 
+	if g.opts.Strict {
+		schema["additionalProperties"] = false
+	}
+
+	// A recursive type is emitted once under $defs and referenced everywhere
+	if name, ok := g.defNames[t]; ok && t != g.root {
+		g.defs[name] = schema
+		return g.ref(t), nil
+	}
+
 	return schema, nil
 }
 
-// typeToJSONSchema converts a Go type to its JSON Schema representation
-func typeToJSONSchema(t reflect.Type) (map[string]interface{}, error) {
+// ref returns a $ref to t, registering a $defs name for it if needed.
+// The root type is referenced as "#".
+func (g *generator) ref(t reflect.Type) map[string]interface{} {
+	if t == g.root {
+		g.defNames[t] = ""
+		return map[string]interface{}{"$ref": "#"}
+	}
+
+	name, ok := g.defNames[t]
+	if !ok {
+		name = t.Name()
+		if name == "" {
+			name = "Anonymous"
+		}
+		// Disambiguate same-named types from different packages
+		base := name
+		for i := 2; g.nameTaken(name); i++ {
+			name = base + strconv.Itoa(i)
+		}
+		g.defNames[t] = name
+	}
+	return map[string]interface{}{"$ref": "#/$defs/" + name}
+}
+
+// nameTaken reports whether a $defs name is already assigned.
+func (g *generator) nameTaken(name string) bool {
+	for _, n := range g.defNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// typeSchema converts a Go type to its JSON Schema representation
+func (g *generator) typeSchema(t reflect.Type) (map[string]interface{}, error) {
 	// Handle pointer types
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
 
+	// Special-cased types take precedence over their Kind
+	switch {
+	case t.Implements(schemaerType) || reflect.PointerTo(t).Implements(schemaerType):
+		return reflect.New(t).Interface().(Schemaer).JSONSchema(), nil
+
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+
+	case t == rawMessageType:
+		return g.anySchema(t)
+
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// Custom JSON encoding: the shape cannot be inferred
+		return g.anySchema(t)
+
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		// encoding/json encodes TextMarshalers as strings
+		return map[string]interface{}{"type": "string"}, nil
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		return map[string]interface{}{"type": "string"}, nil
@@ -100,7 +336,11 @@ func typeToJSONSchema(t reflect.Type) (map[string]interface{}, error) {
 		return map[string]interface{}{"type": "boolean"}, nil
 
 	case reflect.Slice, reflect.Array:
-		elemSchema, err := typeToJSONSchema(t.Elem())
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes []byte as a base64 string
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		elemSchema, err := g.typeSchema(t.Elem())
 		if err != nil {
 			return nil, err
 		}
@@ -109,11 +349,39 @@ func typeToJSONSchema(t reflect.Type) (map[string]interface{}, error) {
 			"items": elemSchema,
 		}, nil
 
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type: %v (only string keys are supported)", t.Key().Kind())
+		}
+		if g.opts.Strict {
+			return nil, fmt.Errorf("%w: map %v requires additionalProperties, which strict mode does not allow", ErrStrictSchema, t)
+		}
+		valueSchema, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": valueSchema,
+		}, nil
+
+	case reflect.Interface:
+		return g.anySchema(t)
+
 	case reflect.Struct:
 		// Recursively convert nested struct
-		return StructToJSONSchema(reflect.New(t).Interface())
+		return g.structSchema(t)
 
 	default:
 		return nil, fmt.Errorf("unsupported type: %v", t.Kind())
 	}
 }
+
+// anySchema returns the unconstrained schema {} used for values of unknown
+// shape. Strict mode rejects these since every value must have a type.
+func (g *generator) anySchema(t reflect.Type) (map[string]interface{}, error) {
+	if g.opts.Strict {
+		return nil, fmt.Errorf("%w: %v has no fixed schema; implement JSONSchema() to describe it", ErrStrictSchema, t)
+	}
+	return map[string]interface{}{}, nil
+}
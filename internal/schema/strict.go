@@ -0,0 +1,223 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Strict-mode limits.
+// Reference: docs/providers/openai.md lines 3653-3665
+const (
+	// MaxStrictDepth is the maximum object nesting depth
+	MaxStrictDepth = 10
+
+	// MaxStrictProperties is the maximum number of object properties in total
+	MaxStrictProperties = 5000
+
+	// MaxStrictEnumValues is the maximum number of enum values in total
+	MaxStrictEnumValues = 1000
+
+	// MaxStrictStringLength is the maximum total length of property names,
+	// definition names, enum values and const values
+	MaxStrictStringLength = 120000
+
+	// MaxStrictLargeEnumLength is the maximum total string length of a single
+	// enum with more than strictLargeEnumThreshold values
+	MaxStrictLargeEnumLength = 15000
+
+	strictLargeEnumThreshold = 250
+)
+
+// ErrStrictSchema indicates a schema that OpenAI strict mode would reject.
+var ErrStrictSchema = errors.New("schema is not strict-mode compliant")
+
+// unsupportedStrictKeywords are composition keywords strict mode rejects.
+// The keywords OpenAI rejects only for fine-tuned models (patternProperties,
+// string and number constraints, array bounds) are not checked: the model
+// is not known here, and the generator emits some of them from tags.
+var unsupportedStrictKeywords = []string{"allOf", "not", "if", "then", "else", "dependentRequired", "dependentSchemas"}
+
+// strictChecker accumulates strict-mode violations while walking a schema.
+type strictChecker struct {
+	violations   []error
+	properties   int
+	enumValues   int
+	stringLength int
+}
+
+// CheckStrict reports every way schema violates OpenAI Structured Outputs
+// strict-mode rules: root must be a non-anyOf object, every object must set
+// additionalProperties: false and require all its properties, unsupported
+// keywords are rejected, and the depth, property, enum and string-size
+// limits are enforced. Violations are joined and each wraps ErrStrictSchema.
+func CheckStrict(schema map[string]interface{}) error {
+	c := &strictChecker{}
+
+	if _, ok := schema["anyOf"]; ok {
+		c.addf("#", "root schema must not use anyOf")
+	}
+	if !hasType(schema, "object") {
+		c.addf("#", "root schema must be an object")
+	}
+
+	c.walk(schema, "#", 0)
+
+	for _, key := range []string{"$defs", "definitions"} {
+		defs, _ := schema[key].(map[string]interface{})
+		for _, name := range sortedKeys(defs) {
+			c.stringLength += len(name)
+			if def, ok := defs[name].(map[string]interface{}); ok {
+				c.walk(def, "#/"+key+"/"+name, 0)
+			}
+		}
+	}
+
+	if c.properties > MaxStrictProperties {
+		c.addf("#", "schema has %d object properties (max %d)", c.properties, MaxStrictProperties)
+	}
+	if c.enumValues > MaxStrictEnumValues {
+		c.addf("#", "schema has %d enum values (max %d)", c.enumValues, MaxStrictEnumValues)
+	}
+	if c.stringLength > MaxStrictStringLength {
+		c.addf("#", "total string length %d exceeds %d", c.stringLength, MaxStrictStringLength)
+	}
+
+	return errors.Join(c.violations...)
+}
+
+// walk checks node and its subschemas; depth counts enclosing objects.
+func (c *strictChecker) walk(node map[string]interface{}, path string, depth int) {
+	for _, keyword := range unsupportedStrictKeywords {
+		if _, ok := node[keyword]; ok {
+			c.addf(path, "keyword %q is not supported", keyword)
+		}
+	}
+
+	if hasType(node, "object") {
+		depth++
+		if depth == MaxStrictDepth+1 {
+			c.addf(path, "nesting depth exceeds %d", MaxStrictDepth)
+		}
+		if ap, ok := node["additionalProperties"].(bool); !ok || ap {
+			c.addf(path, "additionalProperties must be false")
+		}
+
+		props, _ := node["properties"].(map[string]interface{})
+		required := stringSet(node["required"])
+		for _, name := range sortedKeys(props) {
+			c.properties++
+			c.stringLength += len(name)
+			if !required[name] {
+				c.addf(path+"/properties/"+name, "property must be listed in required")
+			}
+			if prop, ok := props[name].(map[string]interface{}); ok {
+				c.walk(prop, path+"/properties/"+name, depth)
+			}
+		}
+	}
+
+	if enum, ok := node["enum"].([]interface{}); ok {
+		c.checkEnum(enum, path)
+	} else if enum, ok := node["enum"].([]string); ok {
+		values := make([]interface{}, len(enum))
+		for i, v := range enum {
+			values[i] = v
+		}
+		c.checkEnum(values, path)
+	}
+
+	if s, ok := node["const"].(string); ok {
+		c.stringLength += len(s)
+	}
+
+	if items, ok := node["items"].(map[string]interface{}); ok {
+		c.walk(items, path+"/items", depth)
+	}
+
+	patterns, _ := node["patternProperties"].(map[string]interface{})
+	for _, pattern := range sortedKeys(patterns) {
+		if s, ok := patterns[pattern].(map[string]interface{}); ok {
+			c.walk(s, path+"/patternProperties/"+pattern, depth)
+		}
+	}
+
+	if anyOf, ok := node["anyOf"].([]interface{}); ok {
+		for i, sub := range anyOf {
+			if s, ok := sub.(map[string]interface{}); ok {
+				c.walk(s, fmt.Sprintf("%s/anyOf/%d", path, i), depth)
+			}
+		}
+	}
+}
+
+// checkEnum applies the enum count and size limits.
+func (c *strictChecker) checkEnum(values []interface{}, path string) {
+	c.enumValues += len(values)
+
+	length := 0
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			length += len(s)
+		}
+	}
+	c.stringLength += length
+
+	if len(values) > strictLargeEnumThreshold && length > MaxStrictLargeEnumLength {
+		c.addf(path, "enum with %d values has total string length %d (max %d)", len(values), length, MaxStrictLargeEnumLength)
+	}
+}
+
+// addf records a violation at path.
+func (c *strictChecker) addf(path, format string, args ...interface{}) {
+	c.violations = append(c.violations, fmt.Errorf("%w: %s: %s", ErrStrictSchema, path, fmt.Sprintf(format, args...)))
+}
+
+// hasType reports whether node's "type" is, or includes, typ.
+func hasType(node map[string]interface{}, typ string) bool {
+	switch t := node["type"].(type) {
+	case string:
+		return t == typ
+	case []string:
+		for _, s := range t {
+			if s == typ {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, s := range t {
+			if s == typ {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringSet converts a []string or []interface{} of strings to a set.
+func stringSet(v interface{}) map[string]bool {
+	set := make(map[string]bool)
+	switch list := v.(type) {
+	case []string:
+		for _, s := range list {
+			set[s] = true
+		}
+	case []interface{}:
+		for _, s := range list {
+			if str, ok := s.(string); ok {
+				set[str] = true
+			}
+		}
+	}
+	return set
+}
+
+// sortedKeys returns the keys of m in sorted order for deterministic output.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
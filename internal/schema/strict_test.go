@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckStrict(t *testing.T) {
+	object := func(properties map[string]interface{}) map[string]interface{} {
+		required := make([]interface{}, 0, len(properties))
+		for _, name := range sortedKeys(properties) {
+			required = append(required, name)
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		schema map[string]interface{}
+		want   []string
+	}{
+		{
+			name:   "compliant",
+			schema: object(map[string]interface{}{"city": map[string]interface{}{"type": "string", "pattern": "^[A-Z]"}}),
+		},
+		{
+			name: "patternProperties are walked",
+			schema: withKeyword(object(nil), "patternProperties", map[string]interface{}{
+				"^x-": map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+			}),
+			want: []string{"#/patternProperties/^x-: additionalProperties must be false"},
+		},
+		{
+			name:   "composition keyword",
+			schema: withKeyword(object(nil), "not", map[string]interface{}{"type": "string"}),
+			want:   []string{`#: keyword "not" is not supported`},
+		},
+		{
+			name: "optional property and open object",
+			schema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"city": map[string]interface{}{"type": "string"}},
+			},
+			want: []string{"#: additionalProperties must be false", "#/properties/city: property must be listed in required"},
+		},
+		{
+			name:   "root is not an object",
+			schema: map[string]interface{}{"type": "string"},
+			want:   []string{"#: root schema must be an object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStrict(tt.schema)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("CheckStrict() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrStrictSchema) {
+				t.Fatalf("CheckStrict() error = %v, want ErrStrictSchema", err)
+			}
+			if got := strings.Count(err.Error(), ErrStrictSchema.Error()); got != len(tt.want) {
+				t.Errorf("CheckStrict() reported %d violations, want %d: %v", got, len(tt.want), err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("CheckStrict() error = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+// withKeyword sets keyword on schema and returns it.
+func withKeyword(schema map[string]interface{}, keyword string, value interface{}) map[string]interface{} {
+	schema[keyword] = value
+	return schema
+}
@@ -0,0 +1,241 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fieldTag holds the parsed `jsonschema` struct tag of a field.
+//
+// Entries are comma-separated; a comma inside a value is escaped as `\,`
+// ("description=City\, e.g. Paris"). Unknown keys are errors. Supported
+// keys:
+//
+//	description=text    property description
+//	enum=value          allowed value (repeat for each value)
+//	minimum=number      inclusive lower bound
+//	maximum=number      inclusive upper bound
+//	pattern=regexp      regular expression strings must match
+//	format=name         string format (date-time, email, uuid, ...)
+//	default=value       default value
+//	examples=value      example value (repeat for each example)
+//
+// Enum, default and example values are converted to the field's JSON type.
+// The valueless flag "required" is accepted and ignored: fields are
+// required unless their json tag has omitempty.
+type fieldTag struct {
+	description string
+	enum        []string
+	minimum     *float64
+	maximum     *float64
+	pattern     string
+	format      string
+	def         *string
+	examples    []string
+}
+
+// tagKeys are the keys a `jsonschema` tag entry may start with.
+var tagKeys = map[string]bool{
+	"description": true, "enum": true, "minimum": true, "maximum": true,
+	"pattern": true, "format": true, "default": true, "examples": true,
+}
+
+// tagFlags are valueless entries, accepted for compatibility and ignored.
+var tagFlags = map[string]bool{"required": true}
+
+// parseTag parses a `jsonschema` struct tag.
+func parseTag(tag string) (*fieldTag, error) {
+	ft := &fieldTag{}
+	if tag == "" {
+		return ft, nil
+	}
+
+	entries, err := splitTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		key, value := entry[0], entry[1]
+		switch key {
+		case "description":
+			ft.description = value
+		case "enum":
+			ft.enum = append(ft.enum, value)
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number: %w", key, err)
+			}
+			if key == "minimum" {
+				ft.minimum = &n
+			} else {
+				ft.maximum = &n
+			}
+		case "pattern":
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, fmt.Errorf("invalid pattern: %w", err)
+			}
+			ft.pattern = value
+		case "format":
+			ft.format = value
+		case "default":
+			ft.def = &value
+		case "examples":
+			ft.examples = append(ft.examples, value)
+		}
+	}
+	return ft, nil
+}
+
+// splitTag splits a tag into key/value entries on commas, honoring `\,`
+// escapes. Flags are dropped.
+func splitTag(tag string) ([][2]string, error) {
+	var parts []string
+	var cur strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			cur.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(tag[i])
+		}
+	}
+	parts = append(parts, cur.String())
+
+	entries := make([][2]string, 0, len(parts))
+	for _, part := range parts {
+		if tagFlags[part] {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed entry %q (expected key=value; escape commas in values as \\,)", part)
+		}
+		if !tagKeys[key] {
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+		entries = append(entries, [2]string{key, value})
+	}
+	return entries, nil
+}
+
+// applyConstraints adds enum, bounds, pattern and format to the value schema.
+// For arrays the constraints apply to the items.
+func (ft *fieldTag) applyConstraints(schema map[string]interface{}) error {
+	target := schema
+	if items, ok := schema["items"].(map[string]interface{}); ok && schema["type"] == "array" {
+		target = items
+	}
+	typ, _ := target["type"].(string)
+
+	if len(ft.enum) > 0 {
+		values := make([]interface{}, len(ft.enum))
+		for i, raw := range ft.enum {
+			v, err := convertValue(raw, typ)
+			if err != nil {
+				return fmt.Errorf("enum: %w", err)
+			}
+			values[i] = v
+		}
+		target["enum"] = values
+	}
+
+	if ft.minimum != nil || ft.maximum != nil {
+		if typ != "integer" && typ != "number" {
+			return fmt.Errorf("minimum/maximum require a numeric field (got %q)", typ)
+		}
+		if ft.minimum != nil {
+			target["minimum"] = *ft.minimum
+		}
+		if ft.maximum != nil {
+			target["maximum"] = *ft.maximum
+		}
+	}
+
+	if ft.pattern != "" || ft.format != "" {
+		if typ != "string" {
+			return fmt.Errorf("pattern/format require a string field (got %q)", typ)
+		}
+		if ft.pattern != "" {
+			target["pattern"] = ft.pattern
+		}
+		if ft.format != "" {
+			target["format"] = ft.format
+		}
+	}
+
+	return nil
+}
+
+// applyAnnotations adds description, default and examples to the property schema.
+func (ft *fieldTag) applyAnnotations(schema map[string]interface{}, t reflect.Type) error {
+	if ft.description != "" {
+		schema["description"] = ft.description
+	}
+
+	typ := jsonTypeOf(t)
+	if ft.def != nil {
+		v, err := convertValue(*ft.def, typ)
+		if err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+		schema["default"] = v
+	}
+
+	if len(ft.examples) > 0 {
+		values := make([]interface{}, len(ft.examples))
+		for i, raw := range ft.examples {
+			v, err := convertValue(raw, typ)
+			if err != nil {
+				return fmt.Errorf("examples: %w", err)
+			}
+			values[i] = v
+		}
+		schema["examples"] = values
+	}
+
+	return nil
+}
+
+// jsonTypeOf returns the JSON type name for scalar Go kinds, or "" otherwise.
+func jsonTypeOf(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	default:
+		return ""
+	}
+}
+
+// convertValue converts a tag value to the given JSON type.
+// Non-scalar types keep the raw string.
+func convertValue(raw, typ string) (interface{}, error) {
+	switch typ {
+	case "integer":
+		return strconv.ParseInt(raw, 10, 64)
+	case "number":
+		return strconv.ParseFloat(raw, 64)
+	case "boolean":
+		return strconv.ParseBool(raw)
+	default:
+		return raw, nil
+	}
+}
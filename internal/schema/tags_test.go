@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		want    *fieldTag
+		wantErr string
+	}{
+		{
+			name: "empty",
+			tag:  "",
+			want: &fieldTag{},
+		},
+		{
+			name: "required flag before description",
+			tag:  "required,description=Event name",
+			want: &fieldTag{description: "Event name"},
+		},
+		{
+			name: "required flag only",
+			tag:  "required",
+			want: &fieldTag{},
+		},
+		{
+			name: "escaped commas before another key",
+			tag:  `description=City\, e.g. Paris\, or Rome,format=email`,
+			want: &fieldTag{description: "City, e.g. Paris, or Rome", format: "email"},
+		},
+		{
+			name: "escaped comma before a key",
+			tag:  `description=a\,enum=b`,
+			want: &fieldTag{description: "a,enum=b"},
+		},
+		{
+			name: "repeated enum and examples",
+			tag:  "enum=a,enum=b,examples=a",
+			want: &fieldTag{enum: []string{"a", "b"}, examples: []string{"a"}},
+		},
+		{
+			name: "bounds and default",
+			tag:  "minimum=1,maximum=2.5,default=2",
+			want: &fieldTag{minimum: ptr(1.0), maximum: ptr(2.5), def: ptr("2")},
+		},
+		{
+			name:    "unknown leading key",
+			tag:     "descripton=typo",
+			wantErr: `unknown key "descripton"`,
+		},
+		{
+			name:    "unknown key after a value",
+			tag:     "description=x,minimun=3",
+			wantErr: `unknown key "minimun"`,
+		},
+		{
+			name:    "unescaped comma in a value",
+			tag:     "description=City, e.g. Paris",
+			wantErr: `malformed entry " e.g. Paris"`,
+		},
+		{
+			name:    "leading entry without a key",
+			tag:     "Event name",
+			wantErr: "malformed entry",
+		},
+		{
+			name:    "non-numeric minimum",
+			tag:     "minimum=one",
+			wantErr: "minimum must be a number",
+		},
+		{
+			name:    "invalid pattern",
+			tag:     "pattern=[",
+			wantErr: "invalid pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTag(tt.tag)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseTag(%q) error = %v, want %q", tt.tag, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTag(%q) error = %v", tt.tag, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTag(%q) = %+v, want %+v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructToJSONSchemaTags(t *testing.T) {
+	type event struct {
+		Name string `json:"name" jsonschema:"required,description=Event name"`
+		City string `json:"city" jsonschema:"description=City\\, e.g. Paris"`
+		Data []byte `json:"data,omitempty"`
+	}
+
+	s, err := StructToJSONSchema(event{})
+	if err != nil {
+		t.Fatalf("StructToJSONSchema() error = %v", err)
+	}
+
+	properties := s["properties"].(map[string]interface{})
+	tests := []struct {
+		property string
+		want     map[string]interface{}
+	}{
+		{"name", map[string]interface{}{"type": "string", "description": "Event name"}},
+		{"city", map[string]interface{}{"type": "string", "description": "City, e.g. Paris"}},
+		{"data", map[string]interface{}{"type": "string", "contentEncoding": "base64"}},
+	}
+	for _, tt := range tests {
+		if got := properties[tt.property]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("property %q = %v, want %v", tt.property, got, tt.want)
+		}
+	}
+
+	if got, want := s["required"], []string{"name", "city"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("required = %v, want %v", got, want)
+	}
+
+	if err := Validate(s, []byte(`{"name":"launch","city":"Paris","data":"aGk="}`)); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
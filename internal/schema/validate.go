@@ -0,0 +1,540 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxRefDepth bounds consecutive $ref hops so that self-referencing schemas
+// which never consume data cannot recurse forever.
+const maxRefDepth = 256
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+	durationPattern = regexp.MustCompile(`^P(\d+W|(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+(\.\d+)?S)?)?)$`)
+)
+
+// ValidationError describes a single schema violation.
+type ValidationError struct {
+	// Path is the JSON pointer (RFC 6901) of the offending value; "" is the root
+	Path string
+
+	// Message describes the violation
+	Message string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// ValidationErrors is the list of violations found in one document.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "schema validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Validator validates JSON documents against a compiled schema.
+// Supports the draft 2020-12 subset used by OpenAI Structured Outputs: type,
+// properties, required, additionalProperties, enum, const, anyOf, $ref/$defs,
+// items, minItems/maxItems, minLength/maxLength, minimum/maximum (and
+// exclusive variants), multipleOf, pattern and format.
+// A Validator is safe for concurrent use.
+type Validator struct {
+	root map[string]interface{}
+
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+// Compile prepares schema for validation. The schema is normalized through
+// JSON so both generated (Go-typed) and decoded schemas are accepted.
+func Compile(schema map[string]interface{}) (*Validator, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("encode schema: %w", err)
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil, fmt.Errorf("decode schema: %w", err)
+	}
+	return &Validator{root: root, patterns: make(map[string]*regexp.Regexp)}, nil
+}
+
+// Validate checks the JSON document data against schema.
+// Returns ValidationErrors listing every violation, or nil.
+func Validate(schema map[string]interface{}, data []byte) error {
+	v, err := Compile(schema)
+	if err != nil {
+		return err
+	}
+	return v.Validate(data)
+}
+
+// Validate checks the JSON document data against the compiled schema.
+func (v *Validator) Validate(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return ValidationErrors{{Path: "", Message: "invalid JSON: " + err.Error()}}
+	}
+	if dec.More() {
+		return ValidationErrors{{Path: "", Message: "invalid JSON: trailing data"}}
+	}
+	return v.ValidateValue(value)
+}
+
+// ValidateValue checks an already-decoded value (as produced by encoding/json)
+// against the compiled schema.
+func (v *Validator) ValidateValue(value interface{}) error {
+	var errs ValidationErrors
+	v.validate(v.root, value, "", 0, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate checks value against node, appending violations to errs.
+func (v *Validator) validate(node map[string]interface{}, value interface{}, path string, refDepth int, errs *ValidationErrors) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if ref, ok := node["$ref"].(string); ok {
+		if refDepth >= maxRefDepth {
+			fail("$ref %q nests too deeply", ref)
+			return
+		}
+		target, err := v.resolve(ref)
+		if err != nil {
+			fail("%v", err)
+			return
+		}
+		v.validate(target, value, path, refDepth+1, errs)
+	}
+
+	if anyOf, ok := node["anyOf"].([]interface{}); ok {
+		matched := false
+		var closest []ValidationErrors
+		for _, sub := range anyOf {
+			subSchema, _ := sub.(map[string]interface{})
+			var subErrs ValidationErrors
+			v.validate(subSchema, value, path, refDepth, &subErrs)
+			if len(subErrs) == 0 {
+				matched = true
+				break
+			}
+			if !hasErrorAt(subErrs, path) {
+				closest = append(closest, subErrs)
+			}
+		}
+		switch {
+		case matched:
+		case len(closest) == 1:
+			// Only one branch matched at this level (e.g. the non-null side
+			// of a nullable field); its nested errors are the useful ones
+			*errs = append(*errs, closest[0]...)
+		default:
+			fail("value does not match any schema in anyOf")
+		}
+	}
+
+	if typ, ok := node["type"]; ok && !matchesType(typ, value) {
+		fail("expected %s, got %s", describeType(typ), jsonType(value))
+		return
+	}
+
+	if enum, ok := node["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if jsonEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("value %s is not one of the allowed enum values", compact(value))
+		}
+	}
+
+	if c, ok := node["const"]; ok && !jsonEqual(c, value) {
+		fail("value %s does not equal const %s", compact(value), compact(c))
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(node, val, path, errs)
+	case []interface{}:
+		v.validateArray(node, val, path, errs)
+	case string:
+		v.validateString(node, val, path, fail)
+	case json.Number:
+		validateNumber(node, val, fail)
+	}
+}
+
+// validateObject applies required, properties and additionalProperties.
+func (v *Validator) validateObject(node map[string]interface{}, obj map[string]interface{}, path string, errs *ValidationErrors) {
+	if required, ok := node["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := obj[name]; !present {
+				*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf("missing required property %q", name)})
+			}
+		}
+	}
+
+	props, _ := node["properties"].(map[string]interface{})
+	additional, hasAdditional := node["additionalProperties"]
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "/" + escapePointer(key)
+		if propSchema, ok := props[key].(map[string]interface{}); ok {
+			v.validate(propSchema, obj[key], childPath, 0, errs)
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		switch ap := additional.(type) {
+		case bool:
+			if !ap {
+				*errs = append(*errs, &ValidationError{Path: childPath, Message: "additional property is not allowed"})
+			}
+		case map[string]interface{}:
+			v.validate(ap, obj[key], childPath, 0, errs)
+		}
+	}
+}
+
+// validateArray applies items, minItems and maxItems.
+func (v *Validator) validateArray(node map[string]interface{}, arr []interface{}, path string, errs *ValidationErrors) {
+	if min, ok := intKeyword(node, "minItems"); ok && len(arr) < min {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf("array has %d items, minimum is %d", len(arr), min)})
+	}
+	if max, ok := intKeyword(node, "maxItems"); ok && len(arr) > max {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf("array has %d items, maximum is %d", len(arr), max)})
+	}
+	if items, ok := node["items"].(map[string]interface{}); ok {
+		for i, elem := range arr {
+			v.validate(items, elem, path+"/"+strconv.Itoa(i), 0, errs)
+		}
+	}
+}
+
+// validateString applies minLength, maxLength, pattern and format.
+func (v *Validator) validateString(node map[string]interface{}, s string, path string, fail func(string, ...interface{})) {
+	length := utf8.RuneCountInString(s)
+	if min, ok := intKeyword(node, "minLength"); ok && length < min {
+		fail("string length %d is less than minLength %d", length, min)
+	}
+	if max, ok := intKeyword(node, "maxLength"); ok && length > max {
+		fail("string length %d exceeds maxLength %d", length, max)
+	}
+
+	if pattern, ok := node["pattern"].(string); ok {
+		re, err := v.compilePattern(pattern)
+		if err != nil {
+			fail("invalid pattern %q: %v", pattern, err)
+		} else if !re.MatchString(s) {
+			fail("string %q does not match pattern %q", s, pattern)
+		}
+	}
+
+	if format, ok := node["format"].(string); ok && !matchesFormat(format, s) {
+		fail("string %q is not a valid %s", s, format)
+	}
+}
+
+// validateNumber applies minimum, maximum, exclusive bounds and multipleOf.
+func validateNumber(node map[string]interface{}, num json.Number, fail func(string, ...interface{})) {
+	n, err := num.Float64()
+	if err != nil {
+		fail("invalid number %s", num)
+		return
+	}
+	if min, ok := floatKeyword(node, "minimum"); ok && n < min {
+		fail("%s is less than minimum %v", num, min)
+	}
+	if max, ok := floatKeyword(node, "maximum"); ok && n > max {
+		fail("%s is greater than maximum %v", num, max)
+	}
+	if min, ok := floatKeyword(node, "exclusiveMinimum"); ok && n <= min {
+		fail("%s must be greater than %v", num, min)
+	}
+	if max, ok := floatKeyword(node, "exclusiveMaximum"); ok && n >= max {
+		fail("%s must be less than %v", num, max)
+	}
+	if m, ok := floatKeyword(node, "multipleOf"); ok && m > 0 {
+		q := n / m
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("%s is not a multiple of %v", num, m)
+		}
+	}
+}
+
+// resolve looks up a local $ref ("#" or a JSON pointer such as "#/$defs/Name").
+func (v *Validator) resolve(ref string) (map[string]interface{}, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q (only local references are supported)", ref)
+	}
+
+	var node interface{} = v.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		if node, ok = m[token]; !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+	}
+
+	target, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("$ref %q does not point to a schema", ref)
+	}
+	return target, nil
+}
+
+// compilePattern returns the cached compiled regular expression.
+// Patterns use Go RE2 syntax, which covers the ECMA-262 subset used in practice.
+func (v *Validator) compilePattern(pattern string) (*regexp.Regexp, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if re, ok := v.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.patterns[pattern] = re
+	return re, nil
+}
+
+// matchesType reports whether value has the schema type (string or list).
+func matchesType(typ interface{}, value interface{}) bool {
+	switch t := typ.(type) {
+	case string:
+		return isType(t, value)
+	case []interface{}:
+		for _, s := range t {
+			if name, ok := s.(string); ok && isType(name, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// isType reports whether value is of the JSON type name.
+func isType(name string, value interface{}) bool {
+	actual := jsonType(value)
+	if name == "number" && actual == "integer" {
+		return true
+	}
+	return name == actual
+}
+
+// jsonType returns the JSON type name of a decoded value.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		// Per draft 2020-12, numbers with a zero fractional part are integers
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// describeType formats a schema type for error messages.
+func describeType(typ interface{}) string {
+	if list, ok := typ.([]interface{}); ok {
+		names := make([]string, len(list))
+		for i, s := range list {
+			names[i] = fmt.Sprint(s)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(typ)
+}
+
+// hasErrorAt reports whether any error in errs is located exactly at path.
+func hasErrorAt(errs ValidationErrors, path string) bool {
+	for _, e := range errs {
+		if e.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEqual compares two decoded JSON values, treating numbers by value.
+func jsonEqual(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			if !jsonEqual(v, bv[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// toFloat converts a decoded JSON number to float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// intKeyword reads a non-negative integer keyword from a schema node.
+func intKeyword(node map[string]interface{}, key string) (int, bool) {
+	f, ok := floatKeyword(node, key)
+	return int(f), ok
+}
+
+// floatKeyword reads a numeric keyword from a schema node.
+func floatKeyword(node map[string]interface{}, key string) (float64, bool) {
+	v, ok := node[key]
+	if !ok {
+		return 0, false
+	}
+	return toFloat(v)
+}
+
+// matchesFormat checks the string formats supported by strict mode.
+// Unknown formats are accepted, as JSON Schema treats format as an annotation.
+func matchesFormat(format, s string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	case "time":
+		for _, layout := range []string{"15:04:05Z07:00", "15:04:05.999999999Z07:00", "15:04:05"} {
+			if _, err := time.Parse(layout, s); err == nil {
+				return true
+			}
+		}
+		return false
+	case "duration":
+		return durationPattern.MatchString(s) && s != "P" && !strings.HasSuffix(s, "T")
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "hostname":
+		return len(s) <= 253 && hostnamePattern.MatchString(s)
+	case "ipv4":
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && strings.Contains(s, ".")
+	case "ipv6":
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	default:
+		return true
+	}
+}
+
+// escapePointer escapes a property name for use in a JSON pointer.
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// compact renders a value as compact JSON for error messages.
+func compact(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
@@ -0,0 +1,243 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	person := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string", "minLength": 1},
+			"age":  map[string]interface{}{"type": "integer", "minimum": 0},
+			"tags": map[string]interface{}{
+				"type":     "array",
+				"items":    map[string]interface{}{"type": "string"},
+				"maxItems": 2,
+			},
+			"address": map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{
+						"type":       "object",
+						"properties": map[string]interface{}{"city": map[string]interface{}{"type": "string"}},
+					},
+					map[string]interface{}{"type": "null"},
+				},
+			},
+		},
+		"required":             []string{"name", "age"},
+		"additionalProperties": false,
+	}
+
+	tree := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"value":    map[string]interface{}{"type": "integer"},
+			"children": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		schema    map[string]interface{}
+		data      string
+		wantPaths []string
+		wantMsg   string
+	}{
+		{
+			name:   "valid object",
+			schema: person,
+			data:   `{"name":"Ada","age":36,"tags":["math"],"address":null}`,
+		},
+		{
+			name:      "missing required property",
+			schema:    person,
+			data:      `{"name":"Ada"}`,
+			wantPaths: []string{""},
+			wantMsg:   `missing required property "age"`,
+		},
+		{
+			name:      "wrong type",
+			schema:    person,
+			data:      `{"name":"Ada","age":"36"}`,
+			wantPaths: []string{"/age"},
+			wantMsg:   "expected integer, got string",
+		},
+		{
+			name:      "integer rejects fraction",
+			schema:    person,
+			data:      `{"name":"Ada","age":36.5}`,
+			wantPaths: []string{"/age"},
+			wantMsg:   "expected integer, got number",
+		},
+		{
+			name:      "additional property",
+			schema:    person,
+			data:      `{"name":"Ada","age":36,"extra":true}`,
+			wantPaths: []string{"/extra"},
+			wantMsg:   "additional property is not allowed",
+		},
+		{
+			name:      "array bounds and items",
+			schema:    person,
+			data:      `{"name":"Ada","age":36,"tags":["a",1,"c"]}`,
+			wantPaths: []string{"/tags", "/tags/1"},
+		},
+		{
+			name:      "nullable branch reports nested error",
+			schema:    person,
+			data:      `{"name":"Ada","age":36,"address":{"city":1}}`,
+			wantPaths: []string{"/address/city"},
+			wantMsg:   "expected string, got integer",
+		},
+		{
+			name:      "no matching anyOf branch",
+			schema:    person,
+			data:      `{"name":"Ada","age":36,"address":"London"}`,
+			wantPaths: []string{"/address"},
+			wantMsg:   "does not match any schema in anyOf",
+		},
+		{
+			name:      "every violation is reported",
+			schema:    person,
+			data:      `{"name":"","age":-1}`,
+			wantPaths: []string{"/age", "/name"},
+		},
+		{
+			name:   "recursive ref",
+			schema: tree,
+			data:   `{"value":1,"children":[{"value":2,"children":[{"value":3}]}]}`,
+		},
+		{
+			name:      "recursive ref violation",
+			schema:    tree,
+			data:      `{"value":1,"children":[{"value":"two"}]}`,
+			wantPaths: []string{"/children/0/value"},
+		},
+		{
+			name:      "enum",
+			schema:    map[string]interface{}{"enum": []interface{}{"red", 1}},
+			data:      `2`,
+			wantPaths: []string{""},
+			wantMsg:   "not one of the allowed enum values",
+		},
+		{
+			name:   "enum compares numbers by value",
+			schema: map[string]interface{}{"enum": []interface{}{"red", 1}},
+			data:   `1.0`,
+		},
+		{
+			name:      "const",
+			schema:    map[string]interface{}{"const": "on"},
+			data:      `"off"`,
+			wantPaths: []string{""},
+		},
+		{
+			name:      "exclusive bounds and multipleOf",
+			schema:    map[string]interface{}{"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.5},
+			data:      `0`,
+			wantPaths: []string{""},
+			wantMsg:   "must be greater than 0",
+		},
+		{
+			name:   "multipleOf with a fraction",
+			schema: map[string]interface{}{"type": "number", "multipleOf": 0.1},
+			data:   `0.3`,
+		},
+		{
+			name:      "pattern",
+			schema:    map[string]interface{}{"type": "string", "pattern": "^[a-z]+$"},
+			data:      `"ABC"`,
+			wantPaths: []string{""},
+			wantMsg:   "does not match pattern",
+		},
+		{
+			name:      "invalid JSON",
+			schema:    person,
+			data:      `{"name":`,
+			wantPaths: []string{""},
+			wantMsg:   "invalid JSON",
+		},
+		{
+			name:      "trailing data",
+			schema:    person,
+			data:      `{"name":"Ada","age":1} {}`,
+			wantPaths: []string{""},
+			wantMsg:   "trailing data",
+		},
+		{
+			name:      "unresolvable ref",
+			schema:    map[string]interface{}{"$ref": "#/$defs/Missing"},
+			data:      `{}`,
+			wantPaths: []string{""},
+			wantMsg:   "unresolvable $ref",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.schema, []byte(tt.data))
+			if tt.wantPaths == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			paths := make([]string, len(errs))
+			for i, e := range errs {
+				paths[i] = e.Path
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("error paths = %q, want %q (%v)", paths, tt.wantPaths, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestMatchesFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+		want   bool
+	}{
+		{"date-time", "2024-05-01T12:00:00Z", true},
+		{"date-time", "2024-05-01 12:00", false},
+		{"date", "2024-05-01", true},
+		{"date", "05/01/2024", false},
+		{"time", "12:00:00+02:00", true},
+		{"time", "noon", false},
+		{"duration", "P1DT2H", true},
+		{"duration", "P", false},
+		{"duration", "P1DT", false},
+		{"email", "ada@example.com", true},
+		{"email", "Ada <ada@example.com>", false},
+		{"hostname", "api.example.com", true},
+		{"hostname", "-bad.example.com", false},
+		{"ipv4", "192.168.0.1", true},
+		{"ipv4", "::1", false},
+		{"ipv6", "::1", true},
+		{"ipv6", "192.168.0.1", false},
+		{"uuid", "123e4567-e89b-12d3-a456-426614174000", true},
+		{"uuid", "123e4567", false},
+		{"unknown", "anything", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+tt.value, func(t *testing.T) {
+			if got := matchesFormat(tt.format, tt.value); got != tt.want {
+				t.Errorf("matchesFormat(%q, %q) = %v, want %v", tt.format, tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package sse implements the server-sent events framing shared by streaming providers.
+// Reference: research.md decision #1 (bufio.Scanner with custom SplitFunc)
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// MaxEventSize caps the size of a single SSE event. Lifecycle events can
+// carry a full response snapshot, so this is well above bufio's 64KB default.
+const MaxEventSize = 8 * 1024 * 1024
+
+// NewScanner returns a scanner yielding one SSE frame per token from r.
+// Decode each frame with ParseFrame.
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxEventSize)
+	scanner.Split(ScanFrames)
+	return scanner
+}
+
+// ScanFrames is a bufio.SplitFunc that yields one SSE frame (the lines between
+// blank-line delimiters) per token. Both "\n\n" and "\r\n\r\n" are accepted.
+func ScanFrames(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.Index(data, []byte("\r\n\r\n")); i >= 0 {
+		if j := bytes.Index(data, []byte("\n\n")); j < 0 || i < j {
+			return i + 4, data[:i], nil
+		}
+	}
+	if i := bytes.Index(data, []byte("\n\n")); i >= 0 {
+		return i + 2, data[:i], nil
+	}
+
+	// Emit any trailing frame without a final delimiter
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// ParseFrame extracts the event name and data payload from an SSE frame.
+// Multiple data lines are joined with "\n" per the SSE specification;
+// comment lines (starting with ':') and unknown fields are ignored.
+func ParseFrame(frame []byte) (eventName string, data []byte) {
+	var dataLines [][]byte
+	for _, line := range bytes.Split(frame, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) == 0 || line[0] == ':' {
+			continue
+		}
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+
+		switch string(field) {
+		case "event":
+			eventName = strings.TrimSpace(string(value))
+		case "data":
+			dataLines = append(dataLines, value)
+		}
+	}
+	return eventName, bytes.Join(dataLines, []byte("\n"))
+}
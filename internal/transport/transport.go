@@ -0,0 +1,434 @@
+// Package transport executes provider HTTP requests with the behavior every
+// provider shares: the retry engine, client-side rate limiting, telemetry
+// hooks and structured logging. Providers supply the wire format, auth
+// headers and error decoding.
+// Reference: architecture.md (Middleware Layering section)
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	internalhttp "github.com/amannhq/go-ai-sdk/internal/http"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// Settings are the request-path options common to all provider configs.
+type Settings struct {
+	// Timeout is the HTTP request timeout (not applied to streams)
+	Timeout time.Duration
+
+	// MaxRetries is the maximum retry attempts for transient failures
+	// (default: 3; ignored when Retry is set)
+	MaxRetries int
+
+	// Retry sets the retry policy, including its MaxRetries (optional,
+	// default: middleware.DefaultRetryConfig)
+	Retry *middleware.RetryConfig
+
+	// HTTPClient replaces the default pooled client (optional); Timeout
+	// applies when it sets none
+	HTTPClient *http.Client
+
+	// Transport configures the default pooled transport and wraps it, or
+	// HTTPClient's, in RoundTripper middleware (optional)
+	Transport *aisdk.TransportConfig
+
+	// Headers are set on every attempt, replacing provider headers of the
+	// same name (optional)
+	Headers http.Header
+
+	// Logger receives structured retry and error events (optional)
+	Logger aisdk.Logger
+
+	// TelemetryHooks are called for every HTTP attempt (optional)
+	TelemetryHooks *middleware.TelemetryHooks
+
+	// RateLimiter paces every HTTP attempt per API key and model (optional)
+	RateLimiter *middleware.RateLimiter
+
+	// Credentials supplies the API key for every request, replacing the
+	// static key the provider set (optional)
+	Credentials aisdk.CredentialProvider
+}
+
+// ErrorMapper converts a non-2xx response into the provider's error
+// (typically via StatusError). It may consume the response body.
+type ErrorMapper func(resp *http.Response, correlationID string) error
+
+// Transport sends provider requests. It is safe for concurrent use.
+type Transport struct {
+	provider        string
+	settings        Settings
+	retryConfig     *middleware.RetryConfig
+	httpClient      *internalhttp.HTTPClient
+	streamingClient *internalhttp.HTTPClient
+	mapError        ErrorMapper
+}
+
+// Request describes one logical provider call, which may take several
+// HTTP attempts.
+type Request struct {
+	// HTTP is the request to send; its body must be replayable (GetBody)
+	HTTP *http.Request
+
+	// Stream sends the request without an overall timeout so the response
+	// body can be read for as long as the context allows
+	Stream bool
+
+	// CorrelationID is attached to mapped errors
+	CorrelationID string
+
+	// APIKey and Model select the rate limiter budget
+	APIKey string
+	Model  string
+
+	// Tokens is the estimated token usage charged to the rate limiter
+	Tokens int
+
+	// Authorize sets a credential on HTTP in the provider's auth scheme.
+	// It is required for Credentials to take effect.
+	Authorize func(req *http.Request, credential string)
+
+	// RetryDelay returns the delay a retryable response asks for when the
+	// provider reports it outside the standard headers (optional; see
+	// internalhttp.RetryPolicy)
+	RetryDelay func(resp *http.Response) time.Duration
+}
+
+// New creates a Transport for the named provider (used in log messages).
+func New(provider string, settings Settings, mapError ErrorMapper) *Transport {
+	settings.Headers = aisdk.MergeHeaders(nil, settings.Headers)
+	t := &Transport{
+		provider:    provider,
+		settings:    settings,
+		retryConfig: newRetryConfig(settings.Retry, settings.MaxRetries),
+		mapError:    mapError,
+	}
+	t.buildHTTPClient()
+	return t
+}
+
+// Apply sets the settings that config sets (see aisdk.ClientConfig): Retry,
+// or else a positive MaxRetries, a positive Timeout, HTTPClient, Logger,
+// TelemetryHooks, RateLimiter and Credentials; Transport is merged and
+// Headers are added. Provider constructors apply their options with it.
+func (s *Settings) Apply(config *aisdk.ClientConfig) {
+	if config.Retry != nil {
+		s.Retry = config.Retry
+	} else if config.MaxRetries > 0 {
+		s.Retry = newRetryConfig(s.Retry, s.MaxRetries)
+		s.Retry.MaxRetries = config.MaxRetries
+	}
+	if config.Timeout > 0 {
+		s.Timeout = config.Timeout
+	}
+	if config.HTTPClient != nil {
+		s.HTTPClient = config.HTTPClient
+	}
+	s.Transport = s.Transport.Merge(config.Transport)
+	s.Headers = aisdk.MergeHeaders(s.Headers, config.Headers)
+	if config.Logger != nil {
+		s.Logger = config.Logger
+	}
+	if config.TelemetryHooks != nil {
+		s.TelemetryHooks = config.TelemetryHooks
+	}
+	if config.RateLimiter != nil {
+		s.RateLimiter = config.RateLimiter
+	}
+	if config.Credentials != nil {
+		s.Credentials = config.Credentials
+	}
+}
+
+// newRetryConfig returns a copy of retry or, when it is nil,
+// middleware.DefaultRetryConfig with a positive maxRetries applied.
+func newRetryConfig(retry *middleware.RetryConfig, maxRetries int) *middleware.RetryConfig {
+	config := middleware.DefaultRetryConfig()
+	if retry != nil {
+		*config = *retry
+	} else if maxRetries > 0 {
+		config.MaxRetries = maxRetries
+	}
+	return config
+}
+
+// buildHTTPClient creates the clients for regular and streaming requests
+// from the injected client or transport settings.
+func (t *Transport) buildHTTPClient() {
+	config := t.settings.Transport
+	if config == nil {
+		config = &aisdk.TransportConfig{}
+	}
+
+	client := t.settings.HTTPClient
+	if client == nil {
+		rt := config.RoundTripper
+		if rt == nil {
+			rt = internalhttp.NewTransport(internalhttp.TransportSettings{
+				Proxy:               config.Proxy,
+				TLSConfig:           config.TLS(),
+				MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+			})
+		}
+		client = &http.Client{Transport: rt}
+	}
+
+	if len(config.Middleware) > 0 {
+		wrapped := *client
+		if wrapped.Transport == nil {
+			wrapped.Transport = http.DefaultTransport
+		}
+		for i := len(config.Middleware) - 1; i >= 0; i-- {
+			wrapped.Transport = config.Middleware[i](wrapped.Transport)
+		}
+		client = &wrapped
+	}
+
+	t.httpClient = internalhttp.WrapHTTPClient(client, t.settings.Timeout)
+	t.streamingClient = t.httpClient.Streaming()
+}
+
+// Configure returns a copy of the Transport with the settings config sets
+// applied (see Settings.Apply and aisdk.ConfigurableProvider); t is not
+// modified.
+func (t *Transport) Configure(config *aisdk.ClientConfig) *Transport {
+	configured := *t
+	configured.settings.Apply(config)
+	configured.retryConfig = newRetryConfig(configured.settings.Retry, configured.settings.MaxRetries)
+	if config.Timeout > 0 || config.HTTPClient != nil || config.Transport != nil {
+		configured.buildHTTPClient()
+	}
+	return &configured
+}
+
+// Send executes req.HTTP through the shared retry engine, reporting every
+// attempt to hooks and logger and pacing it with the rate limiter (if any).
+// With Credentials configured, a credential is set on every attempt and
+// its outcome reported to an aisdk.CredentialObserver. A 401 response
+// invalidates it (aisdk.CredentialInvalidator) and the request is sent once
+// more if Credentials then supplies a different credential.
+// Returns the 2xx response, or the mapped error once retries are exhausted.
+func (t *Transport) Send(ctx context.Context, req *Request) (*http.Response, error) {
+	method, url := req.HTTP.Method, req.HTTP.URL.String()
+	var fresh string
+	for refreshed := false; ; refreshed = true {
+		httpResp, credential, err := t.send(ctx, req, fresh)
+		if err == nil {
+			return httpResp, nil
+		}
+
+		credentials := t.settings.Credentials
+		if refreshed || credentials == nil || req.Authorize == nil || !isUnauthorized(err) {
+			return nil, t.Fail(ctx, method, url, err)
+		}
+		if invalidator, ok := credentials.(aisdk.CredentialInvalidator); ok {
+			invalidator.Invalidate(credential)
+		}
+		c, credentialErr := credentials.Credential(ctx)
+		if credentialErr != nil || c.Value == credential {
+			// Sending the rejected credential again would fail the same way
+			return nil, t.Fail(ctx, method, url, err)
+		}
+		fresh = c.Value
+		t.Log(ctx, "warn", "refreshing credential after 401", "method", method, "url", url)
+	}
+}
+
+// isUnauthorized reports whether err is an HTTP 401 API error.
+func isUnauthorized(err error) bool {
+	var apiErr *aisdk.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
+
+// send executes one logical call, returning the credential used by the
+// last attempt (req.APIKey without Credentials). The first attempt uses
+// first when it is set. Errors are returned unreported, for Send to Fail.
+func (t *Transport) send(ctx context.Context, req *Request, first string) (*http.Response, string, error) {
+	httpReq := req.HTTP
+	method, url := httpReq.Method, httpReq.URL.String()
+
+	credentials := t.settings.Credentials
+	if req.Authorize == nil {
+		credentials = nil
+	}
+	observer, _ := credentials.(aisdk.CredentialObserver)
+
+	// credential is the key of the current attempt; next, when set, was
+	// chosen after a 429 to replace a rate-limited key without waiting, or
+	// by Send to replace a key rejected with a 401
+	credential, next := req.APIKey, first
+	var credentialErr error
+
+	policy := &internalhttp.RetryPolicy{
+		MaxRetries: t.retryConfig.MaxRetries,
+		Backoff:    t.retryConfig.ExponentialBackoff,
+		MaxDelay:   t.retryConfig.MaxDelay,
+		RetryDelay: req.RetryDelay,
+		BeforeAttempt: func(ctx context.Context) error {
+			if credentials != nil {
+				if next == "" {
+					c, err := credentials.Credential(ctx)
+					if err != nil {
+						credentialErr = aisdk.WrapError(err, "get credential")
+						return credentialErr
+					}
+					next = c.Value
+				}
+				credential, next = next, ""
+			}
+			if t.settings.RateLimiter != nil {
+				return t.settings.RateLimiter.Wait(ctx, middleware.RateLimitKey{APIKey: credential, Model: req.Model}, req.Tokens)
+			}
+			return nil
+		},
+		OnAttempt: func(attemptReq *http.Request) {
+			for key, values := range t.settings.Headers {
+				attemptReq.Header[key] = values
+			}
+			if credentials != nil {
+				req.Authorize(attemptReq, credential)
+			}
+			t.settings.TelemetryHooks.RequestStarted(ctx, method, url)
+		},
+		OnResponse: func(resp *http.Response, duration time.Duration) {
+			t.settings.TelemetryHooks.ResponseReceived(ctx, resp.StatusCode, duration)
+			info := internalhttp.ExtractRateLimitHeaders(resp.Header)
+			if t.settings.RateLimiter != nil {
+				t.settings.RateLimiter.Observe(middleware.RateLimitKey{APIKey: credential, Model: req.Model}, RateLimitObservation(info))
+			}
+			if observer != nil {
+				observer.Observe(credential, aisdk.CredentialResult{
+					StatusCode: resp.StatusCode,
+					RateLimit:  RateLimitInfo(info),
+					RetryDelay: info.RetryDelay(),
+				})
+			}
+		},
+		SkipDelay: func(resp *http.Response) bool {
+			if observer == nil || resp.StatusCode != http.StatusTooManyRequests {
+				return false
+			}
+			// Retry at once if another key is available
+			c, err := credentials.Credential(ctx)
+			if err != nil || c.Value == credential {
+				return false
+			}
+			next = c.Value
+			return true
+		},
+		OnRetry: func(attempt int, delay time.Duration, resp *http.Response, err error) {
+			if resp != nil {
+				err = t.mapError(resp, req.CorrelationID)
+			}
+			t.settings.TelemetryHooks.Retrying(ctx, attempt, err)
+			t.Log(ctx, "warn", "retrying "+t.provider+" request", "method", method, "url", url, "attempt", attempt, "backoff", delay, "error", err)
+		},
+	}
+
+	httpClient := t.httpClient
+	if req.Stream {
+		httpClient = t.streamingClient
+	}
+
+	httpResp, err := httpClient.DoRequestWithRetry(ctx, httpReq, policy)
+	if err != nil {
+		if credentialErr != nil {
+			return nil, credential, credentialErr
+		}
+		if ctx.Err() != nil {
+			return nil, credential, ctx.Err()
+		}
+		return nil, credential, aisdk.WrapError(err, "send request")
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		defer httpResp.Body.Close()
+		return nil, credential, t.mapError(httpResp, req.CorrelationID)
+	}
+	return httpResp, credential, nil
+}
+
+// Fail reports a final request failure to hooks and logger and returns err.
+func (t *Transport) Fail(ctx context.Context, method, url string, err error) error {
+	t.settings.TelemetryHooks.Failed(ctx, err)
+	t.Log(ctx, "error", t.provider+" request failed", "method", method, "url", url, "error", err)
+	return err
+}
+
+// Log sends a structured event to the configured logger, if any.
+func (t *Transport) Log(ctx context.Context, level, message string, keyvals ...interface{}) {
+	if t.settings.Logger == nil {
+		return
+	}
+	if id := middleware.GetCorrelationID(ctx); id != "" {
+		keyvals = append(keyvals, "correlation_id", id)
+	}
+	t.settings.Logger.Log(level, message, keyvals...)
+}
+
+// StatusError builds the error for a non-2xx response from decoded details:
+// *aisdk.RateLimitError (with parsed headers) for 429, *aisdk.APIError otherwise.
+// Empty code and message fall back to the HTTP status.
+func StatusError(resp *http.Response, code, message, correlationID string) error {
+	if code == "" {
+		code = http.StatusText(resp.StatusCode)
+	}
+	if message == "" {
+		message = "Request failed with status " + resp.Status
+	}
+	if resp.StatusCode == 429 {
+		info := RateLimitInfo(internalhttp.ExtractRateLimitHeaders(resp.Header))
+		return aisdk.NewRateLimitError(resp.StatusCode, code, message, correlationID, info)
+	}
+	return aisdk.NewAPIError(resp.StatusCode, code, message, correlationID)
+}
+
+// RateLimitInfo converts internal RateLimitInfo to aisdk.RateLimitInfo
+func RateLimitInfo(info *internalhttp.RateLimitInfo) *aisdk.RateLimitInfo {
+	if info == nil {
+		return nil
+	}
+	return &aisdk.RateLimitInfo{
+		Limit:           info.Limit,
+		Remaining:       info.Remaining,
+		ResetAt:         info.ResetAt,
+		LimitTokens:     info.LimitTokens,
+		RemainingTokens: info.RemainingTokens,
+		ResetTokensAt:   info.ResetTokensAt,
+		RetryAfter:      info.RetryAfter,
+	}
+}
+
+// RateLimitObservation converts parsed headers to the rate limiter's input.
+func RateLimitObservation(info *internalhttp.RateLimitInfo) *middleware.RateLimitObservation {
+	obs := &middleware.RateLimitObservation{
+		RequestLimit:      info.Limit,
+		RemainingRequests: info.Remaining,
+		TokenLimit:        info.LimitTokens,
+		RemainingTokens:   info.RemainingTokens,
+		RetryAfter:        info.RetryAfter,
+	}
+	if !info.ResetAt.IsZero() {
+		obs.RequestsReset = time.Until(info.ResetAt)
+	}
+	if !info.ResetTokensAt.IsZero() {
+		obs.TokensReset = time.Until(info.ResetTokensAt)
+	}
+	return obs
+}
+
+// EstimateTokens approximates a request's total token usage for the rate
+// limiter: about four bytes of request JSON per input token, plus the
+// output budget when one is set.
+func EstimateTokens(req *aisdk.CreateResponseRequest, body []byte) int {
+	tokens := len(body)/4 + 1
+	if req.MaxTokens != nil {
+		tokens += *req.MaxTokens
+	}
+	return tokens
+}
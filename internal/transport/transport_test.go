@@ -0,0 +1,408 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+func TestConfigureRetries(t *testing.T) {
+	retry := func(maxRetries int) *middleware.RetryConfig {
+		return &middleware.RetryConfig{MaxRetries: maxRetries, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+	}
+
+	tests := []struct {
+		name      string
+		settings  Settings
+		config    *aisdk.ClientConfig
+		want      int
+		wantDelay time.Duration
+	}{
+		{
+			name:      "defaults",
+			config:    &aisdk.ClientConfig{},
+			want:      3,
+			wantDelay: time.Second,
+		},
+		{
+			name:      "provider max retries",
+			settings:  Settings{MaxRetries: 5},
+			config:    &aisdk.ClientConfig{},
+			want:      5,
+			wantDelay: time.Second,
+		},
+		{
+			name:      "provider retry disables retries",
+			settings:  Settings{MaxRetries: 5, Retry: retry(0)},
+			config:    &aisdk.ClientConfig{},
+			want:      0,
+			wantDelay: time.Millisecond,
+		},
+		{
+			name:      "client max retries overrides the provider count",
+			settings:  Settings{Retry: retry(0)},
+			config:    &aisdk.ClientConfig{MaxRetries: 2},
+			want:      2,
+			wantDelay: time.Millisecond,
+		},
+		{
+			name:      "client retry wins",
+			settings:  Settings{MaxRetries: 5},
+			config:    &aisdk.ClientConfig{MaxRetries: 2, Retry: retry(1)},
+			want:      1,
+			wantDelay: time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := New("test", tt.settings, nil)
+			before := *base.retryConfig
+
+			got := base.Configure(tt.config).retryConfig
+			if got.MaxRetries != tt.want || got.BaseDelay != tt.wantDelay {
+				t.Errorf("MaxRetries = %d, BaseDelay = %v, want %d and %v", got.MaxRetries, got.BaseDelay, tt.want, tt.wantDelay)
+			}
+			if *base.retryConfig != before {
+				t.Errorf("Configure modified the receiver's retry policy: %+v, want %+v", *base.retryConfig, before)
+			}
+		})
+	}
+}
+
+func TestConfigureHeaders(t *testing.T) {
+	base := New("test", Settings{Headers: http.Header{"x-team": {"base"}}}, nil)
+
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    http.Header
+	}{
+		{
+			name: "no headers",
+			want: http.Header{"X-Team": {"base"}},
+		},
+		{
+			name:    "added header",
+			headers: http.Header{"x-trace": {"a"}},
+			want:    http.Header{"X-Team": {"base"}, "X-Trace": {"a"}},
+		},
+		{
+			name:    "replaced header",
+			headers: http.Header{"X-TEAM": {"client"}},
+			want:    http.Header{"X-Team": {"client"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := base.Configure(&aisdk.ClientConfig{Headers: tt.headers}).settings.Headers
+			if !equalHeaders(got, tt.want) {
+				t.Errorf("Headers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if want := (http.Header{"X-Team": {"base"}}); !equalHeaders(base.settings.Headers, want) {
+		t.Errorf("receiver Headers = %v, want %v", base.settings.Headers, want)
+	}
+}
+
+// equalHeaders reports whether a and b hold the same values.
+func equalHeaders(a, b http.Header) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, values := range a {
+		if len(values) != len(b[key]) {
+			return false
+		}
+		for i := range values {
+			if values[i] != b[key][i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSendTelemetryHooksPerAttempt(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantStatuses []int
+		wantRetries  []int
+		wantFailed   bool
+	}{
+		{
+			name:         "success",
+			statuses:     []int{200},
+			wantStatuses: []int{200},
+		},
+		{
+			name:         "retried until success",
+			statuses:     []int{503, 429, 200},
+			wantStatuses: []int{503, 429, 200},
+			wantRetries:  []int{1, 2},
+		},
+		{
+			name:         "retries exhausted",
+			statuses:     []int{503, 503, 503},
+			wantStatuses: []int{503, 503, 503},
+			wantRetries:  []int{1, 2},
+			wantFailed:   true,
+		},
+		{
+			name:         "not retryable",
+			statuses:     []int{400},
+			wantStatuses: []int{400},
+			wantFailed:   true,
+		},
+	}
+
+	mapError := func(resp *http.Response, correlationID string) error {
+		return StatusError(resp, "", "", correlationID)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[attempt]
+				attempt++
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			var started int
+			var statuses, retries []int
+			var failed bool
+			hooks := &middleware.TelemetryHooks{
+				OnRequestStart: func(ctx context.Context, method, url string) { started++ },
+				OnResponse:     func(ctx context.Context, statusCode int, duration float64) { statuses = append(statuses, statusCode) },
+				OnRetry:        func(ctx context.Context, attempt int, err error) { retries = append(retries, attempt) },
+				OnError:        func(ctx context.Context, err error) { failed = true },
+			}
+
+			// Configure applies the client's hooks
+			transport := New("test", Settings{}, mapError).Configure(&aisdk.ClientConfig{MaxRetries: 2, TelemetryHooks: hooks})
+			transport.retryConfig.BaseDelay = time.Millisecond
+
+			httpReq, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte(`{}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := transport.Send(context.Background(), &Request{HTTP: httpReq})
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantFailed || failed != tt.wantFailed {
+				t.Errorf("Send() error = %v, OnError called = %v, want failure %v", err, failed, tt.wantFailed)
+			}
+			if started != len(tt.statuses) || !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("OnRequestStart calls = %d, OnResponse statuses = %v, want %d and %v", started, statuses, len(tt.statuses), tt.wantStatuses)
+			}
+			if !reflect.DeepEqual(retries, tt.wantRetries) {
+				t.Errorf("OnRetry attempts = %v, want %v", retries, tt.wantRetries)
+			}
+		})
+	}
+}
+
+func TestSendRefreshesCredentialAfter401(t *testing.T) {
+	// sequence returns the values in turn, repeating the last one
+	sequence := func(values ...string) aisdk.CredentialProvider {
+		var mu sync.Mutex
+		return aisdk.CredentialFunc(func(context.Context) (aisdk.Credential, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			value := values[0]
+			if len(values) > 1 {
+				values = values[1:]
+			}
+			return aisdk.Credential{Value: value}, nil
+		})
+	}
+
+	tests := []struct {
+		name         string
+		credentials  aisdk.CredentialProvider
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "accepted",
+			credentials:  aisdk.StaticCredentials("good"),
+			wantAttempts: 1,
+		},
+		{
+			name:         "rejected static key is not sent twice",
+			credentials:  aisdk.StaticCredentials("bad"),
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "uncached provider with a new key",
+			credentials:  sequence("bad", "good"),
+			wantAttempts: 2,
+		},
+		{
+			name:         "invalidated cache",
+			credentials:  aisdk.CachingCredentials(sequence("bad", "good"), 0),
+			wantAttempts: 2,
+		},
+		{
+			name:         "refreshed once",
+			credentials:  sequence("bad", "worse", "good"),
+			wantAttempts: 2,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				if r.Header.Get("Authorization") != "Bearer good" {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			}))
+			defer server.Close()
+
+			tr := New("test", Settings{Credentials: tt.credentials}, func(resp *http.Response, correlationID string) error {
+				return StatusError(resp, "", "", correlationID)
+			})
+			httpReq, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("{}"))
+			resp, err := tr.Send(context.Background(), &Request{
+				HTTP: httpReq,
+				Authorize: func(req *http.Request, credential string) {
+					req.Header.Set("Authorization", "Bearer "+credential)
+				},
+			})
+
+			if tt.wantErr {
+				var apiErr *aisdk.APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+					t.Errorf("Send() error = %v, want a 401 APIError", err)
+				}
+			} else if err != nil {
+				t.Errorf("Send() error = %v", err)
+			} else {
+				resp.Body.Close()
+			}
+			if got := int(attempts.Load()); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestTransportMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Seen", strings.Join(r.Header.Values("X-Order"), ","))
+	}))
+	defer server.Close()
+
+	// named adds its name to the request, and to the order it sees the
+	// response in
+	var responses []string
+	named := func(name string) aisdk.RoundTripperMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return aisdk.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Order", name)
+				resp, err := next.RoundTrip(req)
+				responses = append(responses, name)
+				return resp, err
+			})
+		}
+	}
+
+	tests := []struct {
+		name     string
+		settings Settings
+		config   *aisdk.ClientConfig
+	}{
+		{
+			name:     "default transport",
+			settings: Settings{Transport: &aisdk.TransportConfig{Middleware: []aisdk.RoundTripperMiddleware{named("a"), named("b")}}},
+		},
+		{
+			name: "injected HTTP client",
+			settings: Settings{
+				HTTPClient: &http.Client{Transport: http.DefaultTransport},
+				Transport:  &aisdk.TransportConfig{Middleware: []aisdk.RoundTripperMiddleware{named("a")}},
+			},
+			config: &aisdk.ClientConfig{Transport: &aisdk.TransportConfig{Middleware: []aisdk.RoundTripperMiddleware{named("b")}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses = nil
+			tr := New("test", tt.settings, nil)
+			if tt.config != nil {
+				tr = tr.Configure(tt.config)
+			}
+
+			httpReq, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := tr.Send(context.Background(), &Request{HTTP: httpReq})
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if got := resp.Header.Get("X-Seen"); got != "a,b" {
+				t.Errorf("server saw X-Order %q, want a,b", got)
+			}
+			if got := strings.Join(responses, ","); got != "b,a" {
+				t.Errorf("responses seen in order %q, want b,a", got)
+			}
+		})
+	}
+}
+
+func TestTransportRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	tests := []struct {
+		name    string
+		config  *aisdk.TransportConfig
+		wantErr bool
+	}{
+		{name: "system roots", config: nil, wantErr: true},
+		{name: "custom roots", config: &aisdk.TransportConfig{RootCAs: pool}},
+		{name: "custom roots over a base TLS config", config: &aisdk.TransportConfig{TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12}, RootCAs: pool}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := New("test", Settings{Transport: tt.config, Retry: &middleware.RetryConfig{}}, nil)
+			httpReq, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := tr.Send(context.Background(), &Request{HTTP: httpReq})
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Send() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
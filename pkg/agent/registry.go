@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// ToolHandler executes a single tool call.
+// arguments is the JSON-encoded argument object produced by the model; the
+// returned string is sent back to the model as the function_call_output.
+type ToolHandler func(ctx context.Context, arguments string) (string, error)
+
+// Tool pairs a function declaration with the Go handler that executes it.
+type Tool struct {
+	// Definition is the declaration sent to the model
+	Definition aisdk.Tool
+
+	// Handler executes calls to the tool
+	Handler ToolHandler
+}
+
+// Registry holds the tools available to a Runner, keyed by name.
+// It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	tools map[string]Tool
+	order []string
+}
+
+// NewRegistry creates a Registry containing the given tools.
+func NewRegistry(tools ...Tool) (*Registry, error) {
+	r := &Registry{tools: make(map[string]Tool, len(tools))}
+	for _, tool := range tools {
+		if err := r.Register(tool); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a tool to the registry.
+// Returns an error if the definition is invalid, the handler is nil, or a
+// tool with the same name is already registered.
+func (r *Registry) Register(tool Tool) error {
+	if err := tool.Definition.Validate(); err != nil {
+		return err
+	}
+	if tool.Handler == nil {
+		return fmt.Errorf("%w: handler for %q is nil", aisdk.ErrInvalidTool, tool.Definition.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tools[tool.Definition.Name]; exists {
+		return fmt.Errorf("%w: duplicate name %q", aisdk.ErrInvalidTool, tool.Definition.Name)
+	}
+	r.tools[tool.Definition.Name] = tool
+	r.order = append(r.order, tool.Definition.Name)
+	return nil
+}
+
+// Lookup returns the tool registered under name.
+func (r *Registry) Lookup(name string) (Tool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tool, ok := r.tools[name]
+	return tool, ok
+}
+
+// Definitions returns the declarations of all registered tools in
+// registration order.
+func (r *Registry) Definitions() []aisdk.Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]aisdk.Tool, len(r.order))
+	for i, name := range r.order {
+		defs[i] = r.tools[name].Definition
+	}
+	return defs
+}
@@ -0,0 +1,293 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+var (
+	// ErrMaxStepsExceeded indicates the model kept requesting tool calls after MaxSteps
+	ErrMaxStepsExceeded = errors.New("agent: maximum steps exceeded")
+
+	// ErrMissingProvider indicates that no provider was given to New
+	ErrMissingProvider = errors.New("agent: provider is required")
+
+	// ErrMissingRegistry indicates that no tool registry was given to New
+	ErrMissingRegistry = errors.New("agent: tool registry is required")
+
+	// ErrIncompleteStream indicates the stream ended before response.completed
+	ErrIncompleteStream = errors.New("agent: stream ended before response completed")
+)
+
+// Config configures a Runner.
+type Config struct {
+	// MaxSteps is the maximum number of model calls per Run (default: 10)
+	MaxSteps int
+
+	// MaxConcurrency bounds the number of tool calls executed in parallel (default: 4)
+	MaxConcurrency int
+
+	// Stream uses Provider.StreamResponse instead of CreateResponse for each step
+	Stream bool
+
+	// OnStep is called after each step's tool calls have been executed (optional)
+	OnStep func(ctx context.Context, step *Step)
+
+	// OnStreamEvent is called for every event when Stream is true (optional)
+	OnStreamEvent func(ctx context.Context, event *aisdk.StreamEvent)
+}
+
+// DefaultConfig returns a Config with default values
+func DefaultConfig() *Config {
+	return &Config{
+		MaxSteps:       10,
+		MaxConcurrency: 4,
+	}
+}
+
+// Step records one model call and the tool calls it produced.
+type Step struct {
+	// Index is the zero-based step number
+	Index int
+
+	// Response is the model response for this step
+	Response *aisdk.Response
+
+	// ToolResults holds the outcome of each function call in output order
+	ToolResults []ToolResult
+}
+
+// ToolResult is the outcome of executing a single function call.
+type ToolResult struct {
+	// Call is the function call requested by the model
+	Call aisdk.FunctionCall
+
+	// Output is the value sent back to the model
+	Output string
+
+	// Err is the handler error, if any. The error text is still reported to
+	// the model as Output so it can recover.
+	Err error
+}
+
+// Result is the outcome of a Run.
+type Result struct {
+	// Response is the final model response (no further tool calls requested)
+	Response *aisdk.Response
+
+	// Steps lists every step in order, including the final one
+	Steps []Step
+}
+
+// Runner drives the tool-calling loop: call the model, execute requested
+// functions, send their outputs back via PreviousResponseID, and repeat
+// until the model produces a response without tool calls.
+type Runner struct {
+	provider aisdk.Provider
+	registry *Registry
+	config   *Config
+}
+
+// New creates a Runner over provider (typically an *aisdk.Client).
+// A nil config uses DefaultConfig.
+func New(provider aisdk.Provider, registry *Registry, config *Config) (*Runner, error) {
+	if provider == nil {
+		return nil, ErrMissingProvider
+	}
+	if registry == nil {
+		return nil, ErrMissingRegistry
+	}
+
+	defaults := DefaultConfig()
+	if config == nil {
+		config = defaults
+	}
+	cfg := *config
+	if cfg.MaxSteps <= 0 {
+		cfg.MaxSteps = defaults.MaxSteps
+	}
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = defaults.MaxConcurrency
+	}
+
+	return &Runner{
+		provider: provider,
+		registry: registry,
+		config:   &cfg,
+	}, nil
+}
+
+// Run executes the tool loop starting from req.
+// Registered tools are appended to req.Tools, replacing tools of the same
+// name. ToolChoice applies to the first step only so that a forced call does not loop indefinitely.
+// When MaxSteps is reached the partial Result is returned with ErrMaxStepsExceeded.
+func (r *Runner) Run(ctx context.Context, req *aisdk.CreateResponseRequest) (*Result, error) {
+	stepReq := *req
+	stepReq.Tools = mergeTools(req.Tools, r.registry.Definitions())
+
+	result := &Result{}
+	for i := 0; i < r.config.MaxSteps; i++ {
+		resp, err := r.call(ctx, &stepReq)
+		if err != nil {
+			return result, aisdk.WrapError(err, fmt.Sprintf("agent step %d", i))
+		}
+
+		step := Step{Index: i, Response: resp}
+		calls := resp.ToolCalls()
+		if len(calls) > 0 {
+			step.ToolResults = r.executeAll(ctx, calls)
+		}
+
+		if r.config.OnStep != nil {
+			r.config.OnStep(ctx, &step)
+		}
+		result.Steps = append(result.Steps, step)
+		result.Response = resp
+
+		if len(calls) == 0 {
+			return result, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// Chain the next turn with the tool outputs
+		outputs := make([]aisdk.InputItem, len(step.ToolResults))
+		for j, tr := range step.ToolResults {
+			outputs[j] = aisdk.NewFunctionCallOutput(tr.Call.CallID, tr.Output)
+		}
+		stepReq.Input = outputs
+		stepReq.PreviousResponseID = resp.ID
+		stepReq.ToolChoice = nil
+	}
+
+	return result, ErrMaxStepsExceeded
+}
+
+// call performs one model call, streaming if configured.
+func (r *Runner) call(ctx context.Context, req *aisdk.CreateResponseRequest) (*aisdk.Response, error) {
+	if !r.config.Stream {
+		return r.provider.CreateResponse(ctx, req)
+	}
+
+	stream, err := r.provider.StreamResponse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var items []aisdk.OutputItem
+	for {
+		event, err := stream.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrIncompleteStream
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if r.config.OnStreamEvent != nil {
+			r.config.OnStreamEvent(ctx, event)
+		}
+
+		if event.Error != nil {
+			return nil, aisdk.NewAPIError(0, event.Error.Code, event.Error.Message, "")
+		}
+
+		switch event.Type {
+		case aisdk.EventOutputItemDone:
+			if event.Output != nil {
+				items = append(items, *event.Output)
+			}
+		case aisdk.EventResponseCompleted:
+			resp := event.Response
+			if resp == nil {
+				resp = &aisdk.Response{ID: event.ResponseID}
+			}
+			if len(resp.Output) == 0 {
+				resp.Output = items
+			}
+			if event.Usage != nil {
+				resp.Usage = *event.Usage
+			}
+			return resp, nil
+		}
+	}
+}
+
+// mergeTools returns tools followed by defs, dropping tools whose name is
+// also in defs so the registered definition wins.
+func mergeTools(tools, defs []aisdk.Tool) []aisdk.Tool {
+	registered := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		registered[def.Name] = true
+	}
+
+	merged := make([]aisdk.Tool, 0, len(tools)+len(defs))
+	for _, tool := range tools {
+		if !registered[tool.Name] {
+			merged = append(merged, tool)
+		}
+	}
+	return append(merged, defs...)
+}
+
+// executeAll runs calls concurrently, bounded by MaxConcurrency, and returns
+// their results in call order.
+func (r *Runner) executeAll(ctx context.Context, calls []aisdk.FunctionCall) []ToolResult {
+	results := make([]ToolResult, len(calls))
+	sem := make(chan struct{}, r.config.MaxConcurrency)
+
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = ToolResult{Call: call, Err: ctx.Err(), Output: "error: " + ctx.Err().Error()}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, call aisdk.FunctionCall) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = r.execute(ctx, call)
+		}(i, call)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// execute runs a single call, converting unknown tools, handler errors and
+// panics into an error output the model can see.
+func (r *Runner) execute(ctx context.Context, call aisdk.FunctionCall) (result ToolResult) {
+	result.Call = call
+
+	defer func() {
+		if p := recover(); p != nil {
+			result.Err = fmt.Errorf("tool %q panicked: %v", call.Name, p)
+			result.Output = "error: " + result.Err.Error()
+		}
+	}()
+
+	tool, ok := r.registry.Lookup(call.Name)
+	if !ok {
+		result.Err = fmt.Errorf("unknown tool %q", call.Name)
+		result.Output = "error: " + result.Err.Error()
+		return result
+	}
+
+	output, err := tool.Handler(ctx, call.Arguments)
+	if err != nil {
+		result.Err = err
+		result.Output = "error: " + err.Error()
+		return result
+	}
+	result.Output = output
+	return result
+}
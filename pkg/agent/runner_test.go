@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestMergeTools(t *testing.T) {
+	tool := func(name, description string) aisdk.Tool {
+		return aisdk.Tool{Type: "function", Name: name, Description: description}
+	}
+
+	tests := []struct {
+		name  string
+		tools []aisdk.Tool
+		defs  []aisdk.Tool
+		want  []aisdk.Tool
+	}{
+		{
+			name: "no request tools",
+			defs: []aisdk.Tool{tool("a", "registered")},
+			want: []aisdk.Tool{tool("a", "registered")},
+		},
+		{
+			name:  "distinct names",
+			tools: []aisdk.Tool{tool("b", "request")},
+			defs:  []aisdk.Tool{tool("a", "registered")},
+			want:  []aisdk.Tool{tool("b", "request"), tool("a", "registered")},
+		},
+		{
+			name:  "registered definition wins",
+			tools: []aisdk.Tool{tool("a", "request"), tool("b", "request")},
+			defs:  []aisdk.Tool{tool("a", "registered")},
+			want:  []aisdk.Tool{tool("b", "request"), tool("a", "registered")},
+		},
+		{
+			name:  "empty registry",
+			tools: []aisdk.Tool{tool("a", "request")},
+			want:  []aisdk.Tool{tool("a", "request")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeTools(tt.tools, tt.defs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeTools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	registry, err := NewRegistry(Tool{
+		Definition: aisdk.Tool{Type: "function", Name: "block", Parameters: map[string]interface{}{"type": "object"}},
+		Handler: func(ctx context.Context, arguments string) (string, error) {
+			close(started)
+			<-ctx.Done()
+			return "", ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	r := &Runner{registry: registry, config: &Config{MaxConcurrency: 1}}
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	calls := []aisdk.FunctionCall{{CallID: "1", Name: "block"}, {CallID: "2", Name: "block"}}
+	results := r.executeAll(ctx, calls)
+	for i, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("results[%d].Err = %v, want context.Canceled", i, result.Err)
+		}
+	}
+}
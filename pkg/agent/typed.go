@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/schema"
+)
+
+// Validator is implemented by argument types that check their own invariants
+// after decoding. NewTool calls Validate before invoking the function.
+type Validator interface {
+	Validate() error
+}
+
+// NewTool creates a Tool from a typed Go function.
+// The strict parameter schema is derived from Args via schema.StrictFor; model-supplied
+// arguments are validated against the schema, decoded into Args (and checked
+// with Args.Validate if implemented), and the Result is encoded as
+// JSON (strings are passed through unchanged) for the function_call_output.
+func NewTool[Args, Result any](name, description string, fn func(ctx context.Context, args Args) (Result, error)) (Tool, error) {
+	params, err := schema.StrictFor[Args]()
+	if err != nil {
+		return Tool{}, aisdk.WrapError(err, "derive schema for tool "+name)
+	}
+
+	def := aisdk.NewFunctionTool(name, description, params)
+	if err := def.Validate(); err != nil {
+		return Tool{}, err
+	}
+
+	validator, err := schema.Compile(params)
+	if err != nil {
+		return Tool{}, aisdk.WrapError(err, "compile schema for tool "+name)
+	}
+
+	handler := func(ctx context.Context, arguments string) (string, error) {
+		args, err := decodeArguments[Args](arguments, validator)
+		if err != nil {
+			return "", err
+		}
+
+		result, err := fn(ctx, args)
+		if err != nil {
+			return "", err
+		}
+		return encodeResult(result)
+	}
+
+	return Tool{Definition: def, Handler: handler}, nil
+}
+
+// MustNewTool is like NewTool but panics on error.
+// Intended for package-level tool declarations.
+func MustNewTool[Args, Result any](name, description string, fn func(ctx context.Context, args Args) (Result, error)) Tool {
+	tool, err := NewTool(name, description, fn)
+	if err != nil {
+		panic(err)
+	}
+	return tool
+}
+
+// decodeArguments validates the JSON arguments against the tool schema and
+// decodes them into Args. Validating first matters because a missing field
+// would otherwise silently decode to its zero value.
+func decodeArguments[Args any](arguments string, validator *schema.Validator) (Args, error) {
+	var args Args
+	if arguments == "" {
+		arguments = "{}"
+	}
+
+	if err := validator.Validate([]byte(arguments)); err != nil {
+		return args, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return args, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if v, ok := any(&args).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return args, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	return args, nil
+}
+
+// encodeResult converts a handler result to the function_call_output string.
+func encodeResult(result interface{}) (string, error) {
+	if s, ok := result.(string); ok {
+		return s, nil
+	}
+	out, err := json.Marshal(result)
+	if err != nil {
+		return "", aisdk.WrapError(err, "encode tool result")
+	}
+	return string(out), nil
+}
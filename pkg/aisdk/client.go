@@ -2,6 +2,7 @@ package aisdk
 
 import (
 	"context"
+	"slices"
 )
 
 // Provider defines the contract that all AI providers must implement.
@@ -15,6 +16,20 @@ type Provider interface {
 	StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error)
 }
 
+// ConfigurableProvider is implemented by providers that honor client-level
+// settings. New calls Configure and wraps the provider it returns with
+// middleware, so that the ClientConfig fields that are set (APIKey, BaseURL,
+// Timeout, HTTPClient, Headers, Logger, TelemetryHooks, RateLimiter and so
+// on) reach the request path while the provider passed to New, which may be
+// shared by several Clients, is left unchanged.
+type ConfigurableProvider interface {
+	Provider
+
+	// Configure returns a copy of the provider with config applied; the
+	// receiver is not modified.
+	Configure(config *ClientConfig) Provider
+}
+
 // Client is the main SDK client for making AI API requests.
 // Reference: architecture.md (Public API Layer)
 type Client struct {
@@ -23,9 +38,22 @@ type Client struct {
 }
 
 // New creates a new Client with the given configuration and provider.
-// Validates config and initializes the client.
+// Applies opts to a copy of config (DefaultConfig when nil), validates it,
+// applies it to a copy of provider (see ConfigurableProvider), wraps that
+// with config.Middleware and initializes the client.
 // Reference: data-model.md Entity #1
-func New(config *ClientConfig, provider Provider) (*Client, error) {
+func New(config *ClientConfig, provider Provider, opts ...Option) (*Client, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	configured := *config
+	configured.Middleware = slices.Clone(config.Middleware)
+	configured.apply(NewOptions(opts...))
+	return newClient(&configured, provider)
+}
+
+// newClient validates config and creates the Client.
+func newClient(config *ClientConfig, provider Provider) (*Client, error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
@@ -34,32 +62,51 @@ func New(config *ClientConfig, provider Provider) (*Client, error) {
 		return nil, NewAPIError(0, "invalid_config", "provider is required", "")
 	}
 
+	if configurable, ok := provider.(ConfigurableProvider); ok {
+		provider = configurable.Configure(config)
+	}
+
 	return &Client{
 		config:   config,
-		provider: provider,
+		provider: Chain(provider, config.Middleware...),
 	}, nil
 }
 
 // CreateResponse makes a non-streaming request to the AI provider.
 // Reference: Provider interface pattern
 func (c *Client) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	req = c.withDefaultModel(req)
+
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return nil, WrapError(err, "invalid request")
 	}
 
-	// Delegate to provider
+	// Delegate to provider (through the middleware chain)
 	return c.provider.CreateResponse(ctx, req)
 }
 
 // StreamResponse makes a streaming request to the AI provider.
 // Reference: Provider interface pattern
 func (c *Client) StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+	req = c.withDefaultModel(req)
+
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return nil, WrapError(err, "invalid request")
 	}
 
-	// Delegate to provider
+	// Delegate to provider (through the middleware chain)
 	return c.provider.StreamResponse(ctx, req)
 }
+
+// withDefaultModel returns req, or a copy of it using ClientConfig.Model
+// when req sets no model.
+func (c *Client) withDefaultModel(req *CreateResponseRequest) *CreateResponseRequest {
+	if req == nil || req.Model != "" || c.config.Model == "" {
+		return req
+	}
+	withModel := *req
+	withModel.Model = c.config.Model
+	return &withModel
+}
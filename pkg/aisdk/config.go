@@ -1,34 +1,81 @@
 package aisdk
 
 import (
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/amannhq/go-ai-sdk/pkg/middleware"
 )
 
-// ClientConfig configures the AI SDK client.
+// ClientConfig configures the AI SDK client. New applies it to the
+// provider (see ConfigurableProvider); set fields override the provider's
+// own configuration, so a setting can be made in either place. The same
+// settings are available as Options.
 // Reference: data-model.md Entity #1
 type ClientConfig struct {
-	// APIKey is the provider API key (required)
-	// Can be provided directly or loaded from environment variable
+	// APIKey overrides the provider API key (optional; providers validate
+	// and load their own keys, e.g. openai.NewFromEnv)
 	APIKey string
 
-	// BaseURL is the provider API base URL
+	// Credentials supplies the API key per request, overriding the
+	// provider's own key; see CredentialProvider (optional)
+	Credentials CredentialProvider
+
+	// BaseURL overrides the provider API base URL (optional)
 	BaseURL string
 
-	// Timeout is the HTTP request timeout (default: 60s)
+	// Organization and Project select the OpenAI organization and project
+	// billed for requests (optional; other providers ignore them)
+	Organization string
+	Project      string
+
+	// Headers are sent with every request, replacing provider headers of
+	// the same name (optional)
+	Headers http.Header
+
+	// HTTPClient replaces the provider's default pooled http.Client
+	// (optional); Timeout applies when the client sets none
+	HTTPClient *http.Client
+
+	// Transport configures the provider's HTTP transport: a custom
+	// RoundTripper, proxy, TLS and RoundTripper middleware (optional;
+	// merged into the provider's, see TransportConfig.Merge)
+	Transport *TransportConfig
+
+	// Model is the default model for requests that set none (optional)
+	Model string
+
+	// Timeout overrides the provider's HTTP request timeout when positive
+	// (optional; providers default to 60s, Ollama to 5m)
 	Timeout time.Duration
 
-	// MaxRetries is the maximum retry attempts for transient failures (default: 3)
+	// MaxRetries overrides the provider's maximum retry attempts for
+	// transient failures when positive (optional; providers default to 3).
+	// 0 keeps the provider's count: disable retries with a Retry whose
+	// MaxRetries is 0 (WithRetry or WithMaxRetries(0))
 	MaxRetries int
 
+	// Retry overrides the provider's retry policy, including MaxRetries, so
+	// that a Retry with MaxRetries 0 disables retries (optional)
+	Retry *middleware.RetryConfig
+
 	// Logger is an optional structured logger interface for telemetry
 	// If nil, logging is disabled
 	Logger Logger
 
 	// TelemetryHooks provides optional observability callbacks
 	TelemetryHooks *middleware.TelemetryHooks
+
+	// RateLimiter paces requests per API key and model, learning budgets
+	// from response headers (optional). Every HTTP attempt, retries
+	// included, is charged. Share one limiter across clients that use the
+	// same keys.
+	RateLimiter *middleware.RateLimiter
+
+	// Middleware wraps the provider for every CreateResponse and
+	// StreamResponse call; the first entry is the outermost (see Chain)
+	Middleware []Middleware
 }
 
 // Logger is a simple logging interface for telemetry
@@ -39,16 +86,14 @@ type Logger interface {
 
 // DefaultConfig returns a ClientConfig with default values
 func DefaultConfig() *ClientConfig {
-	return &ClientConfig{
-		BaseURL:    "https://api.openai.com/v1",
-		Timeout:    60 * time.Second,
-		MaxRetries: 3,
-	}
+	return &ClientConfig{}
 }
 
 // NewConfigFromEnv creates a ClientConfig loading the API key from environment.
 // Reads OPENAI_API_KEY environment variable.
-// Reference: FR-013 (environment-based configuration)
+//
+// Deprecated: providers load their own keys (e.g. openai.NewFromEnv); use
+// DefaultConfig, or EnvCredentials to read a key on every request.
 func NewConfigFromEnv() (*ClientConfig, error) {
 	apiKey := os.Getenv("OPENAI_API_KEY")
 	if apiKey == "" {
@@ -60,13 +105,10 @@ func NewConfigFromEnv() (*ClientConfig, error) {
 	return config, nil
 }
 
-// Validate checks ClientConfig for required fields and constraint violations.
+// Validate checks ClientConfig for constraint violations.
 // Returns descriptive error per SC-006 (actionable error messages).
 func (c *ClientConfig) Validate() error {
-	if c.APIKey == "" {
-		return ErrMissingAPIKey
-	}
-	if c.Timeout <= 0 {
+	if c.Timeout < 0 {
 		return ErrInvalidTimeout
 	}
 	if c.MaxRetries < 0 {
@@ -74,3 +116,47 @@ func (c *ClientConfig) Validate() error {
 	}
 	return nil
 }
+
+// apply sets the fields given by options.
+func (c *ClientConfig) apply(options *Options) {
+	if options.APIKey != "" {
+		c.APIKey = options.APIKey
+	}
+	if options.Credentials != nil {
+		c.Credentials = options.Credentials
+	}
+	if options.BaseURL != "" {
+		c.BaseURL = options.BaseURL
+	}
+	if options.Organization != "" {
+		c.Organization = options.Organization
+	}
+	if options.Project != "" {
+		c.Project = options.Project
+	}
+	c.Headers = MergeHeaders(c.Headers, options.Headers)
+	if options.HTTPClient != nil {
+		c.HTTPClient = options.HTTPClient
+	}
+	c.Transport = c.Transport.Merge(options.Transport)
+	if options.Timeout > 0 {
+		c.Timeout = options.Timeout
+	}
+	if options.Retry != nil {
+		c.Retry = options.Retry
+		c.MaxRetries = options.Retry.MaxRetries
+	}
+	if options.Logger != nil {
+		c.Logger = options.Logger
+	}
+	if options.TelemetryHooks != nil {
+		c.TelemetryHooks = options.TelemetryHooks
+	}
+	if options.RateLimiter != nil {
+		c.RateLimiter = options.RateLimiter
+	}
+	if options.Model != "" {
+		c.Model = options.Model
+	}
+	c.Middleware = append(c.Middleware, options.Middleware...)
+}
@@ -0,0 +1,282 @@
+package aisdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credential is an API key or bearer token.
+type Credential struct {
+	// Value is the key or token sent with requests
+	Value string
+
+	// ExpiresAt is when the credential stops being valid (zero if unknown)
+	ExpiresAt time.Time
+}
+
+// CredentialProvider supplies the credential for each request, so keys can
+// be rotated and short-lived tokens refreshed without recreating clients.
+// Providers consult it before every HTTP attempt, retries included.
+// Implementations must be safe for concurrent use.
+type CredentialProvider interface {
+	// Credential returns the credential to use now
+	Credential(ctx context.Context) (Credential, error)
+}
+
+// CredentialInvalidator is implemented by CredentialProviders that cache.
+// When a request is rejected with HTTP 401, providers call Invalidate with
+// the rejected value, then retry once if Credential returns a different
+// one. Providers that resolve the credential on every call, such as
+// EnvCredentials and CommandCredentials, need not implement it.
+type CredentialInvalidator interface {
+	// Invalidate discards the cached credential if it still equals value
+	Invalidate(value string)
+}
+
+// CredentialResult is the outcome of an HTTP attempt made with a credential.
+type CredentialResult struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+
+	// RateLimit holds the rate limit headers of the response
+	RateLimit *RateLimitInfo
+
+	// RetryDelay is how long the server asked to wait before the next
+	// request: Retry-After, or the reset of an exhausted budget (0 if none)
+	RetryDelay time.Duration
+}
+
+// CredentialObserver is implemented by CredentialProviders that track the
+// health of their credentials, such as KeyPool. Providers report the
+// result of every HTTP attempt; after a 429, a retry uses a different
+// credential without waiting when Credential returns one.
+type CredentialObserver interface {
+	// Observe records the result of an attempt made with the credential value
+	Observe(value string, result CredentialResult)
+}
+
+// CredentialFunc adapts a function to the CredentialProvider interface.
+type CredentialFunc func(ctx context.Context) (Credential, error)
+
+// Credential implements CredentialProvider.
+func (f CredentialFunc) Credential(ctx context.Context) (Credential, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a CredentialProvider that always supplies key.
+func StaticCredentials(key string) CredentialProvider {
+	return CredentialFunc(func(context.Context) (Credential, error) {
+		if key == "" {
+			return Credential{}, ErrMissingCredential
+		}
+		return Credential{Value: key}, nil
+	})
+}
+
+// EnvCredentials returns a CredentialProvider that reads the named
+// environment variable on every request, picking up changes made by the
+// process (e.g. a secrets agent calling os.Setenv).
+func EnvCredentials(name string) CredentialProvider {
+	return CredentialFunc(func(context.Context) (Credential, error) {
+		value := os.Getenv(name)
+		if value == "" {
+			return Credential{}, fmt.Errorf("%w: %s is not set", ErrMissingCredential, name)
+		}
+		return Credential{Value: value}, nil
+	})
+}
+
+// FileCredentialProvider reads the credential from a file, such as a
+// mounted Kubernetes secret. The file is not watched: every call stats it
+// and re-reads it when its size or modification time changed, so a rotated
+// secret is picked up by the next request. Surrounding whitespace is trimmed.
+type FileCredentialProvider struct {
+	path string
+
+	mu      sync.Mutex
+	value   string
+	modTime time.Time
+	size    int64
+}
+
+// FileCredentials returns a FileCredentialProvider for the file at path.
+func FileCredentials(path string) *FileCredentialProvider {
+	return &FileCredentialProvider{path: path}
+}
+
+// Credential implements CredentialProvider.
+func (p *FileCredentialProvider) Credential(ctx context.Context) (Credential, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return Credential{}, WrapError(err, "read credential file")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.value == "" || !info.ModTime().Equal(p.modTime) || info.Size() != p.size {
+		data, err := os.ReadFile(p.path)
+		if err != nil {
+			return Credential{}, WrapError(err, "read credential file")
+		}
+		p.value = strings.TrimSpace(string(data))
+		p.modTime, p.size = info.ModTime(), info.Size()
+	}
+
+	if p.value == "" {
+		return Credential{}, fmt.Errorf("%w: %s is empty", ErrMissingCredential, p.path)
+	}
+	return Credential{Value: p.value}, nil
+}
+
+// Invalidate implements CredentialInvalidator, forcing the file to be
+// re-read on the next request.
+func (p *FileCredentialProvider) Invalidate(value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.value == value {
+		p.value = ""
+	}
+}
+
+// CommandCredentials returns a CredentialProvider that runs a command and
+// uses its trimmed standard output, e.g. a cloud CLI printing an access
+// token. The command runs on every call; wrap it in CachingCredentials.
+func CommandCredentials(name string, args ...string) CredentialProvider {
+	return CredentialFunc(func(ctx context.Context) (Credential, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+			return Credential{}, WrapError(err, "run credential command "+name)
+		}
+
+		value := strings.TrimSpace(stdout.String())
+		if value == "" {
+			return Credential{}, fmt.Errorf("%w: %s printed nothing", ErrMissingCredential, name)
+		}
+		return Credential{Value: value}, nil
+	})
+}
+
+// credentialRefreshMargin is how long before ExpiresAt a cached credential
+// is refreshed, so it does not expire in flight
+const credentialRefreshMargin = time.Minute
+
+// credentialMinRefresh is the shortest time a fetched credential is cached,
+// so a credential that is already near expiry is not fetched on every call
+const credentialMinRefresh = 5 * time.Second
+
+// credentialFetchTimeout bounds a shared fetch, which outlives the context
+// of the caller that started it
+const credentialFetchTimeout = time.Minute
+
+// CachingCredentialProvider caches the credential of another provider,
+// fetching a fresh one when the TTL elapses, shortly before the
+// credential's ExpiresAt, or after Invalidate. Concurrent callers share a
+// single fetch, which is not canceled when they give up waiting (it is
+// bounded by a one-minute timeout instead).
+type CachingCredentialProvider struct {
+	source CredentialProvider
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	cached    Credential
+	refreshAt time.Time
+	fetch     *credentialFetch
+}
+
+// credentialFetch is a fetch from the source shared by concurrent callers.
+type credentialFetch struct {
+	done       chan struct{}
+	credential Credential
+	err        error
+}
+
+// CachingCredentials wraps source in a CachingCredentialProvider. A ttl of
+// 0 caches the credential until it nears ExpiresAt or is invalidated.
+func CachingCredentials(source CredentialProvider, ttl time.Duration) *CachingCredentialProvider {
+	return &CachingCredentialProvider{source: source, ttl: ttl}
+}
+
+// Credential implements CredentialProvider. It returns ctx.Err() when ctx
+// ends before the credential is fetched.
+func (p *CachingCredentialProvider) Credential(ctx context.Context) (Credential, error) {
+	p.mu.Lock()
+	if p.cached.Value != "" && (p.refreshAt.IsZero() || time.Now().Before(p.refreshAt)) {
+		cached := p.cached
+		p.mu.Unlock()
+		return cached, nil
+	}
+
+	fetch := p.fetch
+	if fetch == nil {
+		fetch = &credentialFetch{done: make(chan struct{})}
+		p.fetch = fetch
+		go p.refresh(context.WithoutCancel(ctx), fetch)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-fetch.done:
+		return fetch.credential, fetch.err
+	case <-ctx.Done():
+		return Credential{}, ctx.Err()
+	}
+}
+
+// refresh fetches a credential from the source and caches it.
+func (p *CachingCredentialProvider) refresh(ctx context.Context, fetch *credentialFetch) {
+	ctx, cancel := context.WithTimeout(ctx, credentialFetchTimeout)
+	credential, err := p.source.Credential(ctx)
+	cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fetch.credential, fetch.err = credential, err
+	p.fetch = nil
+	close(fetch.done)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	p.cached = credential
+	p.refreshAt = time.Time{}
+	if p.ttl > 0 {
+		p.refreshAt = now.Add(p.ttl)
+	}
+	if !credential.ExpiresAt.IsZero() {
+		at := credential.ExpiresAt.Add(-credentialRefreshMargin)
+		if earliest := now.Add(credentialMinRefresh); at.Before(earliest) {
+			at = earliest
+		}
+		if p.refreshAt.IsZero() || at.Before(p.refreshAt) {
+			p.refreshAt = at
+		}
+	}
+}
+
+// Invalidate implements CredentialInvalidator. The source is invalidated
+// too when it caches.
+func (p *CachingCredentialProvider) Invalidate(value string) {
+	p.mu.Lock()
+	if p.cached.Value == value {
+		p.cached = Credential{}
+	}
+	p.mu.Unlock()
+
+	if invalidator, ok := p.source.(CredentialInvalidator); ok {
+		invalidator.Invalidate(value)
+	}
+}
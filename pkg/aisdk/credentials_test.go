@@ -0,0 +1,269 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaticAndEnvCredentials(t *testing.T) {
+	t.Setenv("TEST_AISDK_KEY", "sk-env")
+
+	tests := []struct {
+		name     string
+		provider CredentialProvider
+		want     string
+		wantErr  error
+	}{
+		{name: "static", provider: StaticCredentials("sk-static"), want: "sk-static"},
+		{name: "empty static", provider: StaticCredentials(""), wantErr: ErrMissingCredential},
+		{name: "env", provider: EnvCredentials("TEST_AISDK_KEY"), want: "sk-env"},
+		{name: "unset env", provider: EnvCredentials("TEST_AISDK_UNSET"), wantErr: ErrMissingCredential},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.Credential(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Credential() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.Value != tt.want {
+				t.Errorf("Credential() = %q, want %q", got.Value, tt.want)
+			}
+		})
+	}
+
+	// The variable is read on every call
+	t.Setenv("TEST_AISDK_KEY", "sk-rotated")
+	if got, _ := EnvCredentials("TEST_AISDK_KEY").Credential(context.Background()); got.Value != "sk-rotated" {
+		t.Errorf("Credential() after Setenv = %q, want sk-rotated", got.Value)
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func(p *FileCredentialProvider, want string) {
+		t.Helper()
+		got, err := p.Credential(context.Background())
+		if err != nil {
+			t.Fatalf("Credential() error = %v", err)
+		}
+		if got.Value != want {
+			t.Errorf("Credential() = %q, want %q", got.Value, want)
+		}
+	}
+
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write("sk-one\n", modTime)
+	p := FileCredentials(path)
+	check(p, "sk-one")
+
+	// Same size and modification time: the cached value is kept
+	write("sk-two\n", modTime)
+	check(p, "sk-one")
+
+	// Invalidate forces a re-read
+	p.Invalidate("sk-one")
+	check(p, "sk-two")
+
+	// A changed modification time is picked up
+	write("sk-333\n", modTime.Add(time.Minute))
+	check(p, "sk-333")
+
+	write("  \n", modTime.Add(2*time.Minute))
+	if _, err := p.Credential(context.Background()); !errors.Is(err, ErrMissingCredential) {
+		t.Errorf("Credential() of an empty file error = %v, want ErrMissingCredential", err)
+	}
+
+	if _, err := FileCredentials(filepath.Join(t.TempDir(), "missing")).Credential(context.Background()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Credential() of a missing file error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestCommandCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    string
+		wantErr string
+	}{
+		{name: "token", script: "echo ' tok-123 '", want: "tok-123"},
+		{name: "no output", script: "true", wantErr: "printed nothing"},
+		{name: "failure", script: "echo 'not logged in' >&2; exit 1", wantErr: "not logged in"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CommandCredentials("sh", "-c", tt.script).Credential(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Credential() error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Credential() error = %v", err)
+			}
+			if got.Value != tt.want {
+				t.Errorf("Credential() = %q, want %q", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+// countingSource returns "tok-<n>" for its n-th call, expiring after
+// expiresIn when it is set.
+type countingSource struct {
+	calls     atomic.Int32
+	expiresIn time.Duration
+	err       error
+}
+
+func (s *countingSource) Credential(ctx context.Context) (Credential, error) {
+	n := s.calls.Add(1)
+	if s.err != nil {
+		return Credential{}, s.err
+	}
+	c := Credential{Value: "tok-" + strconv.Itoa(int(n))}
+	if s.expiresIn != 0 {
+		c.ExpiresAt = time.Now().Add(s.expiresIn)
+	}
+	return c, nil
+}
+
+func TestCachingCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		expiresIn time.Duration
+		between   func(p *CachingCredentialProvider)
+		want      string
+		wantCalls int32
+	}{
+		{
+			name:      "cached without ttl or expiry",
+			want:      "tok-1",
+			wantCalls: 1,
+		},
+		{
+			name:      "refreshed after ttl",
+			ttl:       time.Millisecond,
+			between:   func(*CachingCredentialProvider) { time.Sleep(5 * time.Millisecond) },
+			want:      "tok-2",
+			wantCalls: 2,
+		},
+		{
+			name:      "cached until near expiry",
+			expiresIn: time.Hour,
+			want:      "tok-1",
+			wantCalls: 1,
+		},
+		{
+			name:      "credential near expiry is still cached briefly",
+			expiresIn: 30 * time.Second,
+			want:      "tok-1",
+			wantCalls: 1,
+		},
+		{
+			name:      "invalidated",
+			between:   func(p *CachingCredentialProvider) { p.Invalidate("tok-1") },
+			want:      "tok-2",
+			wantCalls: 2,
+		},
+		{
+			name:      "invalidating another value keeps the cache",
+			between:   func(p *CachingCredentialProvider) { p.Invalidate("tok-0") },
+			want:      "tok-1",
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &countingSource{expiresIn: tt.expiresIn}
+			p := CachingCredentials(source, tt.ttl)
+
+			if _, err := p.Credential(context.Background()); err != nil {
+				t.Fatalf("Credential() error = %v", err)
+			}
+			if tt.between != nil {
+				tt.between(p)
+			}
+			got, err := p.Credential(context.Background())
+			if err != nil {
+				t.Fatalf("Credential() error = %v", err)
+			}
+
+			if got.Value != tt.want {
+				t.Errorf("Credential() = %q, want %q", got.Value, tt.want)
+			}
+			if calls := source.calls.Load(); calls != tt.wantCalls {
+				t.Errorf("source calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachingCredentialsErrorsAreNotCached(t *testing.T) {
+	source := &countingSource{err: errors.New("token endpoint down")}
+	p := CachingCredentials(source, 0)
+
+	if _, err := p.Credential(context.Background()); err == nil {
+		t.Fatal("Credential() error = nil, want the source error")
+	}
+	source.err = nil
+	if got, err := p.Credential(context.Background()); err != nil || got.Value != "tok-2" {
+		t.Errorf("Credential() = %q, %v, want tok-2", got.Value, err)
+	}
+}
+
+func TestCachingCredentialsSharedFetch(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	p := CachingCredentials(CredentialFunc(func(ctx context.Context) (Credential, error) {
+		calls.Add(1)
+		<-release
+		return Credential{Value: "tok"}, nil
+	}), 0)
+
+	// A caller that gives up gets its context error without ending the fetch
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.Credential(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Credential() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := p.Credential(context.Background()); err != nil || got.Value != "tok" {
+				t.Errorf("Credential() = %q, %v, want tok", got.Value, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("source calls = %d, want 1", n)
+	}
+}
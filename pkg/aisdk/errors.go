@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/schema"
 )
 
 // Common error variables
@@ -17,6 +19,27 @@ var (
 	// ErrMissingInput indicates that no input was provided in the request
 	ErrMissingInput = errors.New("Input is required (string or []Message)")
 
+	// ErrInvalidInputType indicates that Input has an unsupported Go type
+	ErrInvalidInputType = errors.New("Input must be a string, Message, []Message, or []InputItem")
+
+	// ErrInvalidMessageRole indicates that a message role is not recognized
+	ErrInvalidMessageRole = errors.New("Message role must be 'user', 'assistant', 'system', or 'developer'")
+
+	// ErrMissingMessageContent indicates that a message has no content parts
+	ErrMissingMessageContent = errors.New("Message content is required")
+
+	// ErrInvalidContentPart indicates that a message content part is malformed
+	ErrInvalidContentPart = errors.New("invalid content part")
+
+	// ErrMissingCallID indicates that a function call output has no call ID
+	ErrMissingCallID = errors.New("CallID is required for function_call_output items")
+
+	// ErrInvalidTool indicates that a tool definition is invalid
+	ErrInvalidTool = errors.New("invalid tool")
+
+	// ErrInvalidToolChoice indicates that the tool choice is invalid
+	ErrInvalidToolChoice = errors.New("invalid tool choice")
+
 	// ErrInvalidTemperature indicates that temperature is out of range
 	ErrInvalidTemperature = errors.New("Temperature must be between 0.0 and 2.0")
 
@@ -32,8 +55,25 @@ var (
 	// ErrInvalidTextFormat indicates that text format configuration is invalid
 	ErrInvalidTextFormat = errors.New("Structured outputs require strict: true")
 
+	// ErrStrictSchema indicates a schema that violates strict-mode rules
+	// (see docs/providers/openai.md "Supported schemas")
+	ErrStrictSchema = schema.ErrStrictSchema
+
 	// ErrInvalidReasoningEffort indicates that reasoning effort is invalid
 	ErrInvalidReasoningEffort = errors.New("Reasoning effort must be 'low', 'medium', or 'high'")
+
+	// ErrMissingCredential indicates that a CredentialProvider has no credential
+	ErrMissingCredential = errors.New("credential unavailable")
+
+	// ErrUnknownModel indicates that a Router has no route for a model
+	ErrUnknownModel = errors.New("model matches no Router provider or alias")
+
+	// ErrUnknownProvider indicates that Open found no registered provider
+	ErrUnknownProvider = errors.New("unknown provider")
+
+	// ErrInvalidConnectionString indicates a malformed Open connection
+	// string or ConnectionConfig
+	ErrInvalidConnectionString = errors.New("invalid connection string")
 )
 
 // APIError represents an error returned by an AI provider's API.
@@ -62,18 +102,36 @@ func (e *APIError) Error() string {
 }
 
 // RateLimitInfo contains rate limit state extracted from HTTP headers.
+// Requests and tokens are limited independently; a zero limit means the
+// provider did not report that dimension.
 // Reference: docs/providers/openai.md lines 8-931 (implied by HTTP rate limit headers)
 type RateLimitInfo struct {
 	// Limit is the maximum requests allowed in the time window
+	// (x-ratelimit-limit-requests)
 	Limit int
 
 	// Remaining is the requests left in the current window
+	// (x-ratelimit-remaining-requests)
 	Remaining int
 
-	// ResetAt is when the rate limit window resets
+	// ResetAt is when the request budget is fully replenished
+	// (x-ratelimit-reset-requests)
 	ResetAt time.Time
 
-	// RetryAfter is the delay before retrying (from Retry-After header)
+	// LimitTokens is the maximum tokens allowed in the time window
+	// (x-ratelimit-limit-tokens)
+	LimitTokens int
+
+	// RemainingTokens is the tokens left in the current window
+	// (x-ratelimit-remaining-tokens)
+	RemainingTokens int
+
+	// ResetTokensAt is when the token budget is fully replenished
+	// (x-ratelimit-reset-tokens)
+	ResetTokensAt time.Time
+
+	// RetryAfter is the delay before retrying (from retry-after-ms or
+	// Retry-After, as seconds or an HTTP date)
 	// Only populated on 429 responses
 	RetryAfter time.Duration
 }
@@ -93,7 +151,10 @@ func (e *RateLimitError) Error() string {
 	if e.RateLimitInfo != nil && e.RateLimitInfo.RetryAfter > 0 {
 		return fmt.Sprintf("%s (retry_after=%v)", baseErr, e.RateLimitInfo.RetryAfter)
 	}
-	if e.RateLimitInfo != nil && e.RateLimitInfo.Remaining >= 0 {
+	if e.RateLimitInfo != nil && e.RateLimitInfo.LimitTokens > 0 && e.RateLimitInfo.RemainingTokens <= 0 {
+		return fmt.Sprintf("%s (remaining_tokens=%d/%d)", baseErr, e.RateLimitInfo.RemainingTokens, e.RateLimitInfo.LimitTokens)
+	}
+	if e.RateLimitInfo != nil && e.RateLimitInfo.Limit > 0 {
 		return fmt.Sprintf("%s (remaining=%d/%d)", baseErr, e.RateLimitInfo.Remaining, e.RateLimitInfo.Limit)
 	}
 	return baseErr
@@ -104,6 +165,35 @@ func (e *RateLimitError) Unwrap() error {
 	return e.APIError
 }
 
+// ValidationError describes a single JSON Schema violation at a JSON pointer Path.
+type ValidationError = schema.ValidationError
+
+// ValidationErrors lists every violation found when validating a document
+// (structured output or tool arguments) against its schema.
+type ValidationErrors = schema.ValidationErrors
+
+// RefusalError is returned by GenerateObject when the model refuses to
+// produce structured output (e.g. for safety reasons).
+// Reference: docs/providers/openai.md lines 2194-4038 (refusals)
+type RefusalError struct {
+	// Refusal is the model's explanation
+	Refusal string
+
+	// Response is the full response containing the refusal
+	Response *Response
+}
+
+// Error implements the error interface
+func (e *RefusalError) Error() string {
+	return fmt.Sprintf("model refused to generate object: %s", e.Refusal)
+}
+
+// IsRefusal checks if an error is a RefusalError
+func IsRefusal(err error) bool {
+	var refusalErr *RefusalError
+	return errors.As(err, &refusalErr)
+}
+
 // NewAPIError creates a new APIError with the given details
 func NewAPIError(statusCode int, code, message, correlationID string) *APIError {
 	return &APIError{
@@ -0,0 +1,305 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BalanceStrategy selects how a KeyPool distributes requests across keys.
+type BalanceStrategy int
+
+const (
+	// RoundRobin uses the available keys in turn
+	RoundRobin BalanceStrategy = iota
+
+	// LeastRecentlyRateLimited prefers the key whose last 429 is oldest
+	// (keys never rate limited first), taking keys in turn on ties
+	LeastRecentlyRateLimited
+
+	// Weighted distributes requests in proportion to PoolKey.Weight
+	// (smooth weighted round-robin)
+	Weighted
+)
+
+// PoolKey is one API key of a KeyPool.
+type PoolKey struct {
+	// Key is the API key (required)
+	Key string
+
+	// Name identifies the key in KeyStats, e.g. the project it belongs to
+	// (default: the last four characters of Key)
+	Name string
+
+	// Weight is the key's share of traffic under Weighted, where it must
+	// be positive (other strategies ignore it)
+	Weight int
+}
+
+// KeyPoolConfig configures a KeyPool.
+type KeyPoolConfig struct {
+	// Keys are the pooled API keys (at least one required)
+	Keys []PoolKey
+
+	// Strategy selects the next key (default: RoundRobin)
+	Strategy BalanceStrategy
+
+	// RateLimitPark is how long a key is parked after a 429 that carries
+	// no Retry-After or reset headers (default: 10s)
+	RateLimitPark time.Duration
+
+	// UnauthorizedPark is how long a key is parked after a 401 (default: 10m)
+	UnauthorizedPark time.Duration
+}
+
+// KeyStats reports the health and usage of a pooled key.
+type KeyStats struct {
+	// Name identifies the key (see PoolKey.Name)
+	Name string
+
+	// Weight is the key's configured weight
+	Weight int
+
+	// Requests counts the HTTP attempts made with the key
+	Requests int
+
+	// Successes counts 2xx responses
+	Successes int
+
+	// RateLimited counts 429 responses
+	RateLimited int
+
+	// Unauthorized counts 401 responses
+	Unauthorized int
+
+	// Errors counts other non-2xx responses
+	Errors int
+
+	// LastRateLimited is the time of the last 429 (zero if none)
+	LastRateLimited time.Time
+
+	// ParkedUntil is when a parked key becomes available again (zero or
+	// past if the key is available)
+	ParkedUntil time.Time
+
+	// RateLimit holds the rate limit headers of the last response (nil
+	// before the first response)
+	RateLimit *RateLimitInfo
+}
+
+// KeyPool is a CredentialProvider that distributes requests across several
+// API keys, such as keys of different projects, and parks a key after a 429
+// (for its Retry-After) or a 401. When every key is parked, the key that
+// becomes available first is used. Use it as a provider's Credentials; the
+// provider reports every response through Observe. KeyPool is safe for
+// concurrent use.
+type KeyPool struct {
+	strategy         BalanceStrategy
+	rateLimitPark    time.Duration
+	unauthorizedPark time.Duration
+
+	mu     sync.Mutex
+	keys   []pooledKey
+	index  map[string]int
+	cursor int
+}
+
+// pooledKey is a key with its statistics and weighted round-robin state.
+type pooledKey struct {
+	key   string
+	stats KeyStats
+
+	// current is the smooth weighted round-robin counter
+	current int
+}
+
+// NewKeyPool creates a KeyPool from config.
+func NewKeyPool(config KeyPoolConfig) (*KeyPool, error) {
+	if len(config.Keys) == 0 {
+		return nil, errors.New("KeyPool requires at least one key")
+	}
+	if config.Strategy < RoundRobin || config.Strategy > Weighted {
+		return nil, errors.New("KeyPool Strategy must be RoundRobin, LeastRecentlyRateLimited or Weighted")
+	}
+	if config.RateLimitPark < 0 || config.UnauthorizedPark < 0 {
+		return nil, errors.New("KeyPool park durations cannot be negative")
+	}
+
+	p := &KeyPool{
+		strategy:         config.Strategy,
+		rateLimitPark:    config.RateLimitPark,
+		unauthorizedPark: config.UnauthorizedPark,
+		keys:             make([]pooledKey, len(config.Keys)),
+		index:            make(map[string]int, len(config.Keys)),
+	}
+	if p.rateLimitPark == 0 {
+		p.rateLimitPark = 10 * time.Second
+	}
+	if p.unauthorizedPark == 0 {
+		p.unauthorizedPark = 10 * time.Minute
+	}
+
+	for i, k := range config.Keys {
+		if k.Key == "" {
+			return nil, errors.New("KeyPool keys cannot be empty")
+		}
+		if _, ok := p.index[k.Key]; ok {
+			return nil, errors.New("KeyPool keys must be unique")
+		}
+		if k.Weight < 0 {
+			return nil, errors.New("KeyPool key Weight cannot be negative")
+		}
+		if config.Strategy == Weighted && k.Weight == 0 {
+			return nil, errors.New("KeyPool keys need a positive Weight under Weighted; remove a key to exclude it")
+		}
+
+		name := k.Name
+		if name == "" {
+			name = "..." + k.Key[max(0, len(k.Key)-4):]
+		}
+		p.keys[i] = pooledKey{key: k.Key, stats: KeyStats{Name: name, Weight: k.Weight}}
+		p.index[k.Key] = i
+	}
+	return p, nil
+}
+
+// Credential implements CredentialProvider, selecting a key by the pool's
+// strategy among those not parked.
+func (p *KeyPool) Credential(ctx context.Context) (Credential, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var available []int
+	soonest := 0
+	for i := range p.keys {
+		parkedUntil := p.keys[i].stats.ParkedUntil
+		if !parkedUntil.After(now) {
+			available = append(available, i)
+		} else if parkedUntil.Before(p.keys[soonest].stats.ParkedUntil) {
+			soonest = i
+		}
+	}
+
+	if len(available) == 0 {
+		return Credential{Value: p.keys[soonest].key}, nil
+	}
+	return Credential{Value: p.keys[p.next(available)].key}, nil
+}
+
+// next picks one of the available key indexes by the pool's strategy.
+func (p *KeyPool) next(available []int) int {
+	switch p.strategy {
+	case Weighted:
+		total, best := 0, -1
+		for _, i := range available {
+			k := &p.keys[i]
+			k.current += k.stats.Weight
+			total += k.stats.Weight
+			if best < 0 || k.current > p.keys[best].current {
+				best = i
+			}
+		}
+		p.keys[best].current -= total
+		return best
+
+	case LeastRecentlyRateLimited:
+		// Scan from the cursor so ties are taken in turn
+		best := -1
+		for n := 0; n < len(p.keys); n++ {
+			i := (p.cursor + n) % len(p.keys)
+			if !slices.Contains(available, i) {
+				continue
+			}
+			if best < 0 || p.keys[i].stats.LastRateLimited.Before(p.keys[best].stats.LastRateLimited) {
+				best = i
+			}
+		}
+		p.cursor = (best + 1) % len(p.keys)
+		return best
+
+	default:
+		for n := 0; ; n++ {
+			i := (p.cursor + n) % len(p.keys)
+			if slices.Contains(available, i) {
+				p.cursor = (i + 1) % len(p.keys)
+				return i
+			}
+		}
+	}
+}
+
+// Observe implements CredentialObserver, recording the response and
+// parking the key after a 429 or 401.
+func (p *KeyPool) Observe(value string, result CredentialResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.index[value]
+	if !ok {
+		return
+	}
+	stats := &p.keys[i].stats
+	now := time.Now()
+
+	stats.Requests++
+	if result.RateLimit != nil {
+		info := *result.RateLimit
+		stats.RateLimit = &info
+	}
+
+	switch {
+	case result.StatusCode >= 200 && result.StatusCode < 300:
+		stats.Successes++
+	case result.StatusCode == http.StatusTooManyRequests:
+		stats.RateLimited++
+		stats.LastRateLimited = now
+		delay := result.RetryDelay
+		if delay <= 0 {
+			delay = p.rateLimitPark
+		}
+		p.park(stats, now.Add(delay))
+	case result.StatusCode == http.StatusUnauthorized:
+		stats.Unauthorized++
+		p.park(stats, now.Add(p.unauthorizedPark))
+	default:
+		stats.Errors++
+	}
+}
+
+// Invalidate implements CredentialInvalidator, parking a rejected key so
+// the retry after a 401 uses another.
+func (p *KeyPool) Invalidate(value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if i, ok := p.index[value]; ok {
+		p.park(&p.keys[i].stats, time.Now().Add(p.unauthorizedPark))
+	}
+}
+
+// Stats returns a snapshot of every key's statistics, in configuration order.
+func (p *KeyPool) Stats() []KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]KeyStats, len(p.keys))
+	for i, k := range p.keys {
+		stats[i] = k.stats
+		if k.stats.RateLimit != nil {
+			info := *k.stats.RateLimit
+			stats[i].RateLimit = &info
+		}
+	}
+	return stats
+}
+
+// park extends a key's parking to until.
+func (p *KeyPool) park(stats *KeyStats, until time.Time) {
+	if until.After(stats.ParkedUntil) {
+		stats.ParkedUntil = until
+	}
+}
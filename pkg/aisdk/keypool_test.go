@@ -0,0 +1,186 @@
+package aisdk
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeyPoolStrategies(t *testing.T) {
+	keys := []PoolKey{{Key: "a"}, {Key: "b"}, {Key: "c"}}
+	rateLimited := func(value string) func(*KeyPool) {
+		return func(p *KeyPool) {
+			p.Observe(value, CredentialResult{StatusCode: http.StatusTooManyRequests, RetryDelay: time.Hour})
+		}
+	}
+
+	tests := []struct {
+		name     string
+		config   KeyPoolConfig
+		setup    []func(*KeyPool)
+		calls    int
+		wantKeys []string
+	}{
+		{
+			name:     "round robin",
+			config:   KeyPoolConfig{Keys: keys},
+			calls:    6,
+			wantKeys: []string{"a", "b", "c", "a", "b", "c"},
+		},
+		{
+			name:     "round robin skips parked keys",
+			config:   KeyPoolConfig{Keys: keys},
+			setup:    []func(*KeyPool){rateLimited("b")},
+			calls:    4,
+			wantKeys: []string{"a", "c", "a", "c"},
+		},
+		{
+			name:   "round robin skips keys rejected with 401",
+			config: KeyPoolConfig{Keys: keys},
+			setup: []func(*KeyPool){func(p *KeyPool) {
+				p.Observe("a", CredentialResult{StatusCode: http.StatusUnauthorized})
+			}},
+			calls:    3,
+			wantKeys: []string{"b", "c", "b"},
+		},
+		{
+			name:     "invalidated key is parked",
+			config:   KeyPoolConfig{Keys: keys},
+			setup:    []func(*KeyPool){func(p *KeyPool) { p.Invalidate("c") }},
+			calls:    3,
+			wantKeys: []string{"a", "b", "a"},
+		},
+		{
+			name:   "every key parked uses the first to return",
+			config: KeyPoolConfig{Keys: keys},
+			setup: []func(*KeyPool){
+				rateLimited("a"),
+				func(p *KeyPool) {
+					p.Observe("b", CredentialResult{StatusCode: http.StatusTooManyRequests, RetryDelay: time.Minute})
+				},
+				rateLimited("c"),
+			},
+			calls:    2,
+			wantKeys: []string{"b", "b"},
+		},
+		{
+			name:   "least recently rate limited prefers keys never limited",
+			config: KeyPoolConfig{Keys: keys, Strategy: LeastRecentlyRateLimited},
+			setup: []func(*KeyPool){func(p *KeyPool) {
+				p.keys[0].stats.LastRateLimited = time.Now().Add(-time.Minute)
+			}},
+			calls:    4,
+			wantKeys: []string{"b", "c", "b", "c"},
+		},
+		{
+			name:   "least recently rate limited prefers the oldest 429",
+			config: KeyPoolConfig{Keys: keys, Strategy: LeastRecentlyRateLimited},
+			setup: []func(*KeyPool){func(p *KeyPool) {
+				now := time.Now()
+				p.keys[0].stats.LastRateLimited = now.Add(-time.Minute)
+				p.keys[1].stats.LastRateLimited = now.Add(-time.Hour)
+				p.keys[2].stats.LastRateLimited = now.Add(-time.Second)
+			}},
+			calls:    2,
+			wantKeys: []string{"b", "b"},
+		},
+		{
+			name:     "weighted",
+			config:   KeyPoolConfig{Keys: []PoolKey{{Key: "a", Weight: 3}, {Key: "b", Weight: 1}}, Strategy: Weighted},
+			calls:    8,
+			wantKeys: []string{"a", "a", "b", "a", "a", "a", "b", "a"},
+		},
+		{
+			name:     "weighted skips parked keys",
+			config:   KeyPoolConfig{Keys: []PoolKey{{Key: "a", Weight: 3}, {Key: "b", Weight: 1}}, Strategy: Weighted},
+			setup:    []func(*KeyPool){rateLimited("a")},
+			calls:    2,
+			wantKeys: []string{"b", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := NewKeyPool(tt.config)
+			if err != nil {
+				t.Fatalf("NewKeyPool() error = %v", err)
+			}
+			for _, setup := range tt.setup {
+				setup(pool)
+			}
+
+			got := make([]string, tt.calls)
+			for i := range got {
+				credential, err := pool.Credential(context.Background())
+				if err != nil {
+					t.Fatalf("Credential() error = %v", err)
+				}
+				got[i] = credential.Value
+			}
+			if !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", got, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestKeyPoolObserve(t *testing.T) {
+	pool, err := NewKeyPool(KeyPoolConfig{Keys: []PoolKey{{Key: "sk-secret", Weight: 2}}})
+	if err != nil {
+		t.Fatalf("NewKeyPool() error = %v", err)
+	}
+
+	info := &RateLimitInfo{Limit: 10, Remaining: 9}
+	for _, status := range []int{200, 201, 429, 401, 500} {
+		pool.Observe("sk-secret", CredentialResult{StatusCode: status, RateLimit: info})
+	}
+	pool.Observe("unknown", CredentialResult{StatusCode: 200})
+
+	stats := pool.Stats()[0]
+	if stats.ParkedUntil.Before(time.Now().Add(9 * time.Minute)) {
+		t.Errorf("ParkedUntil = %v, want the 401 park", stats.ParkedUntil)
+	}
+	if stats.LastRateLimited.IsZero() {
+		t.Error("LastRateLimited is zero after a 429")
+	}
+	stats.ParkedUntil, stats.LastRateLimited = time.Time{}, time.Time{}
+
+	want := KeyStats{
+		Name:         "...cret",
+		Weight:       2,
+		Requests:     5,
+		Successes:    2,
+		RateLimited:  1,
+		Unauthorized: 1,
+		Errors:       1,
+		RateLimit:    info,
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("Stats() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestNewKeyPoolValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config KeyPoolConfig
+	}{
+		{"no keys", KeyPoolConfig{}},
+		{"empty key", KeyPoolConfig{Keys: []PoolKey{{Key: ""}}}},
+		{"duplicate key", KeyPoolConfig{Keys: []PoolKey{{Key: "a"}, {Key: "a"}}}},
+		{"negative weight", KeyPoolConfig{Keys: []PoolKey{{Key: "a", Weight: -1}}}},
+		{"zero weight under Weighted", KeyPoolConfig{Keys: []PoolKey{{Key: "a", Weight: 2}, {Key: "b"}}, Strategy: Weighted}},
+		{"unknown strategy", KeyPoolConfig{Keys: []PoolKey{{Key: "a"}}, Strategy: Weighted + 1}},
+		{"negative park", KeyPoolConfig{Keys: []PoolKey{{Key: "a"}}, RateLimitPark: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyPool(tt.config); err == nil {
+				t.Error("NewKeyPool() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package aisdk
+
+import (
+	"fmt"
+)
+
+// Message roles accepted in multi-turn input.
+// Reference: data-model.md Entity #2 (Message)
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleSystem    = "system"
+	RoleDeveloper = "developer"
+)
+
+// Input item types.
+const (
+	InputItemTypeMessage            = "message"
+	InputItemTypeFunctionCallOutput = "function_call_output"
+)
+
+// Input content part types.
+// Reference: docs/providers/openai.md lines 8-931 (images, files), 2100-2190 (audio)
+const (
+	ContentTypeInputText  = "input_text"
+	ContentTypeInputImage = "input_image"
+	ContentTypeInputFile  = "input_file"
+	ContentTypeInputAudio = "input_audio"
+	ContentTypeOutputText = "output_text"
+	ContentTypeRefusal    = "refusal"
+)
+
+// InputItem is a single element of a multi-turn CreateResponseRequest.Input.
+// Implemented by Message, FunctionCall and FunctionCallOutput; requests
+// accept these values only, not pointers to them.
+type InputItem interface {
+	// ItemType returns the wire type of the item ("message", "function_call_output", ...)
+	ItemType() string
+
+	// Validate checks the item for required fields and constraints
+	Validate() error
+}
+
+// Message represents a single message in multi-turn input.
+// Reference: data-model.md Entity #2 (Message)
+type Message struct {
+	// Role is the message author: "user", "assistant", "system" or "developer"
+	Role string `json:"role"`
+
+	// Content contains the message's content parts
+	Content []InputContent `json:"content"`
+}
+
+// InputContent represents a content part within an input Message.
+// Exactly the fields relevant to Type are populated.
+type InputContent struct {
+	// Type identifies the content kind ("input_text", "input_image", "input_file",
+	// "input_audio"; "output_text" or "refusal" for assistant history)
+	Type string `json:"type"`
+
+	// Text contains the text content (input_text, output_text)
+	Text string `json:"text,omitempty"`
+
+	// ImageURL is a URL or base64 data URL of the image (input_image)
+	ImageURL string `json:"image_url,omitempty"`
+
+	// Detail controls image fidelity: "low", "high" or "auto" (input_image)
+	Detail string `json:"detail,omitempty"`
+
+	// FileID references an uploaded file (input_image, input_file)
+	FileID string `json:"file_id,omitempty"`
+
+	// FileURL is a URL of the file (input_file)
+	FileURL string `json:"file_url,omitempty"`
+
+	// FileData is the base64 data URL of the file contents (input_file)
+	FileData string `json:"file_data,omitempty"`
+
+	// Filename is the name of the file sent in FileData (input_file)
+	Filename string `json:"filename,omitempty"`
+
+	// Audio contains the encoded audio clip (input_audio)
+	Audio *AudioInput `json:"input_audio,omitempty"`
+
+	// Refusal contains a refusal replayed from assistant history (refusal)
+	Refusal string `json:"refusal,omitempty"`
+}
+
+// AudioInput carries base64-encoded audio for input_audio content.
+type AudioInput struct {
+	// Data is the base64-encoded audio
+	Data string `json:"data"`
+
+	// Format is the audio encoding ("wav", "mp3")
+	Format string `json:"format"`
+}
+
+// FunctionCallOutput returns the result of a tool call back to the model.
+// Reference: docs/providers/openai.md lines 4075-4240
+type FunctionCallOutput struct {
+	// CallID matches the call_id of the function_call being answered (required)
+	CallID string `json:"call_id"`
+
+	// Output is the tool result, typically JSON or plain text
+	Output string `json:"output"`
+}
+
+// ItemType implements InputItem.
+func (m Message) ItemType() string { return InputItemTypeMessage }
+
+// ItemType implements InputItem.
+func (o FunctionCallOutput) ItemType() string { return InputItemTypeFunctionCallOutput }
+
+// NewUserMessage creates a user message with a single input_text part.
+func NewUserMessage(text string) Message {
+	return Message{Role: RoleUser, Content: []InputContent{NewTextContent(text)}}
+}
+
+// NewSystemMessage creates a system message with a single input_text part.
+func NewSystemMessage(text string) Message {
+	return Message{Role: RoleSystem, Content: []InputContent{NewTextContent(text)}}
+}
+
+// NewDeveloperMessage creates a developer message with a single input_text part.
+func NewDeveloperMessage(text string) Message {
+	return Message{Role: RoleDeveloper, Content: []InputContent{NewTextContent(text)}}
+}
+
+// NewAssistantMessage creates an assistant message replaying prior model output.
+func NewAssistantMessage(text string) Message {
+	return Message{Role: RoleAssistant, Content: []InputContent{{Type: ContentTypeOutputText, Text: text}}}
+}
+
+// NewTextContent creates an input_text content part.
+func NewTextContent(text string) InputContent {
+	return InputContent{Type: ContentTypeInputText, Text: text}
+}
+
+// NewImageURLContent creates an input_image part from a URL or base64 data URL.
+// Detail may be empty to use the model default.
+func NewImageURLContent(url, detail string) InputContent {
+	return InputContent{Type: ContentTypeInputImage, ImageURL: url, Detail: detail}
+}
+
+// NewImageFileContent creates an input_image part referencing an uploaded file.
+func NewImageFileContent(fileID, detail string) InputContent {
+	return InputContent{Type: ContentTypeInputImage, FileID: fileID, Detail: detail}
+}
+
+// NewFileIDContent creates an input_file part referencing an uploaded file.
+func NewFileIDContent(fileID string) InputContent {
+	return InputContent{Type: ContentTypeInputFile, FileID: fileID}
+}
+
+// NewFileURLContent creates an input_file part from a URL.
+func NewFileURLContent(url string) InputContent {
+	return InputContent{Type: ContentTypeInputFile, FileURL: url}
+}
+
+// NewFileDataContent creates an input_file part from inline base64 data.
+// fileData must be a data URL, e.g. "data:application/pdf;base64,...".
+func NewFileDataContent(filename, fileData string) InputContent {
+	return InputContent{Type: ContentTypeInputFile, Filename: filename, FileData: fileData}
+}
+
+// NewAudioContent creates an input_audio part from base64-encoded audio.
+func NewAudioContent(data, format string) InputContent {
+	return InputContent{Type: ContentTypeInputAudio, Audio: &AudioInput{Data: data, Format: format}}
+}
+
+// NewFunctionCallOutput creates a function_call_output item for the given call.
+func NewFunctionCallOutput(callID, output string) FunctionCallOutput {
+	return FunctionCallOutput{CallID: callID, Output: output}
+}
+
+// Validate checks the Message role and each content part.
+func (m Message) Validate() error {
+	switch m.Role {
+	case RoleUser, RoleSystem, RoleDeveloper, RoleAssistant:
+	default:
+		return fmt.Errorf("%w (got %q)", ErrInvalidMessageRole, m.Role)
+	}
+	if len(m.Content) == 0 {
+		return ErrMissingMessageContent
+	}
+	for i := range m.Content {
+		if err := m.Content[i].validate(m.Role); err != nil {
+			return fmt.Errorf("content[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the FunctionCallOutput for required fields.
+func (o FunctionCallOutput) Validate() error {
+	if o.CallID == "" {
+		return ErrMissingCallID
+	}
+	return nil
+}
+
+// validate checks that the content part is well formed and allowed for role.
+func (c *InputContent) validate(role string) error {
+	if role == RoleAssistant {
+		switch c.Type {
+		case ContentTypeOutputText:
+			return nil
+		case ContentTypeRefusal:
+			if c.Refusal == "" {
+				return fmt.Errorf("%w: refusal requires Refusal", ErrInvalidContentPart)
+			}
+			return nil
+		default:
+			return fmt.Errorf("%w: assistant messages accept output_text or refusal (got %q)", ErrInvalidContentPart, c.Type)
+		}
+	}
+
+	switch c.Type {
+	case ContentTypeInputText:
+		if c.Text == "" {
+			return fmt.Errorf("%w: input_text requires Text", ErrInvalidContentPart)
+		}
+	case ContentTypeInputImage:
+		if c.ImageURL == "" && c.FileID == "" {
+			return fmt.Errorf("%w: input_image requires ImageURL or FileID", ErrInvalidContentPart)
+		}
+		switch c.Detail {
+		case "", "low", "high", "auto":
+		default:
+			return fmt.Errorf("%w: image detail must be 'low', 'high', or 'auto' (got %q)", ErrInvalidContentPart, c.Detail)
+		}
+	case ContentTypeInputFile:
+		if c.FileID == "" && c.FileURL == "" && c.FileData == "" {
+			return fmt.Errorf("%w: input_file requires FileID, FileURL, or FileData", ErrInvalidContentPart)
+		}
+		if c.FileData != "" && c.Filename == "" {
+			return fmt.Errorf("%w: input_file with FileData requires Filename", ErrInvalidContentPart)
+		}
+	case ContentTypeInputAudio:
+		if c.Audio == nil || c.Audio.Data == "" || c.Audio.Format == "" {
+			return fmt.Errorf("%w: input_audio requires Audio.Data and Audio.Format", ErrInvalidContentPart)
+		}
+	default:
+		return fmt.Errorf("%w: unknown content type %q", ErrInvalidContentPart, c.Type)
+	}
+	return nil
+}
+
+// InputItems normalizes a request Input into a list of items.
+// Returns (nil, false) when Input is a plain string.
+func InputItems(input interface{}) ([]InputItem, bool) {
+	switch v := input.(type) {
+	case []InputItem:
+		return v, true
+	case []Message:
+		items := make([]InputItem, len(v))
+		for i := range v {
+			items[i] = v[i]
+		}
+		return items, true
+	case Message:
+		return []InputItem{v}, true
+	default:
+		return nil, false
+	}
+}
+
+// validateInput checks CreateResponseRequest.Input.
+// Accepts string, Message, []Message or []InputItem.
+func validateInput(input interface{}) error {
+	if s, ok := input.(string); ok {
+		if s == "" {
+			return ErrMissingInput
+		}
+		return nil
+	}
+
+	items, ok := InputItems(input)
+	if !ok {
+		return fmt.Errorf("%w (got %T)", ErrInvalidInputType, input)
+	}
+	if len(items) == 0 {
+		return ErrMissingInput
+	}
+	for i, item := range items {
+		// Providers convert only the value types; pointers and other
+		// implementations would be dropped from the request
+		switch item.(type) {
+		case Message, FunctionCall, FunctionCallOutput:
+		default:
+			return fmt.Errorf("input[%d]: %w (got %T)", i, ErrInvalidInputType, item)
+		}
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("input[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
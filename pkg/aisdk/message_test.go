@@ -0,0 +1,69 @@
+package aisdk
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateInput(t *testing.T) {
+	var nilMessage *Message
+
+	tests := []struct {
+		name    string
+		input   interface{}
+		wantErr error
+	}{
+		{name: "string", input: "Hello"},
+		{name: "empty string", input: "", wantErr: ErrMissingInput},
+		{name: "message", input: NewUserMessage("Hello")},
+		{name: "messages", input: []Message{NewSystemMessage("Be brief."), NewUserMessage("Hello")}},
+		{name: "no messages", input: []Message{}, wantErr: ErrMissingInput},
+		{
+			name: "items",
+			input: []InputItem{
+				NewUserMessage("Weather in Paris?"),
+				FunctionCall{CallID: "call_1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+				NewFunctionCallOutput("call_1", "18C"),
+			},
+		},
+		{
+			name: "multimodal message",
+			input: Message{Role: RoleUser, Content: []InputContent{
+				NewTextContent("What is this?"),
+				NewImageURLContent("https://example.com/cat.png", "low"),
+			}},
+		},
+		{name: "unsupported type", input: 42, wantErr: ErrInvalidInputType},
+		{name: "nil item", input: []InputItem{nil}, wantErr: ErrInvalidInputType},
+		{name: "pointer item", input: []InputItem{&Message{Role: RoleUser, Content: []InputContent{NewTextContent("Hello")}}}, wantErr: ErrInvalidInputType},
+		{name: "nil pointer item", input: []InputItem{nilMessage}, wantErr: ErrInvalidInputType},
+		{name: "unknown role", input: Message{Role: "robot", Content: []InputContent{NewTextContent("beep")}}, wantErr: ErrInvalidMessageRole},
+		{name: "no content", input: Message{Role: RoleUser}, wantErr: ErrMissingMessageContent},
+		{
+			name:    "bad image detail",
+			input:   Message{Role: RoleUser, Content: []InputContent{NewImageURLContent("https://example.com/cat.png", "huge")}},
+			wantErr: ErrInvalidContentPart,
+		},
+		{
+			name:    "input text in assistant message",
+			input:   Message{Role: RoleAssistant, Content: []InputContent{NewTextContent("Hi")}},
+			wantErr: ErrInvalidContentPart,
+		},
+		{name: "function call output without call ID", input: []InputItem{NewFunctionCallOutput("", "18C")}, wantErr: ErrMissingCallID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInput(tt.input)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("validateInput() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateInput() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// Middleware wraps a Provider to add cross-cutting behavior such as retry,
+// logging, caching, rate limiting or tracing. Because it operates on the
+// Provider interface it composes uniformly across providers and covers both
+// CreateResponse and StreamResponse.
+// Reference: architecture.md (Middleware Layering section)
+type Middleware func(next Provider) Provider
+
+// Chain wraps provider with the given middleware.
+// The first middleware is the outermost: Chain(p, a, b) calls a, then b, then p.
+func Chain(provider Provider, middlewares ...Middleware) Provider {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			provider = middlewares[i](provider)
+		}
+	}
+	return provider
+}
+
+// ProviderFuncs adapts a pair of functions to the Provider interface.
+// It is the usual return value of a Middleware; both functions must be set
+// (pass next.StreamResponse through to leave streaming unchanged).
+type ProviderFuncs struct {
+	// CreateResponseFunc implements Provider.CreateResponse
+	CreateResponseFunc func(ctx context.Context, req *CreateResponseRequest) (*Response, error)
+
+	// StreamResponseFunc implements Provider.StreamResponse
+	StreamResponseFunc func(ctx context.Context, req *CreateResponseRequest) (StreamReader, error)
+}
+
+// CreateResponse calls f.CreateResponseFunc.
+func (f ProviderFuncs) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	return f.CreateResponseFunc(ctx, req)
+}
+
+// StreamResponse calls f.StreamResponseFunc.
+func (f ProviderFuncs) StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+	return f.StreamResponseFunc(ctx, req)
+}
+
+// CorrelationIDMiddleware ensures every call carries a correlation ID,
+// generating one with middleware.GenerateCorrelationID when the context has none.
+func CorrelationIDMiddleware() Middleware {
+	withID := func(ctx context.Context) context.Context {
+		ctx, _ = middleware.EnsureCorrelationID(ctx)
+		return ctx
+	}
+
+	return func(next Provider) Provider {
+		return ProviderFuncs{
+			CreateResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+				return next.CreateResponse(withID(ctx), req)
+			},
+			StreamResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+				return next.StreamResponse(withID(ctx), req)
+			},
+		}
+	}
+}
+
+// RetryMiddleware retries calls that fail with a retryable error (see
+// IsRetryable) using config's exponential backoff, or the server-provided
+// Retry-After delay for rate limit errors; an error asking for a longer wait
+// than config.MaxDelay is returned at once. For StreamResponse only opening
+// the stream is retried; errors after the first event are returned as-is.
+// A nil config uses middleware.DefaultRetryConfig.
+func RetryMiddleware(config *middleware.RetryConfig) Middleware {
+	if config == nil {
+		config = middleware.DefaultRetryConfig()
+	}
+
+	return func(next Provider) Provider {
+		return ProviderFuncs{
+			CreateResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+				var resp *Response
+				err := retry(ctx, config, func() error {
+					var err error
+					resp, err = next.CreateResponse(ctx, req)
+					return err
+				})
+				return resp, err
+			},
+			StreamResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+				var stream StreamReader
+				err := retry(ctx, config, func() error {
+					var err error
+					stream, err = next.StreamResponse(ctx, req)
+					return err
+				})
+				return stream, err
+			},
+		}
+	}
+}
+
+// retry calls fn until it succeeds, fails permanently or retries run out.
+func retry(ctx context.Context, config *middleware.RetryConfig, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= config.MaxRetries || !IsRetryable(err) {
+			return err
+		}
+
+		backoff := config.ExponentialBackoff(attempt)
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) && rateLimitErr.RateLimitInfo != nil && rateLimitErr.RateLimitInfo.RetryAfter > 0 {
+			// Use server-provided retry-after, unless it exceeds the cap
+			if config.MaxDelay > 0 && rateLimitErr.RateLimitInfo.RetryAfter > config.MaxDelay {
+				return err
+			}
+			backoff = rateLimitErr.RateLimitInfo.RetryAfter
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// LoggingMiddleware logs each call's model, duration and outcome to logger.
+// Successful calls are logged at "debug" level and failures at "error".
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Provider) Provider {
+		if logger == nil {
+			return next
+		}
+
+		log := func(ctx context.Context, operation string, req *CreateResponseRequest, start time.Time, err error) {
+			keyvals := []interface{}{
+				"operation", operation,
+				"model", req.Model,
+				"duration", time.Since(start),
+			}
+			if id := middleware.GetCorrelationID(ctx); id != "" {
+				keyvals = append(keyvals, "correlation_id", id)
+			}
+			if err != nil {
+				logger.Log("error", operation+" failed", append(keyvals, "error", err)...)
+				return
+			}
+			logger.Log("debug", operation+" succeeded", keyvals...)
+		}
+
+		return ProviderFuncs{
+			CreateResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+				start := time.Now()
+				resp, err := next.CreateResponse(ctx, req)
+				log(ctx, "CreateResponse", req, start, err)
+				return resp, err
+			},
+			StreamResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+				start := time.Now()
+				stream, err := next.StreamResponse(ctx, req)
+				log(ctx, "StreamResponse", req, start, err)
+				return stream, err
+			},
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// scriptedProvider fails with errs in turn, then responds, counting calls.
+type scriptedProvider struct {
+	errs  []error
+	calls int
+}
+
+func (p *scriptedProvider) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return nil, p.errs[p.calls-1]
+	}
+	return &Response{Model: req.Model}, nil
+}
+
+func (p *scriptedProvider) StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+	_, err := p.CreateResponse(ctx, req)
+	return nil, err
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Provider) Provider {
+			return ProviderFuncs{
+				CreateResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+					order = append(order, name+":create")
+					return next.CreateResponse(ctx, req)
+				},
+				StreamResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+					order = append(order, name+":stream")
+					return next.StreamResponse(ctx, req)
+				},
+			}
+		}
+	}
+
+	provider := &scriptedProvider{}
+	if got := Chain(provider); got != Provider(provider) {
+		t.Errorf("Chain() without middleware = %v, want the provider", got)
+	}
+
+	chained := Chain(provider, record("a"), nil, record("b"))
+	chained.CreateResponse(context.Background(), &CreateResponseRequest{Model: "gpt-4o"})
+	chained.StreamResponse(context.Background(), &CreateResponseRequest{Model: "gpt-4o"})
+
+	want := []string{"a:create", "b:create", "a:stream", "b:stream"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("call order = %v, want %v", order, want)
+	}
+	if provider.calls != 2 {
+		t.Errorf("provider calls = %d, want 2", provider.calls)
+	}
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	unavailable := NewAPIError(503, "unavailable", "overloaded", "")
+	badRequest := NewAPIError(400, "invalid_request", "bad request", "")
+	rateLimited := func(retryAfter time.Duration) error {
+		return &RateLimitError{APIError: NewAPIError(429, "rate_limit_exceeded", "slow down", ""), RateLimitInfo: &RateLimitInfo{RetryAfter: retryAfter}}
+	}
+	longWait := rateLimited(time.Hour)
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "success", wantCalls: 1},
+		{name: "retried until success", errs: []error{unavailable, unavailable}, wantCalls: 3},
+		{name: "retries exhausted", errs: []error{unavailable, unavailable, unavailable}, wantErr: unavailable, wantCalls: 3},
+		{name: "not retryable", errs: []error{badRequest}, wantErr: badRequest, wantCalls: 1},
+		{name: "retry-after within MaxDelay", errs: []error{rateLimited(time.Millisecond)}, wantCalls: 2},
+		{name: "retry-after beyond MaxDelay", errs: []error{longWait}, wantErr: longWait, wantCalls: 1},
+	}
+
+	config := &middleware.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	for _, tt := range tests {
+		for _, stream := range []bool{false, true} {
+			name := tt.name
+			if stream {
+				name += " (stream)"
+			}
+			t.Run(name, func(t *testing.T) {
+				provider := &scriptedProvider{errs: tt.errs}
+				retrying := Chain(provider, RetryMiddleware(config))
+
+				var err error
+				if stream {
+					_, err = retrying.StreamResponse(context.Background(), &CreateResponseRequest{Model: "gpt-4o"})
+				} else {
+					_, err = retrying.CreateResponse(context.Background(), &CreateResponseRequest{Model: "gpt-4o"})
+				}
+
+				if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+				if provider.calls != tt.wantCalls {
+					t.Errorf("calls = %d, want %d", provider.calls, tt.wantCalls)
+				}
+			})
+		}
+	}
+}
+
+func TestRetryMiddlewareContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	provider := &scriptedProvider{errs: []error{NewAPIError(503, "unavailable", "overloaded", "")}}
+	retrying := Chain(provider, RetryMiddleware(&middleware.RetryConfig{MaxRetries: 1, BaseDelay: time.Hour, MaxDelay: time.Hour}))
+
+	if _, err := retrying.CreateResponse(ctx, &CreateResponseRequest{Model: "gpt-4o"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if provider.calls != 1 {
+		t.Errorf("calls = %d, want 1", provider.calls)
+	}
+}
+
+func TestCorrelationIDMiddleware(t *testing.T) {
+	var ids []string
+	capture := ProviderFuncs{
+		CreateResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+			ids = append(ids, middleware.GetCorrelationID(ctx))
+			return &Response{}, nil
+		},
+	}
+	provider := Chain(capture, CorrelationIDMiddleware())
+
+	provider.CreateResponse(context.Background(), &CreateResponseRequest{})
+	provider.CreateResponse(middleware.WithCorrelationID(context.Background(), "req-1"), &CreateResponseRequest{})
+
+	if len(ids) != 2 || ids[0] == "" || ids[1] != "req-1" {
+		t.Errorf("correlation IDs = %q, want a generated ID then req-1", ids)
+	}
+}
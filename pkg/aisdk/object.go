@@ -0,0 +1,107 @@
+package aisdk
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"github.com/amannhq/go-ai-sdk/internal/schema"
+)
+
+// schemaNameInvalidChars matches characters not allowed in TextFormat.Name.
+var schemaNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// objectSchema is the strict schema derived from a GenerateObject type and
+// its compiled validator.
+type objectSchema struct {
+	schema    map[string]interface{}
+	validator *schema.Validator
+}
+
+// objectSchemas caches an *objectSchema per reflect.Type, so each type's
+// schema is derived and compiled once. Providers only read the schema.
+var objectSchemas sync.Map
+
+// GenerateObject requests structured output matching the struct type T.
+// It derives a strict JSON Schema from T, sets req.TextFormat (keeping a
+// caller-provided Name), validates OutputText against the schema (errors
+// are ValidationErrors with JSON-pointer paths) and decodes it into a new T.
+// A model refusal is returned as *RefusalError. req is not modified.
+func GenerateObject[T any](ctx context.Context, client Provider, req *CreateResponseRequest) (*T, *Response, error) {
+	var zero T
+	objSchema, err := objectSchemaFor(&zero)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := schemaName(reflect.TypeOf(zero))
+	if req.TextFormat != nil && req.TextFormat.Name != "" {
+		name = req.TextFormat.Name
+	}
+
+	objReq := *req
+	objReq.TextFormat = &TextFormat{
+		Type:   "json_schema",
+		Name:   name,
+		Schema: objSchema.schema,
+		Strict: true,
+	}
+
+	resp, err := client.CreateResponse(ctx, &objReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if refusal := resp.Refusal(); refusal != "" {
+		return nil, resp, &RefusalError{Refusal: refusal, Response: resp}
+	}
+
+	out := new(T)
+	text := resp.OutputText()
+	if err := objSchema.validator.Validate([]byte(text)); err != nil {
+		return nil, resp, WrapError(err, "validate object")
+	}
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return nil, resp, WrapError(err, "decode object")
+	}
+
+	return out, resp, nil
+}
+
+// objectSchemaFor returns the cached objectSchema of v's type, deriving and
+// compiling it on first use. Errors are not cached.
+func objectSchemaFor(v interface{}) (*objectSchema, error) {
+	t := reflect.TypeOf(v)
+	if cached, ok := objectSchemas.Load(t); ok {
+		return cached.(*objectSchema), nil
+	}
+
+	derived, err := schema.StrictStructToJSONSchema(v)
+	if err != nil {
+		return nil, WrapError(err, "derive schema")
+	}
+	validator, err := schema.Compile(derived)
+	if err != nil {
+		return nil, WrapError(err, "compile schema")
+	}
+
+	cached, _ := objectSchemas.LoadOrStore(t, &objectSchema{schema: derived, validator: validator})
+	return cached.(*objectSchema), nil
+}
+
+// schemaName derives a TextFormat name from a Go type.
+func schemaName(t reflect.Type) string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Name() == "" {
+		return "response"
+	}
+	name := schemaNameInvalidChars.ReplaceAllString(t.Name(), "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
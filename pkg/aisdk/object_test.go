@@ -0,0 +1,152 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// weatherReport is the GenerateObject target of the tests below.
+type weatherReport struct {
+	City        string  `json:"city"`
+	Temperature float64 `json:"temperature"`
+	Conditions  string  `json:"conditions"`
+}
+
+// objectProvider responds with output, or fails with err when it is set,
+// recording the request it was sent.
+type objectProvider struct {
+	output ContentPart
+	err    error
+	sent   *CreateResponseRequest
+}
+
+func (p *objectProvider) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	p.sent = req
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &Response{Output: []OutputItem{{Type: "message", Role: "assistant", Content: []ContentPart{p.output}}}}, nil
+}
+
+func (p *objectProvider) StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestGenerateObject(t *testing.T) {
+	unavailable := NewAPIError(503, "unavailable", "overloaded", "")
+
+	tests := []struct {
+		name        string
+		output      ContentPart
+		err         error
+		textFormat  *TextFormat
+		want        *weatherReport
+		wantName    string
+		wantErr     error
+		wantInvalid string
+	}{
+		{
+			name:     "success",
+			output:   ContentPart{Type: "output_text", Text: `{"city":"Paris","temperature":18.5,"conditions":"sunny"}`},
+			want:     &weatherReport{City: "Paris", Temperature: 18.5, Conditions: "sunny"},
+			wantName: "weatherReport",
+		},
+		{
+			name:       "caller-provided name",
+			output:     ContentPart{Type: "output_text", Text: `{"city":"Oslo","temperature":-3,"conditions":"cloudy"}`},
+			textFormat: &TextFormat{Name: "forecast"},
+			want:       &weatherReport{City: "Oslo", Temperature: -3, Conditions: "cloudy"},
+			wantName:   "forecast",
+		},
+		{
+			name:     "invalid JSON",
+			output:   ContentPart{Type: "output_text", Text: `{"city":"Paris",`},
+			wantName: "weatherReport",
+			wantErr:  ValidationErrors{},
+		},
+		{
+			name:        "schema violation",
+			output:      ContentPart{Type: "output_text", Text: `{"city":"Paris","temperature":"warm","conditions":"foggy"}`},
+			wantName:    "weatherReport",
+			wantErr:     ValidationErrors{},
+			wantInvalid: "/temperature",
+		},
+		{
+			name:     "refusal",
+			output:   ContentPart{Type: "refusal", Refusal: "I can't help with that."},
+			wantName: "weatherReport",
+			wantErr:  &RefusalError{},
+		},
+		{
+			name:     "provider error",
+			err:      unavailable,
+			wantName: "weatherReport",
+			wantErr:  unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &objectProvider{output: tt.output, err: tt.err}
+			req := &CreateResponseRequest{Model: "gpt-4o", Input: "Weather in Paris?", TextFormat: tt.textFormat}
+
+			got, resp, err := GenerateObject[weatherReport](context.Background(), provider, req)
+
+			sent := provider.sent
+			if sent.TextFormat == nil || sent.TextFormat.Name != tt.wantName || !sent.TextFormat.Strict || sent.TextFormat.Schema == nil {
+				t.Errorf("TextFormat = %+v, want a strict schema named %q", sent.TextFormat, tt.wantName)
+			}
+			if req.TextFormat != tt.textFormat {
+				t.Error("GenerateObject modified req")
+			}
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("GenerateObject() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GenerateObject() = %+v, want %+v", got, tt.want)
+				}
+			case ValidationErrors:
+				if !errors.As(err, &want) {
+					t.Fatalf("GenerateObject() error = %v, want ValidationErrors", err)
+				}
+				if tt.wantInvalid != "" && !strings.Contains(err.Error(), tt.wantInvalid) {
+					t.Errorf("GenerateObject() error = %v, want a violation at %s", err, tt.wantInvalid)
+				}
+			case *RefusalError:
+				if !errors.As(err, &want) || want.Refusal != tt.output.Refusal || want.Response != resp {
+					t.Fatalf("GenerateObject() error = %v, want *RefusalError with the response", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("GenerateObject() error = %v, want %v", err, want)
+				}
+			}
+			if tt.wantErr != nil && got != nil {
+				t.Errorf("GenerateObject() = %+v with an error, want nil", got)
+			}
+		})
+	}
+}
+
+func TestGenerateObjectCachesSchema(t *testing.T) {
+	output := ContentPart{Type: "output_text", Text: `{"city":"Paris","temperature":18,"conditions":"sunny"}`}
+	first, second := &objectProvider{output: output}, &objectProvider{output: output}
+	req := &CreateResponseRequest{Model: "gpt-4o", Input: "Weather in Paris?"}
+
+	if _, _, err := GenerateObject[weatherReport](context.Background(), first, req); err != nil {
+		t.Fatalf("GenerateObject() error = %v", err)
+	}
+	if _, _, err := GenerateObject[weatherReport](context.Background(), second, req); err != nil {
+		t.Fatalf("GenerateObject() error = %v", err)
+	}
+
+	if reflect.ValueOf(first.sent.TextFormat.Schema).Pointer() != reflect.ValueOf(second.sent.TextFormat.Schema).Pointer() {
+		t.Error("GenerateObject derived the schema again for the same type")
+	}
+}
@@ -0,0 +1,254 @@
+package aisdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// Option configures a Client or a provider. New and every provider
+// constructor accept the same options, so each setting is made once:
+//
+//	provider, err := openai.New(nil, aisdk.WithAPIKey(key), aisdk.WithProject("proj_..."))
+//	client, err := aisdk.New(nil, provider, aisdk.WithRetry(retry), aisdk.WithLogger(logger))
+//
+// Options override the fields of the config they are applied to.
+type Option func(*Options)
+
+// Options holds the settings collected from Option values; zero fields
+// are unset. Provider constructors read it with NewOptions.
+type Options struct {
+	// APIKey is the provider API key
+	APIKey string
+
+	// Credentials supplies the API key per request
+	Credentials CredentialProvider
+
+	// BaseURL is the provider API base URL
+	BaseURL string
+
+	// Organization and Project select the OpenAI organization and project
+	Organization string
+	Project      string
+
+	// Headers are sent with every request
+	Headers http.Header
+
+	// HTTPClient sends the requests
+	HTTPClient *http.Client
+
+	// Transport configures the HTTP transport: proxy, TLS, middleware
+	Transport *TransportConfig
+
+	// Timeout is the HTTP request timeout
+	Timeout time.Duration
+
+	// Retry is the retry policy, including MaxRetries
+	Retry *middleware.RetryConfig
+
+	// Logger receives structured retry and error events
+	Logger Logger
+
+	// TelemetryHooks are called for every HTTP attempt
+	TelemetryHooks *middleware.TelemetryHooks
+
+	// RateLimiter paces every HTTP attempt
+	RateLimiter *middleware.RateLimiter
+
+	// Middleware wraps the provider (Client only)
+	Middleware []Middleware
+
+	// Model is the default model for requests that set none (Client only)
+	Model string
+}
+
+// NewOptions applies opts, in order, to empty Options.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// ClientConfig returns the settings as a ClientConfig. Provider
+// constructors apply options in this form, the one ConfigurableProvider
+// receives from New.
+func (o *Options) ClientConfig() *ClientConfig {
+	config := &ClientConfig{}
+	config.apply(o)
+	return config
+}
+
+// MergeHeaders returns a copy of headers with overrides added under their
+// canonical names, replacing headers of the same name; headers is returned
+// unchanged when overrides is empty.
+func MergeHeaders(headers, overrides http.Header) http.Header {
+	if len(overrides) == 0 {
+		return headers
+	}
+	merged := headers.Clone()
+	if merged == nil {
+		merged = make(http.Header, len(overrides))
+	}
+	for key, values := range overrides {
+		merged[http.CanonicalHeaderKey(key)] = values
+	}
+	return merged
+}
+
+// WithAPIKey sets the provider API key.
+func WithAPIKey(key string) Option {
+	return func(o *Options) { o.APIKey = key }
+}
+
+// WithCredentials sets a CredentialProvider that supplies the API key per request.
+func WithCredentials(credentials CredentialProvider) Option {
+	return func(o *Options) { o.Credentials = credentials }
+}
+
+// WithBaseURL sets the provider API base URL, e.g. a proxy or an
+// OpenAI-compatible server.
+func WithBaseURL(url string) Option {
+	return func(o *Options) { o.BaseURL = url }
+}
+
+// WithOrganization sets the OpenAI organization billed for requests
+// (OpenAI-Organization header). Other providers ignore it.
+func WithOrganization(id string) Option {
+	return func(o *Options) { o.Organization = id }
+}
+
+// WithProject sets the OpenAI project billed for requests (OpenAI-Project
+// header). Other providers ignore it.
+func WithProject(id string) Option {
+	return func(o *Options) { o.Project = id }
+}
+
+// WithHeaders adds headers sent with every request, replacing provider
+// headers of the same name. Repeated calls accumulate.
+func WithHeaders(headers http.Header) Option {
+	return func(o *Options) {
+		if o.Headers == nil {
+			o.Headers = make(http.Header, len(headers))
+		}
+		for key, values := range headers {
+			o.Headers[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+	}
+}
+
+// WithHeader adds a header sent with every request (see WithHeaders).
+func WithHeader(key, value string) Option {
+	return WithHeaders(http.Header{key: {value}})
+}
+
+// WithHTTPClient sets the http.Client that sends requests, e.g. one with an
+// instrumented Transport. Its Timeout is used when set; otherwise the
+// provider's timeout applies.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) { o.HTTPClient = client }
+}
+
+// WithTransport merges config into the transport configuration (see
+// TransportConfig.Merge).
+func WithTransport(config TransportConfig) Option {
+	return func(o *Options) { o.Transport = o.Transport.Merge(&config) }
+}
+
+// WithRoundTripper replaces the default pooled transport with rt.
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return WithTransport(TransportConfig{RoundTripper: rt})
+}
+
+// WithProxy sets the function that selects the proxy for each request,
+// e.g. http.ProxyURL(u). The default honors HTTPS_PROXY and NO_PROXY.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return WithTransport(TransportConfig{Proxy: proxy})
+}
+
+// WithProxyURL sends every request through the proxy at proxyURL.
+func WithProxyURL(proxyURL *url.URL) Option {
+	return WithProxy(http.ProxyURL(proxyURL))
+}
+
+// WithTLSConfig sets the base TLS configuration of the default transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return WithTransport(TransportConfig{TLSConfig: config})
+}
+
+// WithRootCAs verifies server certificates against pool instead of the
+// system roots.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return WithTransport(TransportConfig{RootCAs: pool})
+}
+
+// WithClientCertificates presents certificates to servers that request a
+// client certificate (mutual TLS).
+func WithClientCertificates(certificates ...tls.Certificate) Option {
+	return WithTransport(TransportConfig{Certificates: certificates})
+}
+
+// WithRoundTripperMiddleware wraps the transport, e.g. to inspect every
+// HTTP request and response (see RoundTripperMiddleware). Repeated calls
+// accumulate, the first outermost.
+func WithRoundTripperMiddleware(middlewares ...RoundTripperMiddleware) Option {
+	return WithTransport(TransportConfig{Middleware: middlewares})
+}
+
+// WithTimeout sets the HTTP request timeout (streams are bounded by their
+// context instead). A non-positive timeout keeps the provider's.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Options) { o.Timeout = timeout }
+}
+
+// WithRetry sets the retry policy: attempts and backoff.
+func WithRetry(config middleware.RetryConfig) Option {
+	return func(o *Options) { o.Retry = &config }
+}
+
+// WithMaxRetries sets the maximum retry attempts, keeping the backoff of
+// an earlier WithRetry (default backoff otherwise).
+func WithMaxRetries(maxRetries int) Option {
+	return func(o *Options) {
+		retry := middleware.DefaultRetryConfig()
+		if o.Retry != nil {
+			*retry = *o.Retry
+		}
+		retry.MaxRetries = maxRetries
+		o.Retry = retry
+	}
+}
+
+// WithLogger sets the structured logger for retry and error events.
+func WithLogger(logger Logger) Option {
+	return func(o *Options) { o.Logger = logger }
+}
+
+// WithTelemetryHooks sets the callbacks invoked for every HTTP attempt.
+func WithTelemetryHooks(hooks *middleware.TelemetryHooks) Option {
+	return func(o *Options) { o.TelemetryHooks = hooks }
+}
+
+// WithRateLimiter sets the client-side rate limiter.
+func WithRateLimiter(limiter *middleware.RateLimiter) Option {
+	return func(o *Options) { o.RateLimiter = limiter }
+}
+
+// WithMiddleware appends provider middleware (see Chain). Client only;
+// provider constructors ignore it.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *Options) { o.Middleware = append(o.Middleware, middlewares...) }
+}
+
+// WithModel sets the default model for requests that set none. Client
+// only; provider constructors ignore it.
+func WithModel(model string) Option {
+	return func(o *Options) { o.Model = model }
+}
@@ -0,0 +1,169 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+func TestNewOptions(t *testing.T) {
+	retry := middleware.RetryConfig{MaxRetries: 5, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want *Options
+	}{
+		{name: "none", want: &Options{}},
+		{name: "nil option is skipped", opts: []Option{nil, WithAPIKey("sk-1")}, want: &Options{APIKey: "sk-1"}},
+		{
+			name: "later option wins",
+			opts: []Option{WithAPIKey("sk-1"), WithTimeout(time.Second), WithAPIKey("sk-2"), WithTimeout(2 * time.Second)},
+			want: &Options{APIKey: "sk-2", Timeout: 2 * time.Second},
+		},
+		{
+			name: "max retries keeps the backoff of an earlier retry policy",
+			opts: []Option{WithRetry(retry), WithMaxRetries(1)},
+			want: &Options{Retry: &middleware.RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second}},
+		},
+		{
+			name: "max retries without a retry policy uses the default backoff",
+			opts: []Option{WithMaxRetries(0)},
+			want: &Options{Retry: &middleware.RetryConfig{MaxRetries: 0, BaseDelay: middleware.DefaultRetryConfig().BaseDelay, MaxDelay: middleware.DefaultRetryConfig().MaxDelay}},
+		},
+		{
+			name: "retry policy replaces an earlier max retries",
+			opts: []Option{WithMaxRetries(1), WithRetry(retry)},
+			want: &Options{Retry: &retry},
+		},
+		{
+			name: "headers accumulate under canonical names",
+			opts: []Option{WithHeader("x-team", "a"), WithHeaders(http.Header{"x-trace": {"1"}}), WithHeader("X-Team", "b")},
+			want: &Options{Headers: http.Header{"X-Team": {"b"}, "X-Trace": {"1"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOptions(tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOptionsOverrideConfig(t *testing.T) {
+	configRetry := &middleware.RetryConfig{MaxRetries: 2, BaseDelay: time.Second, MaxDelay: time.Minute}
+
+	tests := []struct {
+		name   string
+		config *ClientConfig
+		opts   []Option
+		check  func(t *testing.T, got *ClientConfig)
+	}{
+		{
+			name:   "unset options keep the config",
+			config: &ClientConfig{APIKey: "sk-config", Model: "gpt-4o", Timeout: time.Second, MaxRetries: 2},
+			opts:   []Option{WithTimeout(0)},
+			check: func(t *testing.T, got *ClientConfig) {
+				if got.APIKey != "sk-config" || got.Model != "gpt-4o" || got.Timeout != time.Second || got.MaxRetries != 2 {
+					t.Errorf("config = %+v, want its own values", got)
+				}
+			},
+		},
+		{
+			name:   "options override the config",
+			config: &ClientConfig{APIKey: "sk-config", Model: "gpt-4o", Timeout: time.Second},
+			opts:   []Option{WithAPIKey("sk-option"), WithModel("gpt-4o-mini"), WithTimeout(time.Minute)},
+			check: func(t *testing.T, got *ClientConfig) {
+				if got.APIKey != "sk-option" || got.Model != "gpt-4o-mini" || got.Timeout != time.Minute {
+					t.Errorf("config = %+v, want the option values", got)
+				}
+			},
+		},
+		{
+			name:   "retry option replaces the config policy and count",
+			config: &ClientConfig{MaxRetries: 4, Retry: configRetry},
+			opts:   []Option{WithMaxRetries(0)},
+			check: func(t *testing.T, got *ClientConfig) {
+				if got.MaxRetries != 0 || got.Retry == nil || got.Retry.MaxRetries != 0 {
+					t.Errorf("MaxRetries = %d, Retry = %+v, want retries disabled", got.MaxRetries, got.Retry)
+				}
+			},
+		},
+		{
+			name:   "headers are merged",
+			config: &ClientConfig{Headers: http.Header{"X-Team": {"a"}, "X-Env": {"prod"}}},
+			opts:   []Option{WithHeader("x-team", "b")},
+			check: func(t *testing.T, got *ClientConfig) {
+				want := http.Header{"X-Team": {"b"}, "X-Env": {"prod"}}
+				if !reflect.DeepEqual(got.Headers, want) {
+					t.Errorf("Headers = %v, want %v", got.Headers, want)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &configProvider{}
+			client, err := New(tt.config, provider, tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			tt.check(t, client.provider.(*configProvider).config)
+		})
+	}
+
+	if configRetry.MaxRetries != 2 {
+		t.Errorf("New modified the config's Retry: %+v", configRetry)
+	}
+}
+
+func TestNewMiddlewareOrder(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Provider) Provider {
+			return ProviderFuncs{
+				CreateResponseFunc: func(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+					order = append(order, name)
+					return next.CreateResponse(ctx, req)
+				},
+				StreamResponseFunc: next.StreamResponse,
+			}
+		}
+	}
+
+	config := &ClientConfig{Middleware: []Middleware{record("config")}}
+	client, err := New(config, &configProvider{}, WithMiddleware(record("option-1")), WithMiddleware(record("option-2")))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.CreateResponse(context.Background(), &CreateResponseRequest{Model: "gpt-4o", Input: "Hello"})
+
+	want := []string{"config", "option-1", "option-2"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("middleware order = %v, want %v", order, want)
+	}
+	if len(config.Middleware) != 1 {
+		t.Errorf("New modified config.Middleware: %d entries", len(config.Middleware))
+	}
+}
+
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+	config, err := NewConfigFromEnv()
+	if err != nil || config.APIKey != "sk-env" {
+		t.Errorf("NewConfigFromEnv() = %+v, %v, want APIKey sk-env", config, err)
+	}
+
+	t.Setenv("OPENAI_API_KEY", "")
+	if _, err := NewConfigFromEnv(); !errors.Is(err, ErrMissingAPIKey) {
+		t.Errorf("NewConfigFromEnv() without a key error = %v, want ErrMissingAPIKey", err)
+	}
+}
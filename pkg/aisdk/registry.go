@@ -0,0 +1,201 @@
+package aisdk
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ProviderFactory creates a provider from a connection. Factories apply
+// APIKey, Credentials, BaseURL and Timeout, defaulting APIKey and BaseURL to
+// the provider's environment variables and endpoint, reject Options they do
+// not know, and pass opts to the provider constructor. OpenConfig passes
+// MaxRetries as the first option.
+type ProviderFactory func(config *ConnectionConfig, opts ...Option) (Provider, error)
+
+// registry holds the providers registered with Register
+var registry = struct {
+	sync.RWMutex
+	factories map[string]ProviderFactory
+}{factories: make(map[string]ProviderFactory)}
+
+// Register makes a provider available to Open under name. Provider
+// packages register themselves from init, so importing a provider package
+// (e.g. for side effects only, with a blank import) is enough to open it.
+// Register panics if name is empty or already registered, or factory is nil.
+func Register(name string, factory ProviderFactory) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if name == "" || factory == nil {
+		panic("aisdk: Register requires a name and a factory")
+	}
+	if _, ok := registry.factories[name]; ok {
+		panic("aisdk: Register called twice for provider " + name)
+	}
+	registry.factories[name] = factory
+}
+
+// Providers returns the sorted names of the registered providers.
+func Providers() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ConnectionConfig selects a registered provider and configures it
+// together with the Client; see Open and OpenConfig.
+type ConnectionConfig struct {
+	// Provider is the registered provider name, e.g. "openai" (required)
+	Provider string
+
+	// Model is the default model for requests that set none (optional)
+	Model string
+
+	// APIKey is the provider API key (default: the provider's environment
+	// variable, e.g. OPENAI_API_KEY)
+	APIKey string
+
+	// Credentials supplies the API key per request instead of APIKey (optional)
+	Credentials CredentialProvider
+
+	// BaseURL is the provider API base URL (default: the provider's)
+	BaseURL string
+
+	// Timeout is the HTTP request timeout (default: the provider's)
+	Timeout time.Duration
+
+	// MaxRetries is the maximum retry attempts for transient failures (default: 3)
+	MaxRetries int
+
+	// Options are provider-specific settings, such as "api" for openai;
+	// see the provider packages
+	Options map[string]string
+}
+
+// DefaultConnectionConfig returns a ConnectionConfig for provider with
+// default values
+func DefaultConnectionConfig(provider string) *ConnectionConfig {
+	return &ConnectionConfig{
+		Provider:   provider,
+		MaxRetries: 3,
+		Options:    make(map[string]string),
+	}
+}
+
+// ParseConnectionString parses a connection string of the form
+//
+//	provider://[host[/path]]?model=...&timeout=30s&...
+//
+// such as "openai://?model=gpt-4o&timeout=30s". The scheme names the
+// provider; a host sets BaseURL to https://host/path. Query parameters
+// model, api_key, api_key_file (a FileCredentials path), base_url, timeout
+// (a Go duration) and max_retries set the matching fields; any others become
+// Options.
+func ParseConnectionString(connection string) (*ConnectionConfig, error) {
+	u, err := url.Parse(connection)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConnectionString, err)
+	}
+	if u.Scheme == "" || u.Opaque != "" {
+		return nil, fmt.Errorf("%w: expected provider://...", ErrInvalidConnectionString)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("%w: use the api_key parameter instead of userinfo", ErrInvalidConnectionString)
+	}
+
+	config := DefaultConnectionConfig(u.Scheme)
+	if u.Host != "" {
+		config.BaseURL = "https://" + u.Host + u.EscapedPath()
+	}
+
+	for key, values := range u.Query() {
+		value := values[len(values)-1]
+		switch key {
+		case "model":
+			config.Model = value
+		case "api_key":
+			config.APIKey = value
+		case "api_key_file":
+			config.Credentials = FileCredentials(value)
+		case "base_url":
+			config.BaseURL = value
+		case "timeout":
+			if config.Timeout, err = time.ParseDuration(value); err != nil {
+				return nil, fmt.Errorf("%w: timeout: %v", ErrInvalidConnectionString, err)
+			}
+		case "max_retries":
+			if config.MaxRetries, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("%w: max_retries: %v", ErrInvalidConnectionString, err)
+			}
+		default:
+			config.Options[key] = value
+		}
+	}
+
+	return config, nil
+}
+
+// Validate checks the ConnectionConfig for required fields and constraints.
+func (c *ConnectionConfig) Validate() error {
+	if c.Provider == "" {
+		return fmt.Errorf("%w: provider is required", ErrInvalidConnectionString)
+	}
+	if c.Timeout < 0 {
+		return ErrInvalidTimeout
+	}
+	if c.MaxRetries < 0 {
+		return ErrInvalidMaxRetries
+	}
+	return nil
+}
+
+// Open creates a Client from a connection string (see
+// ParseConnectionString) using a registered provider, e.g.
+//
+//	client, err := aisdk.Open("openai://?model=gpt-4o&timeout=30s")
+//
+// The provider package must be imported so that it registers itself. opts
+// apply to both the provider and the Client, overriding the connection string.
+func Open(connection string, opts ...Option) (*Client, error) {
+	config, err := ParseConnectionString(connection)
+	if err != nil {
+		return nil, err
+	}
+	return OpenConfig(config, opts...)
+}
+
+// OpenConfig creates a Client from config using a registered provider.
+// opts apply to both the provider and the Client, overriding config.
+func OpenConfig(config *ConnectionConfig, opts ...Option) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	registry.RLock()
+	factory, ok := registry.factories[config.Provider]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (is its package imported?)", ErrUnknownProvider, config.Provider)
+	}
+
+	providerOpts := append([]Option{WithMaxRetries(config.MaxRetries)}, opts...)
+	provider, err := factory(config, providerOpts...)
+	if err != nil {
+		return nil, WrapError(err, "open "+config.Provider)
+	}
+
+	// The factory applied the connection to the provider
+	clientConfig := DefaultConfig()
+	clientConfig.Model = config.Model
+	return New(clientConfig, provider, opts...)
+}
@@ -0,0 +1,189 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// configProvider is a ConfigurableProvider that records the ClientConfig
+// it is configured with.
+type configProvider struct {
+	config *ClientConfig
+}
+
+func (p *configProvider) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *configProvider) StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *configProvider) Configure(config *ClientConfig) Provider {
+	return &configProvider{config: config}
+}
+
+func TestParseConnectionString(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection string
+		want       *ConnectionConfig
+		wantErr    error
+	}{
+		{
+			name:       "provider only",
+			connection: "openai://",
+			want:       &ConnectionConfig{Provider: "openai", MaxRetries: 3, Options: map[string]string{}},
+		},
+		{
+			name:       "all fields and options",
+			connection: "openai://?model=gpt-4o&api_key=sk-1&timeout=30s&max_retries=5&api=chat&model=gpt-4o-mini",
+			want: &ConnectionConfig{
+				Provider:   "openai",
+				Model:      "gpt-4o-mini",
+				APIKey:     "sk-1",
+				Timeout:    30 * time.Second,
+				MaxRetries: 5,
+				Options:    map[string]string{"api": "chat"},
+			},
+		},
+		{
+			name:       "host sets the base URL",
+			connection: "azure://my-resource.openai.azure.com/openai?deployment=gpt4o",
+			want: &ConnectionConfig{
+				Provider:   "azure",
+				BaseURL:    "https://my-resource.openai.azure.com/openai",
+				MaxRetries: 3,
+				Options:    map[string]string{"deployment": "gpt4o"},
+			},
+		},
+		{
+			name:       "base_url parameter",
+			connection: "ollama://?base_url=http://localhost:11434",
+			want:       &ConnectionConfig{Provider: "ollama", BaseURL: "http://localhost:11434", MaxRetries: 3, Options: map[string]string{}},
+		},
+		{name: "malformed URL", connection: "openai://%zz", wantErr: ErrInvalidConnectionString},
+		{name: "no scheme", connection: "gpt-4o", wantErr: ErrInvalidConnectionString},
+		{name: "opaque", connection: "openai:gpt-4o", wantErr: ErrInvalidConnectionString},
+		{name: "userinfo", connection: "openai://sk-1@api.openai.com", wantErr: ErrInvalidConnectionString},
+		{name: "bad timeout", connection: "openai://?timeout=30", wantErr: ErrInvalidConnectionString},
+		{name: "bad max_retries", connection: "openai://?max_retries=many", wantErr: ErrInvalidConnectionString},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConnectionString(tt.connection)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseConnectionString() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseConnectionString() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	config, err := ParseConnectionString("openai://?api_key_file=/run/secrets/openai")
+	if err != nil {
+		t.Fatalf("ParseConnectionString() error = %v", err)
+	}
+	if file, ok := config.Credentials.(*FileCredentialProvider); !ok || file.path != "/run/secrets/openai" {
+		t.Errorf("Credentials = %#v, want FileCredentials(/run/secrets/openai)", config.Credentials)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	factory := func(config *ConnectionConfig, opts ...Option) (Provider, error) {
+		return &configProvider{}, nil
+	}
+	Register("test-register", factory)
+
+	tests := []struct {
+		name     string
+		provider string
+		factory  ProviderFactory
+	}{
+		{name: "duplicate", provider: "test-register", factory: factory},
+		{name: "empty name", factory: factory},
+		{name: "nil factory", provider: "test-register-nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Register() did not panic")
+				}
+			}()
+			Register(tt.provider, tt.factory)
+		})
+	}
+
+	found := false
+	for _, name := range Providers() {
+		found = found || name == "test-register"
+	}
+	if !found {
+		t.Errorf("Providers() = %v, want it to include test-register", Providers())
+	}
+}
+
+func TestOpenConfig(t *testing.T) {
+	factoryErr := errors.New("bad options")
+	var gotConfig *ConnectionConfig
+	var gotOptions *Options
+	Register("test-open", func(config *ConnectionConfig, opts ...Option) (Provider, error) {
+		gotConfig, gotOptions = config, NewOptions(opts...)
+		if config.Options["fail"] != "" {
+			return nil, factoryErr
+		}
+		return &configProvider{}, nil
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		if _, err := OpenConfig(DefaultConnectionConfig("test-missing")); !errors.Is(err, ErrUnknownProvider) {
+			t.Errorf("OpenConfig() error = %v, want ErrUnknownProvider", err)
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		config := DefaultConnectionConfig("test-open")
+		config.MaxRetries = -1
+		if _, err := OpenConfig(config); !errors.Is(err, ErrInvalidMaxRetries) {
+			t.Errorf("OpenConfig() error = %v, want ErrInvalidMaxRetries", err)
+		}
+	})
+
+	t.Run("factory error", func(t *testing.T) {
+		if _, err := Open("test-open://?fail=1"); !errors.Is(err, factoryErr) {
+			t.Errorf("Open() error = %v, want the factory error", err)
+		}
+	})
+
+	t.Run("options override the connection", func(t *testing.T) {
+		client, err := Open("test-open://?model=gpt-4o&max_retries=1", WithMaxRetries(4), WithModel("gpt-4o-mini"))
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if gotConfig.Model != "gpt-4o" || gotConfig.MaxRetries != 1 {
+			t.Errorf("factory config = %+v, want the parsed connection", gotConfig)
+		}
+		if gotOptions.Retry == nil || gotOptions.Retry.MaxRetries != 4 {
+			t.Errorf("factory Retry = %+v, want MaxRetries 4", gotOptions.Retry)
+		}
+		if client.config.Model != "gpt-4o-mini" {
+			t.Errorf("client Model = %q, want gpt-4o-mini", client.config.Model)
+		}
+	})
+
+	t.Run("connection max retries", func(t *testing.T) {
+		if _, err := Open("test-open://?max_retries=0"); err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if gotOptions.Retry == nil || gotOptions.Retry.MaxRetries != 0 {
+			t.Errorf("factory Retry = %+v, want MaxRetries 0", gotOptions.Retry)
+		}
+	})
+}
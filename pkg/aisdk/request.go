@@ -1,5 +1,9 @@
 package aisdk
 
+import (
+	"github.com/amannhq/go-ai-sdk/internal/schema"
+)
+
 // CreateResponseRequest represents a request to an AI provider's API.
 // Reference: docs/providers/openai.md lines 8-931, 934-1344, data-model.md Entity #2
 type CreateResponseRequest struct {
@@ -7,8 +11,9 @@ type CreateResponseRequest struct {
 	Model string `json:"model"`
 
 	// Input is the prompt text or structured message array (required)
-	// String for simple prompts; []Message for multi-turn conversations
-	Input interface{} `json:"input"` // string or []Message
+	// String for simple prompts; []Message or []InputItem for multi-turn
+	// conversations and multimodal input (see message.go)
+	Input interface{} `json:"input"` // string, Message, []Message or []InputItem
 
 	// Instructions provides high-level behavior guidance (optional)
 	Instructions string `json:"instructions,omitempty"`
@@ -30,6 +35,15 @@ type CreateResponseRequest struct {
 
 	// Reasoning controls o-series reasoning depth (optional)
 	Reasoning *ReasoningConfig `json:"reasoning,omitempty"`
+
+	// Tools declares functions the model may call (optional)
+	Tools []Tool `json:"tools,omitempty"`
+
+	// ToolChoice controls whether and which tools are called (optional, default auto)
+	ToolChoice *ToolChoice `json:"tool_choice,omitempty"`
+
+	// ParallelToolCalls allows multiple function calls in one turn (optional, default true)
+	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
 }
 
 // TextFormat defines structured output schema.
@@ -41,6 +55,12 @@ type TextFormat struct {
 	Strict bool                   `json:"strict"` // Must be true for structured outputs
 }
 
+// ValidateOutput checks a structured output document against Schema.
+// Returns ValidationErrors with JSON-pointer paths on mismatch.
+func (f *TextFormat) ValidateOutput(output string) error {
+	return schema.Validate(f.Schema, []byte(output))
+}
+
 // ReasoningConfig controls reasoning behavior for o-series models.
 type ReasoningConfig struct {
 	// Effort specifies reasoning depth: "low", "medium", or "high"
@@ -56,14 +76,22 @@ func (r *CreateResponseRequest) Validate() error {
 	if r.Input == nil {
 		return ErrMissingInput
 	}
+	if err := validateInput(r.Input); err != nil {
+		return err
+	}
 	if r.Temperature != nil && (*r.Temperature < 0.0 || *r.Temperature > 2.0) {
 		return ErrInvalidTemperature
 	}
 	if r.MaxTokens != nil && *r.MaxTokens <= 0 {
 		return ErrInvalidMaxTokens
 	}
-	if r.TextFormat != nil && r.TextFormat.Type == "json_schema" && !r.TextFormat.Strict {
-		return ErrInvalidTextFormat
+	if r.TextFormat != nil && r.TextFormat.Type == "json_schema" {
+		if !r.TextFormat.Strict {
+			return ErrInvalidTextFormat
+		}
+		if err := schema.CheckStrict(r.TextFormat.Schema); err != nil {
+			return WrapError(err, "TextFormat.Schema")
+		}
 	}
 	if r.Reasoning != nil {
 		effort := r.Reasoning.Effort
@@ -71,5 +99,8 @@ func (r *CreateResponseRequest) Validate() error {
 			return ErrInvalidReasoningEffort
 		}
 	}
+	if err := validateTools(r.Tools, r.ToolChoice); err != nil {
+		return err
+	}
 	return nil
 }
@@ -27,6 +27,13 @@ type Response struct {
 
 	// RateLimitInfo contains rate limit state (extracted from headers)
 	RateLimitInfo *RateLimitInfo `json:"-"` // Not in JSON response
+
+	// Provider is the backend that served the response when routed by a
+	// Router, as "provider/model" (empty otherwise)
+	Provider string `json:"-"`
+
+	// Fallbacks lists the backends a Router tried before Provider, in order
+	Fallbacks []FallbackAttempt `json:"-"`
 }
 
 // TokenUsage tracks token consumption for billing/monitoring.
@@ -48,8 +55,20 @@ type OutputItem struct {
 	// Role is the message role ("assistant", "tool")
 	Role string `json:"role"`
 
-	// Content contains the item's content parts
+	// Content contains the item's content parts (message items)
 	Content []ContentPart `json:"content"`
+
+	// Status is the item status ("in_progress", "completed", "incomplete")
+	Status string `json:"status,omitempty"`
+
+	// CallID identifies the call for function_call items
+	CallID string `json:"call_id,omitempty"`
+
+	// Name is the function name for function_call items
+	Name string `json:"name,omitempty"`
+
+	// Arguments is the JSON-encoded argument object for function_call items
+	Arguments string `json:"arguments,omitempty"`
 }
 
 // ContentPart represents a fragment of content within an OutputItem.
@@ -90,3 +109,17 @@ func (r *Response) OutputText() string {
 	}
 	return buf.String()
 }
+
+// Refusal aggregates all refusal content parts, or returns "" if the model
+// did not refuse.
+func (r *Response) Refusal() string {
+	var buf strings.Builder
+	for _, item := range r.Output {
+		for _, part := range item.Content {
+			if part.Type == "refusal" {
+				buf.WriteString(part.Refusal)
+			}
+		}
+	}
+	return buf.String()
+}
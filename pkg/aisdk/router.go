@@ -0,0 +1,376 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// RouterConfig configures a Router.
+type RouterConfig struct {
+	// Providers maps a name to a backend; requests address it with a
+	// "name/model" model string, e.g. "openai/gpt-4o" (at least one required)
+	Providers map[string]Provider
+
+	// Aliases maps a model alias, e.g. "fast" or "smart", to the ordered
+	// "name/model" targets that serve it; later targets are fallbacks
+	Aliases map[string][]string
+
+	// Fallbacks maps a "name/model" target to the targets tried, in order,
+	// when it fails with a retryable error or times out (optional)
+	Fallbacks map[string][]string
+
+	// Default is the provider that serves models without a known provider
+	// prefix, with the model string passed unchanged (optional)
+	Default string
+
+	// AttemptTimeout bounds each backend attempt; an attempt that exceeds it
+	// falls back to the next target. For StreamResponse it bounds opening
+	// the stream only (default: no limit beyond the caller's context)
+	AttemptTimeout time.Duration
+
+	// BackendRetries is the number of times each backend retries a failed
+	// attempt before the Router falls back, overriding the retry count of
+	// the backends' own policies and ClientConfig.MaxRetries/Retry; the
+	// backends keep their delays (default: 0, so the targets are the retry
+	// policy)
+	BackendRetries int
+
+	// Logger logs every fallback (optional; default: ClientConfig.Logger)
+	Logger Logger
+
+	// TelemetryHooks receives OnFallback for every fallback (optional;
+	// default: ClientConfig.TelemetryHooks)
+	TelemetryHooks *middleware.TelemetryHooks
+}
+
+// FallbackAttempt records a backend a Router tried before the one that
+// served the response.
+type FallbackAttempt struct {
+	// Provider is the backend, as "provider/model"
+	Provider string
+
+	// Err is the error that caused the fallback
+	Err error
+}
+
+// Router is a Provider that routes each request by its model string to one
+// of several providers, falling back along an ordered list of targets when
+// a backend fails with a retryable error (see IsRetryable) or times out.
+// Responses report the backend that served them in Response.Provider and the
+// abandoned ones in Response.Fallbacks. Fallback targets do not share
+// conversation state, so requests that set PreviousResponseID should be
+// routed to a single target.
+type Router struct {
+	providers map[string]Provider
+	aliases   map[string][]target
+	fallbacks map[string][]target
+	fallback  string
+	timeout   time.Duration
+	retries   int
+	logger    Logger
+	hooks     *middleware.TelemetryHooks
+}
+
+// target is a resolved route: a provider name and the model to request
+// from it.
+type target struct {
+	name  string
+	model string
+}
+
+// String returns the target as "provider/model".
+func (t target) String() string {
+	return t.name + "/" + t.model
+}
+
+// NewRouter creates a Router from config, checking that every alias and
+// fallback target names a configured provider.
+func NewRouter(config RouterConfig) (*Router, error) {
+	if len(config.Providers) == 0 {
+		return nil, errors.New("Router requires at least one provider")
+	}
+	for name, provider := range config.Providers {
+		if name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("Router provider name %q must be non-empty and contain no '/'", name)
+		}
+		if provider == nil {
+			return nil, fmt.Errorf("Router provider %q is nil", name)
+		}
+	}
+	if config.Default != "" && config.Providers[config.Default] == nil {
+		return nil, fmt.Errorf("Router Default %q is not a configured provider", config.Default)
+	}
+	if config.AttemptTimeout < 0 {
+		return nil, ErrInvalidTimeout
+	}
+	if config.BackendRetries < 0 {
+		return nil, errors.New("Router BackendRetries cannot be negative")
+	}
+
+	r := &Router{
+		aliases:   make(map[string][]target, len(config.Aliases)),
+		fallbacks: make(map[string][]target, len(config.Fallbacks)),
+		fallback:  config.Default,
+		timeout:   config.AttemptTimeout,
+		retries:   config.BackendRetries,
+		logger:    config.Logger,
+		hooks:     config.TelemetryHooks,
+	}
+	r.providers = r.configureBackends(config.Providers, ClientConfig{})
+
+	resolveAll := func(kind, key string, models []string) ([]target, error) {
+		targets := make([]target, 0, len(models))
+		for _, model := range models {
+			t, ok := r.resolve(model)
+			if !ok {
+				return nil, fmt.Errorf("Router %s %q: %w: %s", kind, key, ErrUnknownModel, model)
+			}
+			targets = append(targets, t)
+		}
+		return targets, nil
+	}
+
+	for alias, models := range config.Aliases {
+		if len(models) == 0 {
+			return nil, fmt.Errorf("Router alias %q has no targets", alias)
+		}
+		targets, err := resolveAll("alias", alias, models)
+		if err != nil {
+			return nil, err
+		}
+		r.aliases[alias] = targets
+	}
+	for model, models := range config.Fallbacks {
+		primary, ok := r.resolve(model)
+		if !ok {
+			return nil, fmt.Errorf("Router fallbacks: %w: %s", ErrUnknownModel, model)
+		}
+		targets, err := resolveAll("fallbacks of", model, models)
+		if err != nil {
+			return nil, err
+		}
+		r.fallbacks[primary.String()] = targets
+	}
+
+	return r, nil
+}
+
+// Configure implements ConfigurableProvider. The returned Router adopts
+// config's Logger and TelemetryHooks unless the RouterConfig set its own,
+// and routes to copies of the backends configured with config minus the
+// settings each backend keeps its own (APIKey, Credentials, BaseURL,
+// Organization and Project) and with retries set by BackendRetries.
+func (r *Router) Configure(config *ClientConfig) Provider {
+	configured := *r
+	if configured.logger == nil {
+		configured.logger = config.Logger
+	}
+	if configured.hooks == nil {
+		configured.hooks = config.TelemetryHooks
+	}
+
+	backend := *config
+	backend.APIKey, backend.Credentials, backend.BaseURL = "", nil, ""
+	backend.Organization, backend.Project = "", ""
+	configured.providers = r.configureBackends(r.providers, backend)
+	return &configured
+}
+
+// configureBackends returns copies of the configurable providers with
+// config applied and their retries set to BackendRetries, so a failing
+// backend falls back instead of retrying with backoff. Backends keep their
+// own backoff delays unless config sets Retry. Other providers are
+// returned as-is.
+func (r *Router) configureBackends(providers map[string]Provider, config ClientConfig) map[string]Provider {
+	switch {
+	case config.Retry != nil:
+		retry := *config.Retry
+		retry.MaxRetries = r.retries
+		config.Retry = &retry
+	case r.retries > 0:
+		config.MaxRetries = r.retries
+	default:
+		// MaxRetries cannot express zero; without retries the delays of
+		// the backends' policies no longer matter
+		config.Retry, config.MaxRetries = &middleware.RetryConfig{}, 0
+	}
+
+	configured := make(map[string]Provider, len(providers))
+	for name, provider := range providers {
+		if configurable, ok := provider.(ConfigurableProvider); ok {
+			provider = configurable.Configure(&config)
+		}
+		configured[name] = provider
+	}
+	return configured
+}
+
+// CreateResponse implements Provider, trying the request model's targets
+// in order until one succeeds or fails with an error that does not allow
+// a fallback.
+func (r *Router) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	targets, err := r.route(req.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	var fallbacks []FallbackAttempt
+	for i := 0; ; i++ {
+		t := targets[i]
+		attemptCtx, cancel := r.attemptContext(ctx)
+		resp, err := r.providers[t.name].CreateResponse(attemptCtx, t.request(req))
+		cancel()
+		if err == nil {
+			resp.Provider = t.String()
+			resp.Fallbacks = fallbacks
+			return resp, nil
+		}
+
+		if i == len(targets)-1 || !r.canFallback(ctx, err) {
+			return nil, err
+		}
+		fallbacks = append(fallbacks, FallbackAttempt{Provider: t.String(), Err: err})
+		r.fellBack(ctx, t, targets[i+1], err)
+	}
+}
+
+// StreamResponse implements Provider. Only opening the stream falls back;
+// errors after the stream is open are returned as-is. Events carrying a
+// Response report the serving backend and fallbacks as CreateResponse does.
+func (r *Router) StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+	targets, err := r.route(req.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	var fallbacks []FallbackAttempt
+	for i := 0; ; i++ {
+		t := targets[i]
+
+		// The attempt timeout must not cancel the stream once it is open,
+		// so it only runs until StreamResponse returns
+		attemptCtx, cancel := context.WithCancel(ctx)
+		var timer *time.Timer
+		if r.timeout > 0 {
+			timer = time.AfterFunc(r.timeout, cancel)
+		}
+		stream, err := r.providers[t.name].StreamResponse(attemptCtx, t.request(req))
+		if timer != nil && !timer.Stop() && ctx.Err() == nil {
+			if err == nil {
+				stream.Close()
+			}
+			err = WrapError(context.DeadlineExceeded, "open stream")
+		}
+		if err == nil {
+			return &routedStream{StreamReader: stream, cancel: cancel, provider: t.String(), fallbacks: fallbacks}, nil
+		}
+		cancel()
+
+		if i == len(targets)-1 || !r.canFallback(ctx, err) {
+			return nil, err
+		}
+		fallbacks = append(fallbacks, FallbackAttempt{Provider: t.String(), Err: err})
+		r.fellBack(ctx, t, targets[i+1], err)
+	}
+}
+
+// route returns the ordered targets for a request model: an alias's
+// targets, or the resolved model followed by its configured fallbacks.
+func (r *Router) route(model string) ([]target, error) {
+	if targets, ok := r.aliases[model]; ok {
+		return targets, nil
+	}
+
+	primary, ok := r.resolve(model)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownModel, model)
+	}
+	return append([]target{primary}, r.fallbacks[primary.String()]...), nil
+}
+
+// resolve maps a "name/model" string to its provider, or a model without a
+// known provider prefix to the Default provider.
+func (r *Router) resolve(model string) (target, bool) {
+	if name, rest, ok := strings.Cut(model, "/"); ok && rest != "" {
+		if _, ok := r.providers[name]; ok {
+			return target{name: name, model: rest}, true
+		}
+	}
+	if r.fallback != "" {
+		return target{name: r.fallback, model: model}, true
+	}
+	return target{}, false
+}
+
+// request returns a copy of req addressed to the target's model.
+func (t target) request(req *CreateResponseRequest) *CreateResponseRequest {
+	routed := *req
+	routed.Model = t.model
+	return &routed
+}
+
+// attemptContext derives the context of one CreateResponse attempt.
+func (r *Router) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.timeout > 0 {
+		return context.WithTimeout(ctx, r.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// canFallback reports whether err allows trying the next target: a
+// retryable error or a timeout, while the caller's context is still live.
+func (r *Router) canFallback(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if IsRetryable(err) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// fellBack reports a fallback from one target to the next.
+func (r *Router) fellBack(ctx context.Context, from, to target, err error) {
+	r.hooks.FallingBack(ctx, from.String(), to.String(), err)
+	if r.logger != nil {
+		keyvals := []interface{}{"from", from.String(), "to", to.String(), "error", err}
+		if id := middleware.GetCorrelationID(ctx); id != "" {
+			keyvals = append(keyvals, "correlation_id", id)
+		}
+		r.logger.Log("warn", "router falling back", keyvals...)
+	}
+}
+
+// routedStream annotates the Responses of a routed stream's events with the
+// serving backend and releases the attempt context on Close.
+type routedStream struct {
+	StreamReader
+	cancel    context.CancelFunc
+	provider  string
+	fallbacks []FallbackAttempt
+}
+
+// Next returns the next event, setting Response.Provider and
+// Response.Fallbacks on events that carry a Response.
+func (s *routedStream) Next() (*StreamEvent, error) {
+	event, err := s.StreamReader.Next()
+	if event != nil && event.Response != nil {
+		event.Response.Provider = s.provider
+		event.Response.Fallbacks = s.fallbacks
+	}
+	return event, err
+}
+
+// Close closes the underlying stream.
+func (s *routedStream) Close() error {
+	err := s.StreamReader.Close()
+	s.cancel()
+	return err
+}
@@ -0,0 +1,219 @@
+package aisdk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// routerBackend is a Provider that fails with err, or responds when err is
+// nil, recording the models it was asked for.
+type routerBackend struct {
+	err    error
+	delay  time.Duration
+	models *[]string
+	name   string
+	config *ClientConfig
+}
+
+func (b *routerBackend) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	*b.models = append(*b.models, b.name+"/"+req.Model)
+	if b.delay > 0 {
+		select {
+		case <-time.After(b.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &Response{Model: req.Model}, nil
+}
+
+func (b *routerBackend) StreamResponse(ctx context.Context, req *CreateResponseRequest) (StreamReader, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (b *routerBackend) Configure(config *ClientConfig) Provider {
+	configured := *b
+	configured.config = config
+	return &configured
+}
+
+func TestRouterFallback(t *testing.T) {
+	unavailable := NewAPIError(503, "unavailable", "overloaded", "")
+	badRequest := NewAPIError(400, "invalid_request", "bad request", "")
+
+	tests := []struct {
+		name         string
+		errs         map[string]error
+		delays       map[string]time.Duration
+		model        string
+		noDefault    bool
+		wantTried    []string
+		wantProvider string
+		wantErr      error
+	}{
+		{
+			name:         "primary succeeds",
+			model:        "smart",
+			wantTried:    []string{"a/big"},
+			wantProvider: "a/big",
+		},
+		{
+			name:         "retryable error falls back",
+			errs:         map[string]error{"a": unavailable},
+			model:        "smart",
+			wantTried:    []string{"a/big", "b/large"},
+			wantProvider: "b/large",
+		},
+		{
+			name:      "non-retryable error stops",
+			errs:      map[string]error{"a": badRequest},
+			model:     "smart",
+			wantTried: []string{"a/big"},
+			wantErr:   badRequest,
+		},
+		{
+			name:      "last target error is returned",
+			errs:      map[string]error{"a": unavailable, "b": badRequest},
+			model:     "smart",
+			wantTried: []string{"a/big", "b/large"},
+			wantErr:   badRequest,
+		},
+		{
+			name:         "attempt timeout falls back",
+			delays:       map[string]time.Duration{"a": time.Second},
+			model:        "smart",
+			wantTried:    []string{"a/big", "b/large"},
+			wantProvider: "b/large",
+		},
+		{
+			name:         "configured fallbacks of a prefixed model",
+			errs:         map[string]error{"a": unavailable},
+			model:        "a/small",
+			wantTried:    []string{"a/small", "b/medium"},
+			wantProvider: "b/medium",
+		},
+		{
+			name:         "default provider serves unprefixed models",
+			model:        "other",
+			wantTried:    []string{"b/other"},
+			wantProvider: "b/other",
+		},
+		{
+			name:      "unknown provider without default",
+			model:     "c/model",
+			noDefault: true,
+			wantErr:   ErrUnknownModel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tried []string
+			providers := make(map[string]Provider)
+			for _, name := range []string{"a", "b"} {
+				providers[name] = &routerBackend{err: tt.errs[name], delay: tt.delays[name], models: &tried, name: name}
+			}
+			config := RouterConfig{
+				Providers:      providers,
+				Aliases:        map[string][]string{"smart": {"a/big", "b/large"}},
+				Fallbacks:      map[string][]string{"a/small": {"b/medium"}},
+				Default:        "b",
+				AttemptTimeout: 50 * time.Millisecond,
+			}
+			if tt.noDefault {
+				config.Default = ""
+			}
+			router, err := NewRouter(config)
+			if err != nil {
+				t.Fatalf("NewRouter() error = %v", err)
+			}
+
+			resp, err := router.CreateResponse(context.Background(), &CreateResponseRequest{Model: tt.model})
+			if !reflect.DeepEqual(tried, tt.wantTried) {
+				t.Errorf("tried %v, want %v", tried, tt.wantTried)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateResponse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateResponse() error = %v", err)
+			}
+			if resp.Provider != tt.wantProvider {
+				t.Errorf("Provider = %q, want %q", resp.Provider, tt.wantProvider)
+			}
+			if len(resp.Fallbacks) != len(tt.wantTried)-1 {
+				t.Errorf("Fallbacks = %v, want %d", resp.Fallbacks, len(tt.wantTried)-1)
+			}
+		})
+	}
+}
+
+func TestRouterBackendRetries(t *testing.T) {
+	clientRetry := &middleware.RetryConfig{MaxRetries: 4, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name           string
+		backendRetries int
+		client         *ClientConfig
+		wantMaxRetries int
+		wantRetry      *middleware.RetryConfig
+	}{
+		{
+			name:      "standalone",
+			wantRetry: &middleware.RetryConfig{},
+		},
+		{
+			name:      "client max retries",
+			client:    &ClientConfig{MaxRetries: 5},
+			wantRetry: &middleware.RetryConfig{},
+		},
+		{
+			name:           "backend retries keep the backends' delays",
+			backendRetries: 2,
+			client:         &ClientConfig{MaxRetries: 5},
+			wantMaxRetries: 2,
+		},
+		{
+			name:           "client retry policy",
+			backendRetries: 1,
+			client:         &ClientConfig{Retry: clientRetry},
+			wantRetry:      &middleware.RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tried []string
+			router, err := NewRouter(RouterConfig{
+				Providers:      map[string]Provider{"a": &routerBackend{models: &tried, name: "a"}},
+				BackendRetries: tt.backendRetries,
+			})
+			if err != nil {
+				t.Fatalf("NewRouter() error = %v", err)
+			}
+			if tt.client != nil {
+				router = router.Configure(tt.client).(*Router)
+			}
+
+			config := router.providers["a"].(*routerBackend).config
+			if config.MaxRetries != tt.wantMaxRetries || !reflect.DeepEqual(config.Retry, tt.wantRetry) {
+				t.Errorf("backend MaxRetries = %d, Retry = %+v, want %d and %+v", config.MaxRetries, config.Retry, tt.wantMaxRetries, tt.wantRetry)
+			}
+		})
+	}
+
+	if clientRetry.MaxRetries != 4 {
+		t.Errorf("Configure modified the client's Retry: %+v", clientRetry)
+	}
+}
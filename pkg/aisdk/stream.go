@@ -6,21 +6,40 @@ type StreamEvent struct {
 	// Type identifies the event kind (20+ types per OpenAI streaming spec)
 	Type string `json:"type"`
 
+	// SequenceNumber orders events within a single stream
+	SequenceNumber int `json:"sequence_number,omitempty"`
+
 	// ResponseID links the event to its parent response
 	ResponseID string `json:"response_id,omitempty"`
 
 	// ItemID links the event to its parent output item
 	ItemID string `json:"item_id,omitempty"`
 
-	// Delta contains incremental content for text/refusal deltas
+	// OutputIndex is the index of the parent output item in Response.Output
+	OutputIndex int `json:"output_index,omitempty"`
+
+	// ContentIndex is the index of the content part within the output item
+	ContentIndex int `json:"content_index,omitempty"`
+
+	// Delta contains incremental content for text/refusal/arguments deltas
 	Delta string `json:"delta,omitempty"`
 
+	// Text contains the final value for *.done events
+	// (output_text.done, refusal.done, function_call_arguments.done)
+	Text string `json:"text,omitempty"`
+
 	// Error contains error details for error events
 	Error *StreamError `json:"error,omitempty"`
 
-	// Output contains completed output item for item_done events
+	// Output contains the output item for output_item.added/done events
 	Output *OutputItem `json:"output,omitempty"`
 
+	// Part contains the content part for content_part.added/done events
+	Part *ContentPart `json:"part,omitempty"`
+
+	// Response contains the response snapshot for response.* lifecycle events
+	Response *Response `json:"response,omitempty"`
+
 	// Usage contains token usage for response_completed events
 	Usage *TokenUsage `json:"usage,omitempty"`
 }
@@ -33,26 +52,40 @@ type StreamError struct {
 
 // Common event types (constants for type safety)
 const (
-	EventResponseCreated    = "response.created"
-	EventResponseInProgress = "response.in_progress"
-	EventResponseCompleted  = "response.completed"
-	EventResponseFailed     = "response.failed"
-	EventOutputItemAdded    = "response.output_item.added"
-	EventOutputItemDone     = "response.output_item.done"
-	EventContentPartAdded   = "response.content_part.added"
-	EventContentPartDone    = "response.content_part.done"
-	EventOutputTextDelta    = "response.output_text.delta"
-	EventOutputTextDone     = "response.output_text.done"
-	EventRefusalDelta       = "response.refusal.delta"
-	EventRefusalDone        = "response.refusal.done"
-	EventError              = "error"
+	EventResponseCreated                 = "response.created"
+	EventResponseInProgress              = "response.in_progress"
+	EventResponseCompleted               = "response.completed"
+	EventResponseFailed                  = "response.failed"
+	EventResponseIncomplete              = "response.incomplete"
+	EventOutputItemAdded                 = "response.output_item.added"
+	EventOutputItemDone                  = "response.output_item.done"
+	EventContentPartAdded                = "response.content_part.added"
+	EventContentPartDone                 = "response.content_part.done"
+	EventOutputTextDelta                 = "response.output_text.delta"
+	EventOutputTextDone                  = "response.output_text.done"
+	EventOutputTextAnnotationAdded       = "response.output_text.annotation.added"
+	EventRefusalDelta                    = "response.refusal.delta"
+	EventRefusalDone                     = "response.refusal.done"
+	EventFunctionCallArgumentsDelta      = "response.function_call_arguments.delta"
+	EventFunctionCallArgumentsDone       = "response.function_call_arguments.done"
+	EventReasoningSummaryTextDelta       = "response.reasoning_summary_text.delta"
+	EventReasoningSummaryTextDone        = "response.reasoning_summary_text.done"
+	EventFileSearchCallInProgress        = "response.file_search_call.in_progress"
+	EventFileSearchCallSearching         = "response.file_search_call.searching"
+	EventFileSearchCallCompleted         = "response.file_search_call.completed"
+	EventCodeInterpreterCallInProgress   = "response.code_interpreter_call.in_progress"
+	EventCodeInterpreterCallCodeDelta    = "response.code_interpreter_call_code.delta"
+	EventCodeInterpreterCallCodeDone     = "response.code_interpreter_call_code.done"
+	EventCodeInterpreterCallInterpreting = "response.code_interpreter_call.interpreting"
+	EventCodeInterpreterCallCompleted    = "response.code_interpreter_call.completed"
+	EventError                           = "error"
 )
 
 // StreamReader provides an interface for reading streaming events.
 // Reference: architecture.md (Streaming flow)
 type StreamReader interface {
 	// Next returns the next event from the stream.
-	// Returns io.EOF when the stream is complete.
+	// Returns io.EOF when the stream is complete (after response.completed).
 	Next() (*StreamEvent, error)
 
 	// Close terminates the stream and cleans up resources.
@@ -0,0 +1,198 @@
+package aisdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/amannhq/go-ai-sdk/internal/schema"
+)
+
+// Tool types
+const (
+	ToolTypeFunction = "function"
+)
+
+// ToolChoice modes
+const (
+	ToolChoiceAuto     = "auto"
+	ToolChoiceNone     = "none"
+	ToolChoiceRequired = "required"
+	ToolChoiceFunction = "function"
+)
+
+// Output item types produced by tool calling
+const (
+	OutputItemTypeMessage      = "message"
+	OutputItemTypeFunctionCall = "function_call"
+)
+
+// Input item type for replaying a model's function call in stateless conversations
+const (
+	InputItemTypeFunctionCall = "function_call"
+)
+
+// toolNamePattern matches function names accepted by the API.
+var toolNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// Tool declares a function the model may call.
+// Reference: docs/providers/openai.md lines 4040-5097 (function calling)
+type Tool struct {
+	// Type is the tool kind (currently only "function")
+	Type string `json:"type"`
+
+	// Name is the function name the model uses to call the tool (required)
+	Name string `json:"name"`
+
+	// Description tells the model when and how to use the tool
+	Description string `json:"description,omitempty"`
+
+	// Parameters is the JSON Schema object describing the function arguments
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Strict enforces the parameter schema exactly (structured outputs rules apply)
+	Strict bool `json:"strict"`
+}
+
+// ToolChoice controls whether and which tools the model calls.
+type ToolChoice struct {
+	// Mode is "auto", "none", "required", or "function"
+	Mode string `json:"mode"`
+
+	// Name is the function to force when Mode is "function"
+	Name string `json:"name,omitempty"`
+}
+
+// FunctionCall is a function_call item emitted by the model.
+// It also implements InputItem so calls can be replayed in stateless
+// conversations alongside their FunctionCallOutput.
+type FunctionCall struct {
+	// ID is the output item identifier
+	ID string `json:"id,omitempty"`
+
+	// CallID is used to match the FunctionCallOutput to this call
+	CallID string `json:"call_id"`
+
+	// Name is the function name
+	Name string `json:"name"`
+
+	// Arguments is the JSON-encoded argument object
+	Arguments string `json:"arguments"`
+}
+
+// NewFunctionTool creates a strict function Tool with the given parameter schema.
+// The schema must satisfy strict-mode rules; set Strict to false otherwise.
+func NewFunctionTool(name, description string, parameters map[string]interface{}) Tool {
+	return Tool{
+		Type:        ToolTypeFunction,
+		Name:        name,
+		Description: description,
+		Parameters:  parameters,
+		Strict:      true,
+	}
+}
+
+// ForceFunction returns a ToolChoice that requires the named function to be called.
+func ForceFunction(name string) *ToolChoice {
+	return &ToolChoice{Mode: ToolChoiceFunction, Name: name}
+}
+
+// ItemType implements InputItem.
+func (f FunctionCall) ItemType() string { return InputItemTypeFunctionCall }
+
+// Validate checks the FunctionCall for required fields.
+func (f FunctionCall) Validate() error {
+	if f.CallID == "" {
+		return ErrMissingCallID
+	}
+	if f.Name == "" {
+		return fmt.Errorf("%w: function_call requires Name", ErrInvalidTool)
+	}
+	return nil
+}
+
+// DecodeArguments unmarshals the JSON-encoded Arguments into v.
+func (f FunctionCall) DecodeArguments(v interface{}) error {
+	if err := json.Unmarshal([]byte(f.Arguments), v); err != nil {
+		return WrapError(err, "decode arguments for "+f.Name)
+	}
+	return nil
+}
+
+// ValidateArguments checks a call's JSON-encoded arguments against Parameters.
+// Returns ValidationErrors with JSON-pointer paths on mismatch.
+func (t *Tool) ValidateArguments(arguments string) error {
+	if t.Parameters == nil {
+		return nil
+	}
+	return schema.Validate(t.Parameters, []byte(arguments))
+}
+
+// Validate checks the Tool for required fields and constraints.
+func (t *Tool) Validate() error {
+	if t.Type != ToolTypeFunction {
+		return fmt.Errorf("%w: unsupported tool type %q", ErrInvalidTool, t.Type)
+	}
+	if !toolNamePattern.MatchString(t.Name) {
+		return fmt.Errorf("%w: name must match %s (got %q)", ErrInvalidTool, toolNamePattern, t.Name)
+	}
+	if t.Parameters != nil {
+		if typ, _ := t.Parameters["type"].(string); typ != "object" {
+			return fmt.Errorf("%w: parameters for %q must be a JSON Schema with type \"object\"", ErrInvalidTool, t.Name)
+		}
+		if t.Strict {
+			if err := schema.CheckStrict(t.Parameters); err != nil {
+				return fmt.Errorf("%w: parameters for %q: %w", ErrInvalidTool, t.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTools checks tool definitions and the tool choice against them.
+func validateTools(tools []Tool, choice *ToolChoice) error {
+	names := make(map[string]bool, len(tools))
+	for i := range tools {
+		if err := tools[i].Validate(); err != nil {
+			return fmt.Errorf("tools[%d]: %w", i, err)
+		}
+		if names[tools[i].Name] {
+			return fmt.Errorf("tools[%d]: %w: duplicate name %q", i, ErrInvalidTool, tools[i].Name)
+		}
+		names[tools[i].Name] = true
+	}
+
+	if choice == nil {
+		return nil
+	}
+	switch choice.Mode {
+	case ToolChoiceAuto, ToolChoiceNone:
+	case ToolChoiceRequired:
+		if len(tools) == 0 {
+			return fmt.Errorf("%w: %q requires at least one tool", ErrInvalidToolChoice, choice.Mode)
+		}
+	case ToolChoiceFunction:
+		if !names[choice.Name] {
+			return fmt.Errorf("%w: function %q is not declared in Tools", ErrInvalidToolChoice, choice.Name)
+		}
+	default:
+		return fmt.Errorf("%w: mode must be 'auto', 'none', 'required', or 'function' (got %q)", ErrInvalidToolChoice, choice.Mode)
+	}
+	return nil
+}
+
+// ToolCalls returns the function calls requested by the model, in output order.
+func (r *Response) ToolCalls() []FunctionCall {
+	var calls []FunctionCall
+	for _, item := range r.Output {
+		if item.Type == OutputItemTypeFunctionCall {
+			calls = append(calls, FunctionCall{
+				ID:        item.ID,
+				CallID:    item.CallID,
+				Name:      item.Name,
+				Arguments: item.Arguments,
+			})
+		}
+	}
+	return calls
+}
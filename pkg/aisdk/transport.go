@@ -0,0 +1,121 @@
+package aisdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// TransportConfig configures the HTTP transport a provider sends requests
+// through: a custom RoundTripper, or the default pooled transport with a
+// proxy and TLS settings, optionally wrapped in RoundTripperMiddleware.
+// Set it per provider (Config.Transport), per client (ClientConfig.Transport)
+// or with options such as WithProxy and WithRootCAs.
+type TransportConfig struct {
+	// RoundTripper replaces the default pooled transport (optional); Proxy,
+	// TLSConfig, RootCAs, Certificates and MaxIdleConnsPerHost then do not apply
+	RoundTripper http.RoundTripper
+
+	// Proxy selects the proxy for each request, e.g. http.ProxyURL(u)
+	// (default: http.ProxyFromEnvironment, which reads HTTPS_PROXY and NO_PROXY)
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// TLSConfig is the base TLS configuration; it is cloned, and RootCAs and
+	// Certificates are applied on top (optional)
+	TLSConfig *tls.Config
+
+	// RootCAs verifies server certificates instead of the system pool, e.g.
+	// for a TLS-intercepting corporate proxy (optional)
+	RootCAs *x509.CertPool
+
+	// Certificates are presented to servers that request a client
+	// certificate, for mutual TLS (optional)
+	Certificates []tls.Certificate
+
+	// MaxIdleConnsPerHost bounds the idle connections kept per host (default: 10)
+	MaxIdleConnsPerHost int
+
+	// Middleware wraps the transport, the first entry outermost, e.g. to
+	// inspect, sign or record requests and responses (optional). It also
+	// wraps the Transport of a ClientConfig.HTTPClient.
+	Middleware []RoundTripperMiddleware
+}
+
+// RoundTripperMiddleware wraps an http.RoundTripper. Unlike Middleware it
+// sees every HTTP attempt, retries included, with the final headers.
+type RoundTripperMiddleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface,
+// for writing RoundTripperMiddleware:
+//
+//	inspect := func(next http.RoundTripper) http.RoundTripper {
+//		return aisdk.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			resp, err := next.RoundTrip(req)
+//			// Inspect req and resp
+//			return resp, err
+//		})
+//	}
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Merge returns a copy of c with the fields override sets replacing c's;
+// override's Middleware is appended (wrapping inside c's). Either may be
+// nil; Merge returns nil when both are.
+func (c *TransportConfig) Merge(override *TransportConfig) *TransportConfig {
+	if override == nil {
+		return c
+	}
+	if c == nil {
+		merged := *override
+		merged.Middleware = slices.Clone(override.Middleware)
+		return &merged
+	}
+
+	merged := *c
+	if override.RoundTripper != nil {
+		merged.RoundTripper = override.RoundTripper
+	}
+	if override.Proxy != nil {
+		merged.Proxy = override.Proxy
+	}
+	if override.TLSConfig != nil {
+		merged.TLSConfig = override.TLSConfig
+	}
+	if override.RootCAs != nil {
+		merged.RootCAs = override.RootCAs
+	}
+	if override.Certificates != nil {
+		merged.Certificates = override.Certificates
+	}
+	if override.MaxIdleConnsPerHost > 0 {
+		merged.MaxIdleConnsPerHost = override.MaxIdleConnsPerHost
+	}
+	merged.Middleware = append(slices.Clone(c.Middleware), override.Middleware...)
+	return &merged
+}
+
+// TLS returns the TLS configuration for the default transport: a clone of
+// TLSConfig with RootCAs and Certificates applied, or nil when none is set.
+func (c *TransportConfig) TLS() *tls.Config {
+	if c.TLSConfig == nil && c.RootCAs == nil && c.Certificates == nil {
+		return nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.TLSConfig != nil {
+		config = c.TLSConfig.Clone()
+	}
+	if c.RootCAs != nil {
+		config.RootCAs = c.RootCAs
+	}
+	if c.Certificates != nil {
+		config.Certificates = c.Certificates
+	}
+	return config
+}
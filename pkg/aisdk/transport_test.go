@@ -0,0 +1,123 @@
+package aisdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestTransportConfigMerge(t *testing.T) {
+	proxy := http.ProxyURL(&url.URL{Scheme: "http", Host: "proxy:3128"})
+	pool := x509.NewCertPool()
+	rt := RoundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+	named := func(name string) RoundTripperMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Order", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		var base *TransportConfig
+		if got := base.Merge(nil); got != nil {
+			t.Errorf("nil.Merge(nil) = %+v, want nil", got)
+		}
+		base = &TransportConfig{MaxIdleConnsPerHost: 4}
+		if got := base.Merge(nil); got != base {
+			t.Errorf("Merge(nil) = %+v, want the receiver", got)
+		}
+	})
+
+	t.Run("override fields", func(t *testing.T) {
+		base := &TransportConfig{Proxy: proxy, MaxIdleConnsPerHost: 4, Middleware: []RoundTripperMiddleware{named("base")}}
+		got := base.Merge(&TransportConfig{RoundTripper: rt, RootCAs: pool, Middleware: []RoundTripperMiddleware{named("override")}})
+
+		if got.RoundTripper == nil || got.Proxy == nil || got.RootCAs != pool || got.MaxIdleConnsPerHost != 4 {
+			t.Errorf("Merge() = %+v, want the override's RoundTripper and RootCAs and the base's Proxy and MaxIdleConnsPerHost", got)
+		}
+		if len(got.Middleware) != 2 || len(base.Middleware) != 1 {
+			t.Fatalf("Merge() middleware = %d entries (base %d), want 2 (base 1)", len(got.Middleware), len(base.Middleware))
+		}
+
+		// The base middleware stays outermost
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		var order []string
+		inner := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			order = req.Header.Values("X-Order")
+			return nil, nil
+		})
+		got.Middleware[0](got.Middleware[1](inner)).RoundTrip(req)
+		if len(order) != 2 || order[0] != "base" || order[1] != "override" {
+			t.Errorf("middleware order = %v, want [base override]", order)
+		}
+	})
+
+	t.Run("nil receiver copies the override", func(t *testing.T) {
+		var base *TransportConfig
+		override := &TransportConfig{Middleware: []RoundTripperMiddleware{named("a")}}
+		got := base.Merge(override)
+		if got == override || len(got.Middleware) != 1 {
+			t.Fatalf("Merge() = %p %+v, want a copy of the override", got, got)
+		}
+		got.Middleware = append(got.Middleware, named("b"))
+		if len(override.Middleware) != 1 {
+			t.Error("appending to the merged middleware modified the override")
+		}
+	})
+}
+
+func TestTransportConfigTLS(t *testing.T) {
+	pool := x509.NewCertPool()
+	cert := tls.Certificate{Certificate: [][]byte{{1}}}
+	base := &tls.Config{MinVersion: tls.VersionTLS13, ServerName: "api.internal"}
+
+	tests := []struct {
+		name   string
+		config TransportConfig
+		check  func(t *testing.T, got *tls.Config)
+	}{
+		{
+			name:   "unset",
+			config: TransportConfig{},
+			check: func(t *testing.T, got *tls.Config) {
+				if got != nil {
+					t.Errorf("TLS() = %+v, want nil", got)
+				}
+			},
+		},
+		{
+			name:   "root CAs",
+			config: TransportConfig{RootCAs: pool},
+			check: func(t *testing.T, got *tls.Config) {
+				if got.RootCAs != pool || got.MinVersion != tls.VersionTLS12 {
+					t.Errorf("TLS() = %+v, want RootCAs and TLS 1.2", got)
+				}
+			},
+		},
+		{
+			name:   "base config with client certificates",
+			config: TransportConfig{TLSConfig: base, RootCAs: pool, Certificates: []tls.Certificate{cert}},
+			check: func(t *testing.T, got *tls.Config) {
+				if got == base || got.ServerName != "api.internal" || got.MinVersion != tls.VersionTLS13 {
+					t.Errorf("TLS() = %+v, want a clone of TLSConfig", got)
+				}
+				if got.RootCAs != pool || len(got.Certificates) != 1 {
+					t.Errorf("TLS() RootCAs, Certificates = %v, %d, want the configured ones", got.RootCAs, len(got.Certificates))
+				}
+				if base.RootCAs != nil || base.Certificates != nil {
+					t.Error("TLS() modified TLSConfig")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, tt.config.TLS())
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited indicates a request was rejected client-side because its
+// API key and model had no remaining request or token budget.
+var ErrRateLimited = errors.New("client-side rate limit exceeded")
+
+// RateLimitExceededError is returned by RateLimiter.Wait when a request is
+// rejected rather than delayed. It wraps ErrRateLimited.
+type RateLimitExceededError struct {
+	// Dimension is the exhausted budget: "requests" or "tokens"
+	Dimension string
+
+	// RetryAfter is the estimated delay until the budget allows the request
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *RateLimitExceededError) Error() string {
+	return fmt.Sprintf("%s: %s budget exhausted (retry_after=%v)", ErrRateLimited, e.Dimension, e.RetryAfter)
+}
+
+// Unwrap returns ErrRateLimited for errors.Is
+func (e *RateLimitExceededError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// RateLimiterConfig configures a RateLimiter.
+// Reference: architecture.md (Rate Limit Middleware)
+type RateLimiterConfig struct {
+	// RequestsPerWindow is the initial request budget per key (0: unlimited
+	// until learned from response headers)
+	RequestsPerWindow int
+
+	// TokensPerWindow is the initial token budget per key (0: unlimited
+	// until learned from response headers)
+	TokensPerWindow int
+
+	// Window is the period over which budgets replenish (default: 1m)
+	Window time.Duration
+
+	// Block makes Wait sleep until budget is available; otherwise Wait
+	// rejects with *RateLimitExceededError (DefaultRateLimiterConfig: true)
+	Block bool
+
+	// MaxWait caps how long Wait blocks; longer waits are rejected instead
+	// (0: no cap)
+	MaxWait time.Duration
+}
+
+// DefaultRateLimiterConfig returns a RateLimiterConfig that blocks and
+// learns all budgets from response headers.
+func DefaultRateLimiterConfig() *RateLimiterConfig {
+	return &RateLimiterConfig{
+		Window: time.Minute,
+		Block:  true,
+	}
+}
+
+// RateLimitKey identifies an independent budget. Providers enforce limits
+// per organization/API key and per model, so both are part of the key.
+type RateLimitKey struct {
+	APIKey string
+	Model  string
+}
+
+// RateLimitObservation is the rate-limit state reported by a response.
+// Zero limits mean the dimension was not reported.
+type RateLimitObservation struct {
+	// RequestLimit and RemainingRequests describe the request budget;
+	// RequestsReset is the time until it is fully replenished
+	RequestLimit      int
+	RemainingRequests int
+	RequestsReset     time.Duration
+
+	// TokenLimit and RemainingTokens describe the token budget;
+	// TokensReset is the time until it is fully replenished
+	TokenLimit      int
+	RemainingTokens int
+	TokensReset     time.Duration
+
+	// RetryAfter is the server-requested pause (429 responses)
+	RetryAfter time.Duration
+}
+
+// RateLimiter paces requests so callers stay under provider rate limits
+// instead of hitting 429s. Each RateLimitKey has a request and a token
+// budget, modeled as buckets that refill continuously over the window. The
+// budgets start from the config and are corrected by Observe using the
+// limits the provider reports in its response headers.
+// Providers call Wait before every HTTP attempt, so each retry takes budget
+// like the first attempt: the provider counts it against the same limits.
+// A RateLimiter is safe for concurrent use and is meant to be shared by all
+// clients using the same API keys.
+type RateLimiter struct {
+	config *RateLimiterConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	budgets map[RateLimitKey]*keyBudget
+}
+
+// keyBudget holds the buckets and server-requested pause for one key.
+type keyBudget struct {
+	requests    bucket
+	tokens      bucket
+	pausedUntil time.Time
+}
+
+// bucket is a continuously refilling budget. A zero capacity is unlimited.
+type bucket struct {
+	capacity float64
+	level    float64
+	rate     float64 // units per second
+	updated  time.Time
+}
+
+// NewRateLimiter creates a RateLimiter. A nil config uses DefaultRateLimiterConfig.
+func NewRateLimiter(config *RateLimiterConfig) *RateLimiter {
+	if config == nil {
+		config = DefaultRateLimiterConfig()
+	}
+	cfg := *config
+	if cfg.Window <= 0 {
+		cfg.Window = time.Minute
+	}
+	return &RateLimiter{
+		config:  &cfg,
+		now:     time.Now,
+		budgets: make(map[RateLimitKey]*keyBudget),
+	}
+}
+
+// Wait reserves one request and tokens (an estimate of the request's total
+// token usage) from key's budget. When the budget is short it blocks until
+// it refills, or returns *RateLimitExceededError if blocking is disabled or
+// the wait would exceed MaxWait. Returns ctx.Err() if ctx ends while waiting.
+func (l *RateLimiter) Wait(ctx context.Context, key RateLimitKey, tokens int) error {
+	for {
+		delay, dimension := l.reserve(key, tokens)
+		if delay == 0 {
+			return nil
+		}
+		if !l.config.Block || (l.config.MaxWait > 0 && delay > l.config.MaxWait) {
+			return &RateLimitExceededError{Dimension: dimension, RetryAfter: delay}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes budget for one request if available. Otherwise it returns
+// the delay until enough budget has refilled and the exhausted dimension.
+func (l *RateLimiter) reserve(key RateLimitKey, tokens int) (time.Duration, string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.budget(key, now)
+
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now), "requests"
+	}
+
+	b.requests.refill(now)
+	b.tokens.refill(now)
+
+	if delay := b.requests.delay(1); delay > 0 {
+		return delay, "requests"
+	}
+	if delay := b.tokens.delay(float64(tokens)); delay > 0 {
+		return delay, "tokens"
+	}
+
+	b.requests.take(1)
+	b.tokens.take(float64(tokens))
+	return 0, ""
+}
+
+// Observe updates key's budgets from the rate-limit state reported by a
+// response. Limits and replenishment rates are learned from the headers;
+// the remaining budget never rises above what the server reported, so
+// requests still in flight stay accounted for.
+func (l *RateLimiter) Observe(key RateLimitKey, obs *RateLimitObservation) {
+	if obs == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.budget(key, now)
+
+	b.requests.learn(now, obs.RequestLimit, obs.RemainingRequests, obs.RequestsReset, l.config.Window)
+	b.tokens.learn(now, obs.TokenLimit, obs.RemainingTokens, obs.TokensReset, l.config.Window)
+
+	if obs.RetryAfter > 0 {
+		if until := now.Add(obs.RetryAfter); until.After(b.pausedUntil) {
+			b.pausedUntil = until
+		}
+	}
+}
+
+// budget returns key's budget, creating it from the config if needed.
+// Callers must hold l.mu.
+func (l *RateLimiter) budget(key RateLimitKey, now time.Time) *keyBudget {
+	b, ok := l.budgets[key]
+	if !ok {
+		b = &keyBudget{
+			requests: newBucket(l.config.RequestsPerWindow, l.config.Window, now),
+			tokens:   newBucket(l.config.TokensPerWindow, l.config.Window, now),
+		}
+		l.budgets[key] = b
+	}
+	return b
+}
+
+// newBucket creates a full bucket holding limit units per window.
+func newBucket(limit int, window time.Duration, now time.Time) bucket {
+	return bucket{
+		capacity: float64(limit),
+		level:    float64(limit),
+		rate:     float64(limit) / window.Seconds(),
+		updated:  now,
+	}
+}
+
+// refill adds the budget replenished since the last update.
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.level = math.Min(b.capacity, b.level+elapsed*b.rate)
+	}
+	b.updated = now
+}
+
+// delay returns how long until n units are available (0 if they are now).
+// Requests larger than the capacity only wait for a full bucket.
+func (b *bucket) delay(n float64) time.Duration {
+	if b.capacity <= 0 {
+		return 0
+	}
+	n = math.Min(n, b.capacity)
+	if b.level >= n {
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((n - b.level) / b.rate * float64(time.Second))
+}
+
+// take removes n units; the level may go negative for oversized requests.
+func (b *bucket) take(n float64) {
+	if b.capacity > 0 {
+		b.level -= n
+	}
+}
+
+// learn applies a reported limit, remaining budget and time-to-full-reset.
+func (b *bucket) learn(now time.Time, limit, remaining int, reset, window time.Duration) {
+	if limit <= 0 {
+		return
+	}
+	b.refill(now)
+
+	if b.capacity <= 0 {
+		// First limit learned for a previously unlimited bucket
+		b.level = float64(remaining)
+	}
+	b.capacity = float64(limit)
+	b.rate = float64(limit) / window.Seconds()
+	if reset > 0 && remaining < limit {
+		// The server reports how long the missing budget takes to refill
+		b.rate = float64(limit-remaining) / reset.Seconds()
+	}
+	b.level = math.Min(b.level, float64(remaining))
+}
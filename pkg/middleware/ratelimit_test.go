@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBudgets(t *testing.T) {
+	keyA := RateLimitKey{APIKey: "sk-a", Model: "gpt-4o"}
+	keyB := RateLimitKey{APIKey: "sk-b", Model: "gpt-4o"}
+	keyMini := RateLimitKey{APIKey: "sk-a", Model: "gpt-4o-mini"}
+
+	// step reserves tokens from key, or advances the clock or reports
+	// observe when those are set
+	type step struct {
+		advance time.Duration
+		observe *RateLimitObservation
+		key     RateLimitKey
+		tokens  int
+
+		wantDelay     time.Duration
+		wantDimension string
+	}
+
+	tests := []struct {
+		name   string
+		config RateLimiterConfig
+		steps  []step
+	}{
+		{
+			name:   "unlimited until learned",
+			config: RateLimiterConfig{Window: time.Minute},
+			steps:  []step{{key: keyA, tokens: 1e6}, {key: keyA, tokens: 1e6}},
+		},
+		{
+			name:   "request bucket refills over the window",
+			config: RateLimiterConfig{RequestsPerWindow: 2, Window: time.Minute},
+			steps: []step{
+				{key: keyA},
+				{key: keyA},
+				{key: keyA, wantDelay: 30 * time.Second, wantDimension: "requests"},
+				{advance: 20 * time.Second},
+				{key: keyA, wantDelay: 10 * time.Second, wantDimension: "requests"},
+				{advance: 10 * time.Second},
+				{key: keyA},
+			},
+		},
+		{
+			name:   "token bucket",
+			config: RateLimiterConfig{TokensPerWindow: 1000, Window: time.Minute},
+			steps: []step{
+				{key: keyA, tokens: 600},
+				{key: keyA, tokens: 600, wantDelay: 12 * time.Second, wantDimension: "tokens"},
+				{advance: 12 * time.Second},
+				{key: keyA, tokens: 600},
+			},
+		},
+		{
+			name:   "oversized request waits for a full bucket only",
+			config: RateLimiterConfig{TokensPerWindow: 100, Window: time.Minute},
+			steps: []step{
+				{key: keyA, tokens: 500},
+				{key: keyA, tokens: 10, wantDelay: 246 * time.Second, wantDimension: "tokens"},
+			},
+		},
+		{
+			name:   "keys and models are isolated",
+			config: RateLimiterConfig{RequestsPerWindow: 1, Window: time.Minute},
+			steps: []step{
+				{key: keyA},
+				{key: keyA, wantDelay: time.Minute, wantDimension: "requests"},
+				{key: keyB},
+				{key: keyMini},
+			},
+		},
+		{
+			name:   "budget learned from headers",
+			config: RateLimiterConfig{Window: time.Minute},
+			steps: []step{
+				{key: keyA},
+				{key: keyA, observe: &RateLimitObservation{RequestLimit: 60, RemainingRequests: 0, RequestsReset: 2 * time.Second}},
+				{key: keyA, wantDelay: time.Second / 30, wantDimension: "requests"},
+				{key: keyB},
+			},
+		},
+		{
+			name:   "reported budget lowers the configured one",
+			config: RateLimiterConfig{TokensPerWindow: 10000, Window: time.Minute},
+			steps: []step{
+				{key: keyA, observe: &RateLimitObservation{TokenLimit: 6000, RemainingTokens: 100, TokensReset: 59 * time.Second}},
+				{key: keyA, tokens: 200, wantDelay: time.Second, wantDimension: "tokens"},
+			},
+		},
+		{
+			name:   "retry-after pauses the key",
+			config: RateLimiterConfig{Window: time.Minute},
+			steps: []step{
+				{key: keyA, observe: &RateLimitObservation{RetryAfter: 5 * time.Second}},
+				{key: keyA, wantDelay: 5 * time.Second, wantDimension: "requests"},
+				{advance: 5 * time.Second},
+				{key: keyA},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+			l := NewRateLimiter(&tt.config)
+			l.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				switch {
+				case s.advance > 0:
+					now = now.Add(s.advance)
+				case s.observe != nil:
+					l.Observe(s.key, s.observe)
+				default:
+					delay, dimension := l.reserve(s.key, s.tokens)
+					if diff := delay - s.wantDelay; diff < -time.Millisecond || diff > time.Millisecond || dimension != s.wantDimension {
+						t.Errorf("step %d: reserve() = %v, %q, want %v, %q", i, delay, dimension, s.wantDelay, s.wantDimension)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	key := RateLimitKey{APIKey: "sk-a", Model: "gpt-4o"}
+
+	t.Run("blocks until refilled", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimiterConfig{RequestsPerWindow: 1, Window: 20 * time.Millisecond, Block: true})
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			if err := l.Wait(context.Background(), key, 0); err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+			t.Errorf("second Wait() returned after %v, want about 20ms", elapsed)
+		}
+	})
+
+	t.Run("rejects when not blocking", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimiterConfig{RequestsPerWindow: 1, Window: time.Minute})
+		l.Wait(context.Background(), key, 0)
+
+		err := l.Wait(context.Background(), key, 0)
+		var exceeded *RateLimitExceededError
+		if !errors.As(err, &exceeded) || !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("Wait() error = %v, want *RateLimitExceededError", err)
+		}
+		if exceeded.Dimension != "requests" || exceeded.RetryAfter <= 0 {
+			t.Errorf("Dimension, RetryAfter = %q, %v, want requests and a positive delay", exceeded.Dimension, exceeded.RetryAfter)
+		}
+	})
+
+	t.Run("rejects waits beyond MaxWait", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimiterConfig{RequestsPerWindow: 1, Window: time.Minute, Block: true, MaxWait: time.Second})
+		l.Wait(context.Background(), key, 0)
+
+		if err := l.Wait(context.Background(), key, 0); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("Wait() error = %v, want ErrRateLimited", err)
+		}
+	})
+
+	t.Run("context ends while blocked", func(t *testing.T) {
+		l := NewRateLimiter(&RateLimiterConfig{RequestsPerWindow: 1, Window: time.Hour, Block: true})
+		l.Wait(context.Background(), key, 0)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := l.Wait(ctx, key, 0); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Wait() error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
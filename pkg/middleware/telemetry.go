@@ -10,17 +10,59 @@ import (
 // All hooks are optional and will be called if non-nil.
 // Reference: architecture.md (Middleware Layering section)
 type TelemetryHooks struct {
-	// OnRequestStart is called before sending a request
+	// OnRequestStart is called before sending each request attempt
 	OnRequestStart func(ctx context.Context, method, url string)
 
 	// OnRetry is called when a request is retried
 	OnRetry func(ctx context.Context, attempt int, err error)
 
-	// OnResponse is called after receiving a successful response
+	// OnResponse is called after receiving each HTTP response (any status);
+	// duration is the attempt's round-trip time in seconds
 	OnResponse func(ctx context.Context, statusCode int, duration float64)
 
 	// OnError is called when a request fails after all retries
 	OnError func(ctx context.Context, err error)
+
+	// OnFallback is called when aisdk.Router gives up on a backend after err
+	// and tries the next; from and to are "provider/model"
+	OnFallback func(ctx context.Context, from, to string, err error)
+}
+
+// RequestStarted calls OnRequestStart if set. Safe on a nil receiver.
+func (h *TelemetryHooks) RequestStarted(ctx context.Context, method, url string) {
+	if h != nil && h.OnRequestStart != nil {
+		h.OnRequestStart(ctx, method, url)
+	}
+}
+
+// Retrying calls OnRetry if set. attempt is the number of the upcoming
+// attempt (1 for the first retry). Safe on a nil receiver.
+func (h *TelemetryHooks) Retrying(ctx context.Context, attempt int, err error) {
+	if h != nil && h.OnRetry != nil {
+		h.OnRetry(ctx, attempt, err)
+	}
+}
+
+// ResponseReceived calls OnResponse if set, passing duration in seconds.
+// Safe on a nil receiver.
+func (h *TelemetryHooks) ResponseReceived(ctx context.Context, statusCode int, duration time.Duration) {
+	if h != nil && h.OnResponse != nil {
+		h.OnResponse(ctx, statusCode, duration.Seconds())
+	}
+}
+
+// Failed calls OnError if set. Safe on a nil receiver.
+func (h *TelemetryHooks) Failed(ctx context.Context, err error) {
+	if h != nil && h.OnError != nil {
+		h.OnError(ctx, err)
+	}
+}
+
+// FallingBack calls OnFallback if set. Safe on a nil receiver.
+func (h *TelemetryHooks) FallingBack(ctx context.Context, from, to string, err error) {
+	if h != nil && h.OnFallback != nil {
+		h.OnFallback(ctx, from, to, err)
+	}
 }
 
 // correlationIDKey is the context key for correlation IDs
@@ -39,6 +81,16 @@ func GetCorrelationID(ctx context.Context) string {
 	return ""
 }
 
+// EnsureCorrelationID returns ctx and its correlation ID, first attaching a
+// newly generated one if ctx has none.
+func EnsureCorrelationID(ctx context.Context) (context.Context, string) {
+	if id := GetCorrelationID(ctx); id != "" {
+		return ctx, id
+	}
+	id := GenerateCorrelationID()
+	return WithCorrelationID(ctx, id), id
+}
+
 // GenerateCorrelationID generates a simple correlation ID
 // In production, consider using UUID v4
 func GenerateCorrelationID() string {
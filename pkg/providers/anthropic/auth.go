@@ -0,0 +1,12 @@
+package anthropic
+
+import (
+	"net/http"
+)
+
+// addAuthHeaders adds Anthropic authentication and versioning headers to the request.
+// Reference: FR-004 (authentication)
+func addAuthHeaders(req *http.Request, apiKey, version string) {
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", version)
+}
@@ -0,0 +1,211 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/amannhq/go-ai-sdk/internal/history"
+	internalhttp "github.com/amannhq/go-ai-sdk/internal/http"
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// Client implements the Provider interface for the Anthropic Messages API.
+// Reference: architecture.md (Provider Interface Pattern)
+type Client struct {
+	config    *Config
+	transport *transport.Transport
+	history   *history.History[message]
+}
+
+// New creates a new Anthropic client with the given configuration.
+// Options are applied to a copy of config (DefaultConfig when nil).
+func New(config *Config, opts ...aisdk.Option) (*Client, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	configured := *config
+	config = &configured
+	overrides := aisdk.NewOptions(opts...).ClientConfig()
+	config.override(overrides)
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := overrides.Validate(); err != nil {
+		return nil, err
+	}
+	settings := config.settings()
+	settings.Apply(overrides)
+
+	return &Client{
+		config:    config,
+		transport: transport.New("anthropic", settings, mapAnthropicError),
+		history:   history.New[message](config.HistorySize),
+	}, nil
+}
+
+// NewFromEnv creates a new Anthropic client loading configuration from environment.
+func NewFromEnv(opts ...aisdk.Option) (*Client, error) {
+	config, err := NewConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return New(config, opts...)
+}
+
+// Configure implements aisdk.ConfigurableProvider. The copy shares the
+// conversation history of c.
+func (c *Client) Configure(config *aisdk.ClientConfig) aisdk.Provider {
+	configured := *c.config
+	configured.override(config)
+	return &Client{
+		config:    &configured,
+		transport: c.transport.Configure(config),
+		history:   c.history,
+	}
+}
+
+// CreateResponse implements Provider.CreateResponse for Anthropic.
+// POSTs to /messages and converts the reply to an aisdk.Response.
+func (c *Client) CreateResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (*aisdk.Response, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, aisdk.WrapError(err, "anthropic.CreateResponse")
+	}
+
+	// Convert to Anthropic format
+	mReq, err := c.toRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "anthropic.CreateResponse")
+	}
+	mReq.Stream = false
+
+	// Every attempt is traced under the same correlation ID
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	httpResp, err := c.send(ctx, req, mReq, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	// Parse response
+	var mResp messagesResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&mResp); err != nil {
+		return nil, c.transport.Fail(ctx, http.MethodPost, httpResp.Request.URL.String(), aisdk.WrapError(err, "decode response"))
+	}
+
+	structuredTool := structuredToolName(req)
+	c.remember(mReq, &mResp, structuredTool)
+
+	// Convert to SDK format
+	resp := toAISDKResponse(&mResp, structuredTool)
+
+	// Attach rate limit info
+	resp.RateLimitInfo = transport.RateLimitInfo(internalhttp.ExtractRateLimitHeaders(httpResp.Header))
+
+	return resp, nil
+}
+
+// StreamResponse implements Provider.StreamResponse for Anthropic.
+// POSTs to /messages with stream: true and returns a StreamReader translating
+// the Messages API events into response.* events. The caller must Close the
+// reader to release the connection.
+func (c *Client) StreamResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (aisdk.StreamReader, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, aisdk.WrapError(err, "anthropic.StreamResponse")
+	}
+
+	// Convert to Anthropic format with streaming enabled
+	mReq, err := c.toRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "anthropic.StreamResponse")
+	}
+	mReq.Stream = true
+
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	// Open the stream (retrying connection setup); the body stays open
+	// until the reader is closed
+	httpResp, err := c.send(ctx, req, mReq, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	structuredTool := structuredToolName(req)
+	return newStreamReader(ctx, httpResp.Body, structuredTool, func(mResp *messagesResponse) {
+		c.remember(mReq, mResp, structuredTool)
+	}), nil
+}
+
+// toRequest converts req, replaying the conversation named by PreviousResponseID.
+func (c *Client) toRequest(req *aisdk.CreateResponseRequest) (*messagesRequest, error) {
+	opts := requestOptions{defaultMaxTokens: c.config.DefaultMaxTokens}
+	if req.PreviousResponseID != "" {
+		prior, ok := c.history.Get(req.PreviousResponseID)
+		if !ok {
+			return nil, ErrUnknownPreviousResponse
+		}
+		opts.history = prior
+	}
+	return toAnthropicRequest(req, opts)
+}
+
+// remember records the conversation so later requests can chain on it.
+// The structured-output tool call is stored as the text it stands for,
+// since a tool_use turn must otherwise be answered with a tool_result.
+func (c *Client) remember(mReq *messagesRequest, mResp *messagesResponse, structuredTool string) {
+	reply := make([]contentBlock, 0, len(mResp.Content))
+	for _, block := range mResp.Content {
+		if block.Type == blockToolUse && block.Name == structuredTool {
+			block = contentBlock{Type: blockText, Text: string(block.Input)}
+		}
+		reply = append(reply, block)
+	}
+
+	conversation := append([]message{}, mReq.Messages...)
+	if len(reply) > 0 {
+		conversation = append(conversation, message{Role: aisdk.RoleAssistant, Content: reply})
+	}
+	c.history.Put(mResp.ID, conversation)
+}
+
+// send POSTs mReq to /messages through the shared transport.
+// Streaming requests are sent without the overall client timeout.
+func (c *Client) send(ctx context.Context, req *aisdk.CreateResponseRequest, mReq *messagesRequest, correlationID string) (*http.Response, error) {
+	// Marshal request
+	body, err := json.Marshal(mReq)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "marshal request")
+	}
+
+	// Create HTTP request
+	url := c.config.BaseURL + "/messages"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, aisdk.WrapError(err, "create http request")
+	}
+
+	// Add headers
+	addAuthHeaders(httpReq, c.config.APIKey, c.config.Version)
+	httpReq.Header.Set("Content-Type", "application/json")
+	if mReq.Stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+
+	return c.transport.Send(ctx, &transport.Request{
+		HTTP:          httpReq,
+		Stream:        mReq.Stream,
+		CorrelationID: correlationID,
+		APIKey:        c.config.APIKey,
+		Authorize: func(req *http.Request, apiKey string) {
+			addAuthHeaders(req, apiKey, c.config.Version)
+		},
+		Model:  req.Model,
+		Tokens: transport.EstimateTokens(req, body),
+	})
+}
@@ -0,0 +1,155 @@
+package anthropic
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// ErrMissingAPIKey indicates that no Anthropic API key was provided
+var ErrMissingAPIKey = errors.New("API key required; set ANTHROPIC_API_KEY environment variable or provide via Config.APIKey")
+
+// Config holds the configuration for the Anthropic provider.
+// Reference: data-model.md Entity #1 (ClientConfig)
+type Config struct {
+	// APIKey is the Anthropic API key (required unless Credentials is set)
+	APIKey string
+
+	// Credentials supplies the API key per request instead of APIKey, for
+	// rotated keys and short-lived tokens (optional)
+	Credentials aisdk.CredentialProvider
+
+	// BaseURL is the Anthropic API base URL (default: https://api.anthropic.com/v1)
+	BaseURL string
+
+	// Version is sent as the anthropic-version header (default: 2023-06-01)
+	Version string
+
+	// Timeout is the HTTP request timeout (default: 60s)
+	Timeout time.Duration
+
+	// MaxRetries is the maximum retry attempts for transient failures
+	// (default: 3; ignored when Retry is set)
+	MaxRetries int
+
+	// DefaultMaxTokens is sent as max_tokens when the request sets no
+	// MaxTokens, since the Messages API requires it (default: 4096)
+	DefaultMaxTokens int
+
+	// HistorySize is the number of conversations remembered for
+	// PreviousResponseID chaining; the Messages API is stateless, so the
+	// client replays prior turns itself (default: 1000, 0 disables chaining)
+	HistorySize int
+
+	// Logger receives structured retry and error events (optional)
+	Logger aisdk.Logger
+
+	// TelemetryHooks are called for every HTTP attempt (optional)
+	TelemetryHooks *middleware.TelemetryHooks
+
+	// RateLimiter paces every HTTP attempt per API key and model (optional)
+	RateLimiter *middleware.RateLimiter
+
+	// Retry sets the retry policy, including its MaxRetries (optional,
+	// default: middleware.DefaultRetryConfig)
+	Retry *middleware.RetryConfig
+
+	// HTTPClient replaces the default pooled http.Client (optional);
+	// Timeout applies when the client sets none
+	HTTPClient *http.Client
+
+	// Transport configures the HTTP transport: a custom RoundTripper, proxy,
+	// TLS (custom CA pool, client certificates) and RoundTripper middleware
+	// (optional)
+	Transport *aisdk.TransportConfig
+
+	// Headers are sent with every request, replacing headers of the same
+	// name set by the provider (optional)
+	Headers http.Header
+}
+
+// DefaultConfig returns a Config with default values
+func DefaultConfig() *Config {
+	return &Config{
+		BaseURL:          "https://api.anthropic.com/v1",
+		Version:          "2023-06-01",
+		Timeout:          60 * time.Second,
+		MaxRetries:       3,
+		DefaultMaxTokens: 4096,
+		HistorySize:      1000,
+	}
+}
+
+// Validate checks the Config for required fields and constraints.
+// Returns descriptive error per SC-006 (actionable error messages).
+func (c *Config) Validate() error {
+	if c.APIKey == "" && c.Credentials == nil {
+		return ErrMissingAPIKey
+	}
+
+	if c.BaseURL == "" {
+		return errors.New("BaseURL cannot be empty")
+	}
+
+	// Validate BaseURL is a valid URL
+	if _, err := url.Parse(c.BaseURL); err != nil {
+		return errors.New("BaseURL must be a valid URL")
+	}
+
+	if c.Version == "" {
+		return errors.New("Version cannot be empty (e.g., '2023-06-01')")
+	}
+
+	if c.Timeout <= 0 {
+		return errors.New("Timeout must be positive duration")
+	}
+
+	if c.MaxRetries < 0 {
+		return errors.New("MaxRetries cannot be negative")
+	}
+
+	if c.DefaultMaxTokens <= 0 {
+		return errors.New("DefaultMaxTokens must be positive")
+	}
+
+	if c.HistorySize < 0 {
+		return errors.New("HistorySize cannot be negative")
+	}
+
+	return nil
+}
+
+// override sets the provider fields config sets; Settings.Apply sets the
+// shared ones.
+func (c *Config) override(config *aisdk.ClientConfig) {
+	if config.APIKey != "" {
+		c.APIKey = config.APIKey
+	}
+	if config.Credentials != nil {
+		c.Credentials = config.Credentials
+	}
+	if config.BaseURL != "" {
+		c.BaseURL = config.BaseURL
+	}
+}
+
+// settings returns the transport settings of the Config.
+func (c *Config) settings() transport.Settings {
+	return transport.Settings{
+		Timeout:        c.Timeout,
+		MaxRetries:     c.MaxRetries,
+		Retry:          c.Retry,
+		HTTPClient:     c.HTTPClient,
+		Transport:      c.Transport,
+		Headers:        c.Headers,
+		Logger:         c.Logger,
+		TelemetryHooks: c.TelemetryHooks,
+		RateLimiter:    c.RateLimiter,
+		Credentials:    c.Credentials,
+	}
+}
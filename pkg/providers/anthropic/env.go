@@ -0,0 +1,19 @@
+package anthropic
+
+import (
+	"os"
+)
+
+// NewConfigFromEnv creates a Config loading the API key from environment.
+// Reads ANTHROPIC_API_KEY environment variable.
+// Reference: FR-013 (environment-based configuration)
+func NewConfigFromEnv() (*Config, error) {
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	config := DefaultConfig()
+	config.APIKey = apiKey
+	return config, nil
+}
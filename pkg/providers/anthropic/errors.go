@@ -0,0 +1,47 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+)
+
+var (
+	// ErrUnknownPreviousResponse indicates that PreviousResponseID does not
+	// name a response remembered by this client
+	ErrUnknownPreviousResponse = errors.New("previous response not found; Anthropic is stateless, so only responses created by this client (within HistorySize) can be chained")
+
+	// ErrUnsupportedFeature indicates a request feature the Messages API cannot express
+	ErrUnsupportedFeature = errors.New("unsupported by the Anthropic Messages API")
+
+	// ErrInvalidTemperature indicates a Temperature outside Anthropic's
+	// narrower range, or other than 1.0 with extended thinking (Reasoning)
+	ErrInvalidTemperature = errors.New("Temperature must be between 0.0 and 1.0 for Anthropic, and 1.0 (or unset) with Reasoning")
+)
+
+// anthropicError represents an error response from Anthropic
+type anthropicError struct {
+	Type  string `json:"type"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// mapAnthropicError converts an HTTP error response to an *aisdk.APIError,
+// or an *aisdk.RateLimitError for 429 responses. The error type (e.g.
+// "overloaded_error") becomes the Code. It consumes the response body.
+// Reference: FR-005 (error handling), research.md decision #6
+func mapAnthropicError(resp *http.Response, correlationID string) error {
+	var apiErr anthropicError
+	body, err := io.ReadAll(resp.Body)
+	if err == nil {
+		json.Unmarshal(body, &apiErr)
+	}
+
+	// Missing details fall back to the HTTP status
+	return transport.StatusError(resp, apiErr.Error.Type, apiErr.Error.Message, correlationID)
+}
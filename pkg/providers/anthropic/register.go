@@ -0,0 +1,54 @@
+package anthropic
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func init() {
+	aisdk.Register("anthropic", openConnection)
+}
+
+// openConnection is the aisdk.ProviderFactory for "anthropic://"
+// connections. APIKey defaults to ANTHROPIC_API_KEY. Options: version,
+// max_tokens (DefaultMaxTokens) and history_size.
+func openConnection(conn *aisdk.ConnectionConfig, opts ...aisdk.Option) (aisdk.Provider, error) {
+	config := DefaultConfig()
+	config.APIKey = os.Getenv("ANTHROPIC_API_KEY")
+	if conn.APIKey != "" {
+		config.APIKey = conn.APIKey
+	}
+	config.Credentials = conn.Credentials
+	if conn.BaseURL != "" {
+		config.BaseURL = conn.BaseURL
+	}
+	if conn.Timeout > 0 {
+		config.Timeout = conn.Timeout
+	}
+
+	for key, value := range conn.Options {
+		var err error
+		switch key {
+		case "version":
+			config.Version = value
+		case "max_tokens":
+			config.DefaultMaxTokens, err = strconv.Atoi(value)
+		case "history_size":
+			config.HistorySize, err = strconv.Atoi(value)
+		default:
+			return nil, fmt.Errorf("%w: unknown option %q", aisdk.ErrInvalidConnectionString, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", aisdk.ErrInvalidConnectionString, key, err)
+		}
+	}
+
+	client, err := New(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,326 @@
+package anthropic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/amannhq/go-ai-sdk/internal/sse"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// Stream event and delta types.
+// Reference: Anthropic Messages API (streaming)
+const (
+	streamMessageStart      = "message_start"
+	streamMessageDelta      = "message_delta"
+	streamMessageStop       = "message_stop"
+	streamContentBlockStart = "content_block_start"
+	streamContentBlockDelta = "content_block_delta"
+	streamContentBlockStop  = "content_block_stop"
+	streamPing              = "ping"
+	streamError             = "error"
+
+	deltaText      = "text_delta"
+	deltaInputJSON = "input_json_delta"
+	deltaThinking  = "thinking_delta"
+	deltaSignature = "signature_delta"
+)
+
+// anthropicStreamEvent represents a streaming event in Anthropic wire format.
+// Fields are a union across all event types; only the relevant ones are set.
+type anthropicStreamEvent struct {
+	Type         string            `json:"type"`
+	Index        int               `json:"index"`
+	Message      *messagesResponse `json:"message,omitempty"`
+	ContentBlock *contentBlock     `json:"content_block,omitempty"`
+	Delta        *streamDelta      `json:"delta,omitempty"`
+	Usage        *usage            `json:"usage,omitempty"`
+	Error        *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// streamDelta is the delta of content_block_delta and message_delta events
+type streamDelta struct {
+	Type        string `json:"type"`
+	Text        string `json:"text,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
+	Thinking    string `json:"thinking,omitempty"`
+	Signature   string `json:"signature,omitempty"`
+	StopReason  string `json:"stop_reason,omitempty"`
+}
+
+// anthropicStreamReader implements aisdk.StreamReader over an SSE response
+// body, translating Anthropic events into the Responses-style event stream.
+// One Anthropic event may yield several aisdk events, so they are queued.
+type anthropicStreamReader struct {
+	ctx     context.Context
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+
+	// structuredTool is the forced tool emulating TextFormat, if any
+	structuredTool string
+
+	// message accumulates the response as blocks stream in
+	message messagesResponse
+
+	// arguments accumulates input_json_delta fragments per block index
+	arguments map[int]string
+
+	// onComplete is called with the full message at message_stop
+	onComplete func(*messagesResponse)
+
+	pending  []*aisdk.StreamEvent
+	sequence int
+
+	// done is set once a terminal event has been queued, or by Close,
+	// which may run on another goroutine
+	done atomic.Bool
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newStreamReader wraps an SSE response body in an anthropicStreamReader.
+func newStreamReader(ctx context.Context, body io.ReadCloser, structuredTool string, onComplete func(*messagesResponse)) *anthropicStreamReader {
+	return &anthropicStreamReader{
+		ctx:            ctx,
+		body:           body,
+		scanner:        sse.NewScanner(body),
+		structuredTool: structuredTool,
+		arguments:      make(map[int]string),
+		onComplete:     onComplete,
+	}
+}
+
+// Next returns the next event from the stream.
+// Returns io.EOF once response.completed (or another terminal event) has been
+// delivered, or when the server closes the stream.
+func (r *anthropicStreamReader) Next() (*aisdk.StreamEvent, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			r.Close()
+			return nil, err
+		}
+
+		if len(r.pending) > 0 {
+			event := r.pending[0]
+			r.pending = r.pending[1:]
+			return event, nil
+		}
+
+		if r.done.Load() {
+			return nil, io.EOF
+		}
+
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				if ctxErr := r.ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				return nil, aisdk.WrapError(err, "read stream")
+			}
+			r.done.Store(true)
+			return nil, io.EOF
+		}
+
+		eventName, data := sse.ParseFrame(r.scanner.Bytes())
+		if len(data) == 0 {
+			// Comment or keep-alive frame
+			continue
+		}
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, aisdk.WrapError(err, "decode stream event")
+		}
+		if event.Type == "" {
+			event.Type = eventName
+		}
+
+		r.handle(&event)
+	}
+}
+
+// Close terminates the stream and releases the HTTP response body.
+// It is safe to call Close multiple times.
+func (r *anthropicStreamReader) Close() error {
+	r.closeOnce.Do(func() {
+		r.done.Store(true)
+		r.closeErr = r.body.Close()
+	})
+	return r.closeErr
+}
+
+// handle translates one Anthropic event and queues the resulting events.
+func (r *anthropicStreamReader) handle(event *anthropicStreamEvent) {
+	switch event.Type {
+	case streamMessageStart:
+		if event.Message != nil {
+			r.message = *event.Message
+			r.message.Content = nil
+		}
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventResponseCreated, Response: r.snapshot()})
+
+	case streamContentBlockStart:
+		if event.ContentBlock == nil {
+			return
+		}
+		r.setBlock(event.Index, *event.ContentBlock)
+		item := r.item(event.Index)
+		item.Status = "in_progress"
+		r.emit(&aisdk.StreamEvent{
+			Type:        aisdk.EventOutputItemAdded,
+			ItemID:      item.ID,
+			OutputIndex: event.Index,
+			Output:      &item,
+		})
+
+	case streamContentBlockDelta:
+		r.handleDelta(event.Index, event.Delta)
+
+	case streamContentBlockStop:
+		r.handleBlockStop(event.Index)
+
+	case streamMessageDelta:
+		if event.Delta != nil && event.Delta.StopReason != "" {
+			r.message.StopReason = event.Delta.StopReason
+		}
+		if event.Usage != nil {
+			// message_delta usage is cumulative
+			r.message.Usage.OutputTokens = event.Usage.OutputTokens
+			if event.Usage.InputTokens > 0 {
+				r.message.Usage.InputTokens = event.Usage.InputTokens
+			}
+		}
+
+	case streamMessageStop:
+		resp := r.snapshot()
+		eventType := aisdk.EventResponseCompleted
+		if r.message.StopReason == stopMaxTokens {
+			eventType = aisdk.EventResponseIncomplete
+		}
+		usage := resp.Usage
+		r.emit(&aisdk.StreamEvent{Type: eventType, Response: resp, Usage: &usage})
+		r.done.Store(true)
+		if r.onComplete != nil {
+			r.onComplete(&r.message)
+		}
+
+	case streamError:
+		streamErr := &aisdk.StreamError{}
+		if event.Error != nil {
+			streamErr.Code = event.Error.Type
+			streamErr.Message = event.Error.Message
+		}
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventError, Error: streamErr})
+		r.done.Store(true)
+
+	case streamPing:
+		// Keep-alive
+	}
+}
+
+// handleDelta applies a content_block_delta and queues the matching delta event.
+func (r *anthropicStreamReader) handleDelta(index int, delta *streamDelta) {
+	block := r.block(index)
+	if delta == nil || block == nil {
+		return
+	}
+	itemID := r.item(index).ID
+
+	switch delta.Type {
+	case deltaText:
+		block.Text += delta.Text
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventOutputTextDelta, ItemID: itemID, OutputIndex: index, Delta: delta.Text})
+
+	case deltaInputJSON:
+		r.arguments[index] += delta.PartialJSON
+		eventType := aisdk.EventFunctionCallArgumentsDelta
+		if block.Name == r.structuredTool {
+			// The structured-output tool input is the response text
+			eventType = aisdk.EventOutputTextDelta
+		}
+		r.emit(&aisdk.StreamEvent{Type: eventType, ItemID: itemID, OutputIndex: index, Delta: delta.PartialJSON})
+
+	case deltaThinking:
+		block.Thinking += delta.Thinking
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventReasoningSummaryTextDelta, ItemID: itemID, OutputIndex: index, Delta: delta.Thinking})
+
+	case deltaSignature:
+		// Needed to replay thinking blocks; not surfaced as an event
+		block.Signature += delta.Signature
+	}
+}
+
+// handleBlockStop finalizes a block and queues its *.done and output_item.done events.
+func (r *anthropicStreamReader) handleBlockStop(index int) {
+	block := r.block(index)
+	if block == nil {
+		return
+	}
+
+	if block.Type == blockToolUse {
+		arguments := r.arguments[index]
+		if arguments == "" {
+			arguments = "{}"
+		}
+		block.Input = json.RawMessage(arguments)
+	}
+
+	item := r.item(index)
+	switch {
+	case block.Type == blockText:
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventOutputTextDone, ItemID: item.ID, OutputIndex: index, Text: block.Text})
+	case block.Type == blockToolUse && block.Name == r.structuredTool:
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventOutputTextDone, ItemID: item.ID, OutputIndex: index, Text: string(block.Input)})
+	case block.Type == blockToolUse:
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventFunctionCallArgumentsDone, ItemID: item.ID, OutputIndex: index, Text: string(block.Input)})
+	case block.Type == blockThinking:
+		r.emit(&aisdk.StreamEvent{Type: aisdk.EventReasoningSummaryTextDone, ItemID: item.ID, OutputIndex: index, Text: block.Thinking})
+	}
+
+	r.emit(&aisdk.StreamEvent{Type: aisdk.EventOutputItemDone, ItemID: item.ID, OutputIndex: index, Output: &item})
+}
+
+// setBlock stores the block at index, growing the content slice as needed.
+func (r *anthropicStreamReader) setBlock(index int, block contentBlock) {
+	if index < 0 {
+		return
+	}
+	for len(r.message.Content) <= index {
+		r.message.Content = append(r.message.Content, contentBlock{})
+	}
+	r.message.Content[index] = block
+}
+
+// block returns the block at index, or nil if it has not started.
+func (r *anthropicStreamReader) block(index int) *contentBlock {
+	if index < 0 || index >= len(r.message.Content) {
+		return nil
+	}
+	return &r.message.Content[index]
+}
+
+// item converts the block at index to its output item.
+func (r *anthropicStreamReader) item(index int) aisdk.OutputItem {
+	return toOutputItem(r.message.ID, index, r.block(index), r.message.StopReason, r.structuredTool)
+}
+
+// snapshot converts the message accumulated so far to an aisdk.Response.
+func (r *anthropicStreamReader) snapshot() *aisdk.Response {
+	return toAISDKResponse(&r.message, r.structuredTool)
+}
+
+// emit queues event, stamping the sequence number and response ID.
+func (r *anthropicStreamReader) emit(event *aisdk.StreamEvent) {
+	event.SequenceNumber = r.sequence
+	event.ResponseID = r.message.ID
+	r.sequence++
+	r.pending = append(r.pending, event)
+}
@@ -0,0 +1,225 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestStreamReader(t *testing.T) {
+	const (
+		start = `{"type":"message_start","message":{"id":"msg_1","model":"claude","usage":{"input_tokens":5}}}`
+		stop  = `{"type":"message_stop"}`
+	)
+
+	tests := []struct {
+		name           string
+		events         []string
+		structuredTool string
+		wantTypes      []string
+		wantText       string
+		wantCalls      []aisdk.FunctionCall
+		wantUsage      aisdk.TokenUsage
+
+		// wantStored is the content kept for the next turn
+		wantStored []contentBlock
+	}{
+		{
+			name: "text",
+			events: []string{
+				start,
+				`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+				`{"type":"ping"}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"lo"}}`,
+				`{"type":"content_block_stop","index":0}`,
+				`{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":2}}`,
+				stop,
+			},
+			wantTypes: []string{
+				aisdk.EventResponseCreated,
+				aisdk.EventOutputItemAdded,
+				aisdk.EventOutputTextDelta,
+				aisdk.EventOutputTextDelta,
+				aisdk.EventOutputTextDone,
+				aisdk.EventOutputItemDone,
+				aisdk.EventResponseCompleted,
+			},
+			wantText:  "Hello",
+			wantUsage: aisdk.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		{
+			name: "tool use",
+			events: []string{
+				start,
+				`{"type":"content_block_start","index":0,"content_block":{"type":"tool_use","id":"t1","name":"get_weather","input":{}}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"city\":"}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"\"Paris\"}"}}`,
+				`{"type":"content_block_stop","index":0}`,
+				`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":7}}`,
+				stop,
+			},
+			wantTypes: []string{
+				aisdk.EventResponseCreated,
+				aisdk.EventOutputItemAdded,
+				aisdk.EventFunctionCallArgumentsDelta,
+				aisdk.EventFunctionCallArgumentsDelta,
+				aisdk.EventFunctionCallArgumentsDone,
+				aisdk.EventOutputItemDone,
+				aisdk.EventResponseCompleted,
+			},
+			wantCalls: []aisdk.FunctionCall{{ID: "t1", CallID: "t1", Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+			wantUsage: aisdk.TokenUsage{PromptTokens: 5, CompletionTokens: 7, TotalTokens: 12},
+		},
+		{
+			name: "structured output tool streams as text",
+			events: []string{
+				start,
+				`{"type":"content_block_start","index":0,"content_block":{"type":"tool_use","id":"t1","name":"person","input":{}}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"name\":\"Ann\"}"}}`,
+				`{"type":"content_block_stop","index":0}`,
+				stop,
+			},
+			structuredTool: "person",
+			wantTypes: []string{
+				aisdk.EventResponseCreated,
+				aisdk.EventOutputItemAdded,
+				aisdk.EventOutputTextDelta,
+				aisdk.EventOutputTextDone,
+				aisdk.EventOutputItemDone,
+				aisdk.EventResponseCompleted,
+			},
+			wantText:  `{"name":"Ann"}`,
+			wantUsage: aisdk.TokenUsage{PromptTokens: 5, TotalTokens: 5},
+		},
+		{
+			name: "thinking then text",
+			events: []string{
+				start,
+				`{"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":""}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"hmm"}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"signature_delta","signature":"sig"}}`,
+				`{"type":"content_block_stop","index":0}`,
+				`{"type":"content_block_start","index":1,"content_block":{"type":"text","text":""}}`,
+				`{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"Hi"}}`,
+				`{"type":"content_block_stop","index":1}`,
+				stop,
+			},
+			wantTypes: []string{
+				aisdk.EventResponseCreated,
+				aisdk.EventOutputItemAdded,
+				aisdk.EventReasoningSummaryTextDelta,
+				aisdk.EventReasoningSummaryTextDone,
+				aisdk.EventOutputItemDone,
+				aisdk.EventOutputItemAdded,
+				aisdk.EventOutputTextDelta,
+				aisdk.EventOutputTextDone,
+				aisdk.EventOutputItemDone,
+				aisdk.EventResponseCompleted,
+			},
+			wantText:  "Hi",
+			wantUsage: aisdk.TokenUsage{PromptTokens: 5, TotalTokens: 5},
+			wantStored: []contentBlock{
+				{Type: "thinking", Thinking: "hmm", Signature: "sig"},
+				{Type: "text", Text: "Hi"},
+			},
+		},
+		{
+			name: "max tokens is incomplete",
+			events: []string{
+				start,
+				`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+				`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hi"}}`,
+				`{"type":"content_block_stop","index":0}`,
+				`{"type":"message_delta","delta":{"stop_reason":"max_tokens"},"usage":{"output_tokens":1}}`,
+				stop,
+			},
+			wantTypes: []string{
+				aisdk.EventResponseCreated,
+				aisdk.EventOutputItemAdded,
+				aisdk.EventOutputTextDelta,
+				aisdk.EventOutputTextDone,
+				aisdk.EventOutputItemDone,
+				aisdk.EventResponseIncomplete,
+			},
+			wantText:  "Hi",
+			wantUsage: aisdk.TokenUsage{PromptTokens: 5, CompletionTokens: 1, TotalTokens: 6},
+		},
+		{
+			name: "error",
+			events: []string{
+				start,
+				`{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+			},
+			wantTypes: []string{aisdk.EventResponseCreated, aisdk.EventError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body strings.Builder
+			for _, event := range tt.events {
+				// Anthropic names every event in the event field too
+				var typed struct{ Type string }
+				json.Unmarshal([]byte(event), &typed)
+				body.WriteString("event: " + typed.Type + "\ndata: " + event + "\n\n")
+			}
+
+			var completed *messagesResponse
+			reader := newStreamReader(context.Background(), io.NopCloser(strings.NewReader(body.String())), tt.structuredTool, func(m *messagesResponse) {
+				completed = m
+			})
+			defer reader.Close()
+
+			var types []string
+			var last *aisdk.StreamEvent
+			for {
+				event, err := reader.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				if event.SequenceNumber != len(types) {
+					t.Errorf("SequenceNumber = %d, want %d", event.SequenceNumber, len(types))
+				}
+				types = append(types, event.Type)
+				last = event
+			}
+
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Fatalf("event types = %v, want %v", types, tt.wantTypes)
+			}
+			if last.Type == aisdk.EventError {
+				if completed != nil {
+					t.Error("onComplete called after an error")
+				}
+				return
+			}
+			if completed == nil {
+				t.Fatal("onComplete not called")
+			}
+			if tt.wantStored != nil && !reflect.DeepEqual(completed.Content, tt.wantStored) {
+				t.Errorf("stored content = %+v, want %+v", completed.Content, tt.wantStored)
+			}
+
+			resp := last.Response
+			if text := resp.OutputText(); text != tt.wantText {
+				t.Errorf("OutputText() = %q, want %q", text, tt.wantText)
+			}
+			if calls := resp.ToolCalls(); !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("ToolCalls() = %+v, want %+v", calls, tt.wantCalls)
+			}
+			if resp.Usage != tt.wantUsage {
+				t.Errorf("Usage = %+v, want %+v", resp.Usage, tt.wantUsage)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// Content block types.
+// Reference: Anthropic Messages API (content blocks)
+const (
+	blockText             = "text"
+	blockImage            = "image"
+	blockDocument         = "document"
+	blockToolUse          = "tool_use"
+	blockToolResult       = "tool_result"
+	blockThinking         = "thinking"
+	blockRedactedThinking = "redacted_thinking"
+)
+
+// Stop reasons that change how the response is reported
+const (
+	stopMaxTokens = "max_tokens"
+	stopRefusal   = "refusal"
+)
+
+// Output item and content types used for thinking blocks, which have no
+// dedicated aisdk constants
+const (
+	outputItemTypeReasoning = "reasoning"
+	contentTypeReasoning    = "reasoning_text"
+)
+
+// structuredOutputToolName is the forced tool used for TextFormat when the
+// format has no name
+const structuredOutputToolName = "json_output"
+
+// thinkingBudgets maps ReasoningConfig.Effort to extended-thinking token budgets
+var thinkingBudgets = map[string]int{
+	"low":    1024,
+	"medium": 4096,
+	"high":   16384,
+}
+
+// messagesRequest represents the Anthropic wire format for requests.
+// Maps from aisdk.CreateResponseRequest to the Messages API.
+type messagesRequest struct {
+	Model       string      `json:"model"`
+	MaxTokens   int         `json:"max_tokens"`
+	System      string      `json:"system,omitempty"`
+	Messages    []message   `json:"messages"`
+	Temperature *float64    `json:"temperature,omitempty"`
+	Stream      bool        `json:"stream,omitempty"`
+	Tools       []tool      `json:"tools,omitempty"`
+	ToolChoice  *toolChoice `json:"tool_choice,omitempty"`
+	Thinking    *thinking   `json:"thinking,omitempty"`
+}
+
+// message represents a user or assistant turn
+type message struct {
+	Role    string         `json:"role"`
+	Content []contentBlock `json:"content"`
+}
+
+// contentBlock represents a content block in requests and responses.
+// Fields are a union across block types; only the relevant ones are set.
+type contentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	Source    *source         `json:"source,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	Thinking  string          `json:"thinking,omitempty"`
+	Signature string          `json:"signature,omitempty"`
+	Data      string          `json:"data,omitempty"`
+}
+
+// source represents the data of an image or document block
+type source struct {
+	Type      string `json:"type"` // "base64" or "url"
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// tool represents a tool definition in Anthropic format
+type tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// toolChoice represents the Anthropic tool_choice object
+type toolChoice struct {
+	Type                   string `json:"type"` // "auto", "any", "tool" or "none"
+	Name                   string `json:"name,omitempty"`
+	DisableParallelToolUse bool   `json:"disable_parallel_tool_use,omitempty"`
+}
+
+// thinking represents the extended thinking configuration
+type thinking struct {
+	Type         string `json:"type"` // "enabled"
+	BudgetTokens int    `json:"budget_tokens"`
+}
+
+// messagesResponse represents the Anthropic wire format for responses
+type messagesResponse struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	Role       string         `json:"role"`
+	Model      string         `json:"model"`
+	Content    []contentBlock `json:"content"`
+	StopReason string         `json:"stop_reason"`
+	Usage      usage          `json:"usage"`
+}
+
+// usage represents token usage in Anthropic format
+type usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// requestOptions carries the provider settings needed for conversion
+type requestOptions struct {
+	defaultMaxTokens int
+
+	// history holds the prior turns when PreviousResponseID is set
+	history []message
+}
+
+// toAnthropicRequest converts aisdk.CreateResponseRequest to messagesRequest.
+// Instructions and system/developer messages become the system prompt;
+// TextFormat is emulated with a forced tool whose input is the output object.
+func toAnthropicRequest(req *aisdk.CreateResponseRequest, opts requestOptions) (*messagesRequest, error) {
+	if t := req.Temperature; t != nil && (*t > 1 || (req.Reasoning != nil && *t != 1)) {
+		return nil, fmt.Errorf("%w (got %g)", ErrInvalidTemperature, *t)
+	}
+
+	system, messages, err := toAnthropicMessages(req.Input)
+	if err != nil {
+		return nil, err
+	}
+	if req.Instructions != "" {
+		system = append([]string{req.Instructions}, system...)
+	}
+
+	mReq := &messagesRequest{
+		Model:       req.Model,
+		MaxTokens:   opts.defaultMaxTokens,
+		System:      strings.Join(system, "\n\n"),
+		Messages:    mergeTurns(append(append([]message{}, opts.history...), messages...)),
+		Temperature: req.Temperature,
+		Stream:      req.Stream,
+	}
+	if req.MaxTokens != nil {
+		mReq.MaxTokens = *req.MaxTokens
+	}
+
+	// Convert Reasoning to an extended-thinking budget
+	if req.Reasoning != nil {
+		budget := thinkingBudgets[req.Reasoning.Effort]
+		mReq.Thinking = &thinking{Type: "enabled", BudgetTokens: budget}
+		if mReq.MaxTokens <= budget {
+			// max_tokens includes the thinking budget
+			mReq.MaxTokens = budget + opts.defaultMaxTokens
+		}
+	}
+
+	// Convert Tools if present
+	for _, t := range req.Tools {
+		schema := t.Parameters
+		if schema == nil {
+			schema = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		mReq.Tools = append(mReq.Tools, tool{Name: t.Name, Description: t.Description, InputSchema: schema})
+	}
+
+	// Convert ToolChoice if present
+	if req.ToolChoice != nil {
+		switch req.ToolChoice.Mode {
+		case aisdk.ToolChoiceAuto:
+			mReq.ToolChoice = &toolChoice{Type: "auto"}
+		case aisdk.ToolChoiceNone:
+			mReq.ToolChoice = &toolChoice{Type: "none"}
+		case aisdk.ToolChoiceRequired:
+			mReq.ToolChoice = &toolChoice{Type: "any"}
+		case aisdk.ToolChoiceFunction:
+			mReq.ToolChoice = &toolChoice{Type: "tool", Name: req.ToolChoice.Name}
+		}
+	}
+	if req.ParallelToolCalls != nil && !*req.ParallelToolCalls && len(mReq.Tools) > 0 {
+		if mReq.ToolChoice == nil {
+			mReq.ToolChoice = &toolChoice{Type: "auto"}
+		}
+		mReq.ToolChoice.DisableParallelToolUse = true
+	}
+
+	// Emulate structured outputs with a forced tool call
+	if name := structuredToolName(req); name != "" {
+		if mReq.Thinking != nil {
+			return nil, fmt.Errorf("%w: TextFormat cannot be combined with Reasoning (forced tool use is incompatible with extended thinking)", ErrUnsupportedFeature)
+		}
+		for _, t := range mReq.Tools {
+			if t.Name == name {
+				return nil, fmt.Errorf("%w: TextFormat is sent as a tool named %q, which Tools already declares; set TextFormat.Name to another name", ErrUnsupportedFeature, name)
+			}
+		}
+		mReq.Tools = append(mReq.Tools, tool{
+			Name:        name,
+			Description: "Respond with the final answer as the input of this tool, matching its schema exactly.",
+			InputSchema: req.TextFormat.Schema,
+		})
+		mReq.ToolChoice = &toolChoice{Type: "tool", Name: name}
+	}
+
+	return mReq, nil
+}
+
+// structuredToolName returns the forced tool name used for TextFormat, or ""
+// when the request does not ask for structured output.
+func structuredToolName(req *aisdk.CreateResponseRequest) string {
+	if req.TextFormat == nil || req.TextFormat.Type != "json_schema" {
+		return ""
+	}
+	if req.TextFormat.Name != "" {
+		return req.TextFormat.Name
+	}
+	return structuredOutputToolName
+}
+
+// toAnthropicMessages converts aisdk input (string, Message, []Message or
+// []InputItem) to system prompt parts and user/assistant messages.
+func toAnthropicMessages(input interface{}) ([]string, []message, error) {
+	items, ok := aisdk.InputItems(input)
+	if !ok {
+		// Plain string prompt
+		text, _ := input.(string)
+		return nil, []message{{Role: aisdk.RoleUser, Content: []contentBlock{{Type: blockText, Text: text}}}}, nil
+	}
+
+	var system []string
+	var messages []message
+	for i, item := range items {
+		switch v := item.(type) {
+		case aisdk.Message:
+			if v.Role == aisdk.RoleSystem || v.Role == aisdk.RoleDeveloper {
+				for j, part := range v.Content {
+					if part.Type != aisdk.ContentTypeInputText {
+						return nil, nil, fmt.Errorf("input[%d]: content[%d]: %w: %s content in %s message (the system prompt is text only)", i, j, ErrUnsupportedFeature, part.Type, v.Role)
+					}
+					system = append(system, part.Text)
+				}
+				continue
+			}
+			blocks, err := toContentBlocks(v.Content)
+			if err != nil {
+				return nil, nil, fmt.Errorf("input[%d]: %w", i, err)
+			}
+			messages = append(messages, message{Role: v.Role, Content: blocks})
+		case aisdk.FunctionCall:
+			arguments := v.Arguments
+			if arguments == "" {
+				arguments = "{}"
+			}
+			messages = append(messages, message{Role: aisdk.RoleAssistant, Content: []contentBlock{{
+				Type:  blockToolUse,
+				ID:    v.CallID,
+				Name:  v.Name,
+				Input: json.RawMessage(arguments),
+			}}})
+		case aisdk.FunctionCallOutput:
+			messages = append(messages, message{Role: aisdk.RoleUser, Content: []contentBlock{{
+				Type:      blockToolResult,
+				ToolUseID: v.CallID,
+				Content:   v.Output,
+			}}})
+		}
+	}
+	return system, messages, nil
+}
+
+// toContentBlocks converts message content parts to Anthropic content blocks.
+func toContentBlocks(parts []aisdk.InputContent) ([]contentBlock, error) {
+	blocks := make([]contentBlock, 0, len(parts))
+	for i, part := range parts {
+		switch part.Type {
+		case aisdk.ContentTypeInputText, aisdk.ContentTypeOutputText:
+			blocks = append(blocks, contentBlock{Type: blockText, Text: part.Text})
+		case aisdk.ContentTypeRefusal:
+			blocks = append(blocks, contentBlock{Type: blockText, Text: part.Refusal})
+		case aisdk.ContentTypeInputImage:
+			src, err := toSource(part.ImageURL, part.FileID)
+			if err != nil {
+				return nil, fmt.Errorf("content[%d]: %w", i, err)
+			}
+			blocks = append(blocks, contentBlock{Type: blockImage, Source: src})
+		case aisdk.ContentTypeInputFile:
+			ref := part.FileURL
+			if part.FileData != "" {
+				ref = part.FileData
+			}
+			src, err := toSource(ref, part.FileID)
+			if err != nil {
+				return nil, fmt.Errorf("content[%d]: %w", i, err)
+			}
+			blocks = append(blocks, contentBlock{Type: blockDocument, Source: src})
+		default:
+			return nil, fmt.Errorf("content[%d]: %w: %s content", i, ErrUnsupportedFeature, part.Type)
+		}
+	}
+	return blocks, nil
+}
+
+// toSource converts a URL or base64 data URL to a block source.
+// Uploaded file IDs are not supported.
+func toSource(ref, fileID string) (*source, error) {
+	if ref == "" && fileID != "" {
+		return nil, fmt.Errorf("%w: file IDs (upload the content inline or by URL)", ErrUnsupportedFeature)
+	}
+
+	// data:<media type>;base64,<data>
+	if rest, ok := strings.CutPrefix(ref, "data:"); ok {
+		meta, data, found := strings.Cut(rest, ",")
+		mediaType, isBase64 := strings.CutSuffix(meta, ";base64")
+		if !found || !isBase64 {
+			return nil, fmt.Errorf("%w: data URLs must be base64 encoded", aisdk.ErrInvalidContentPart)
+		}
+		return &source{Type: "base64", MediaType: mediaType, Data: data}, nil
+	}
+	return &source{Type: "url", URL: ref}, nil
+}
+
+// mergeTurns joins consecutive messages from the same role, since the
+// Messages API requires user and assistant turns to alternate (e.g. several
+// tool results answering one assistant turn).
+func mergeTurns(messages []message) []message {
+	merged := make([]message, 0, len(messages))
+	for _, m := range messages {
+		if n := len(merged); n > 0 && merged[n-1].Role == m.Role {
+			last := &merged[n-1]
+			last.Content = append(append([]contentBlock{}, last.Content...), m.Content...)
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// toAISDKResponse converts messagesResponse to aisdk.Response. Each content
+// block becomes one output item so stream output indexes match.
+func toAISDKResponse(resp *messagesResponse, structuredTool string) *aisdk.Response {
+	out := &aisdk.Response{
+		ID:      resp.ID,
+		Object:  "response",
+		Model:   resp.Model,
+		Created: time.Now().Unix(),
+		Usage:   toTokenUsage(&resp.Usage),
+		Output:  make([]aisdk.OutputItem, len(resp.Content)),
+	}
+
+	for i := range resp.Content {
+		out.Output[i] = toOutputItem(resp.ID, i, &resp.Content[i], resp.StopReason, structuredTool)
+	}
+
+	return out
+}
+
+// toOutputItem converts a content block to an aisdk.OutputItem.
+func toOutputItem(responseID string, index int, block *contentBlock, stopReason, structuredTool string) aisdk.OutputItem {
+	status := "completed"
+	if stopReason == stopMaxTokens {
+		status = "incomplete"
+	}
+
+	switch block.Type {
+	case blockToolUse:
+		arguments := string(block.Input)
+		if block.Name == structuredTool {
+			// Forced structured-output tool: its input is the output object
+			return messageItem(responseID, index, status, aisdk.ContentPart{Type: aisdk.ContentTypeOutputText, Text: arguments})
+		}
+		return aisdk.OutputItem{
+			ID:        block.ID,
+			Type:      aisdk.OutputItemTypeFunctionCall,
+			Status:    status,
+			CallID:    block.ID,
+			Name:      block.Name,
+			Arguments: arguments,
+		}
+
+	case blockThinking, blockRedactedThinking:
+		item := aisdk.OutputItem{
+			ID:     itemID(responseID, index),
+			Type:   outputItemTypeReasoning,
+			Status: status,
+		}
+		if block.Thinking != "" {
+			item.Content = []aisdk.ContentPart{{Type: contentTypeReasoning, Text: block.Thinking}}
+		}
+		return item
+
+	default:
+		if stopReason == stopRefusal {
+			return messageItem(responseID, index, status, aisdk.ContentPart{Type: aisdk.ContentTypeRefusal, Refusal: block.Text})
+		}
+		return messageItem(responseID, index, status, aisdk.ContentPart{Type: aisdk.ContentTypeOutputText, Text: block.Text})
+	}
+}
+
+// messageItem creates an assistant message item with a single content part.
+func messageItem(responseID string, index int, status string, part aisdk.ContentPart) aisdk.OutputItem {
+	return aisdk.OutputItem{
+		ID:      itemID(responseID, index),
+		Type:    aisdk.OutputItemTypeMessage,
+		Role:    aisdk.RoleAssistant,
+		Status:  status,
+		Content: []aisdk.ContentPart{part},
+	}
+}
+
+// itemID derives a stable item ID for blocks that have none.
+func itemID(responseID string, index int) string {
+	return fmt.Sprintf("%s_%d", responseID, index)
+}
+
+// toTokenUsage converts Anthropic usage; cached input counts as prompt tokens.
+func toTokenUsage(u *usage) aisdk.TokenUsage {
+	prompt := u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+	return aisdk.TokenUsage{
+		PromptTokens:     prompt,
+		CompletionTokens: u.OutputTokens,
+		TotalTokens:      prompt + u.OutputTokens,
+	}
+}
@@ -0,0 +1,274 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestToAnthropicRequest(t *testing.T) {
+	maxTokens := 100
+	noParallel := false
+	temperature := func(t float64) *float64 { return &t }
+	schema := map[string]interface{}{"type": "object", "properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}}}
+
+	tests := []struct {
+		name    string
+		req     *aisdk.CreateResponseRequest
+		history []message
+		want    string
+		wantErr error
+	}{
+		{
+			name: "string input",
+			req:  &aisdk.CreateResponseRequest{Model: "claude", Input: "hi", MaxTokens: &maxTokens},
+			want: `{"model":"claude","max_tokens":100,"messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}]}`,
+		},
+		{
+			name: "instructions and system messages become the system prompt",
+			req: &aisdk.CreateResponseRequest{
+				Model:        "claude",
+				Instructions: "be nice",
+				Input:        []aisdk.Message{aisdk.NewSystemMessage("sys"), aisdk.NewDeveloperMessage("dev"), aisdk.NewUserMessage("hi")},
+			},
+			want: `{"model":"claude","max_tokens":4096,"system":"be nice\n\nsys\n\ndev","messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}]}`,
+		},
+		{
+			name: "function calls and outputs merge into alternating turns",
+			req: &aisdk.CreateResponseRequest{
+				Model: "claude",
+				Input: []aisdk.InputItem{
+					aisdk.NewUserMessage("weather?"),
+					aisdk.FunctionCall{CallID: "t1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+					aisdk.FunctionCall{CallID: "t2", Name: "get_time"},
+					aisdk.NewFunctionCallOutput("t1", "sunny"),
+					aisdk.NewFunctionCallOutput("t2", "noon"),
+				},
+			},
+			want: `{"model":"claude","max_tokens":4096,"messages":[
+				{"role":"user","content":[{"type":"text","text":"weather?"}]},
+				{"role":"assistant","content":[
+					{"type":"tool_use","id":"t1","name":"get_weather","input":{"city":"Paris"}},
+					{"type":"tool_use","id":"t2","name":"get_time","input":{}}]},
+				{"role":"user","content":[
+					{"type":"tool_result","tool_use_id":"t1","content":"sunny"},
+					{"type":"tool_result","tool_use_id":"t2","content":"noon"}]}]}`,
+		},
+		{
+			name:    "history precedes the input",
+			req:     &aisdk.CreateResponseRequest{Model: "claude", Input: "again"},
+			history: []message{{Role: aisdk.RoleUser, Content: []contentBlock{{Type: blockText, Text: "hi"}}}, {Role: aisdk.RoleAssistant, Content: []contentBlock{{Type: blockText, Text: "Hello"}}}},
+			want: `{"model":"claude","max_tokens":4096,"messages":[
+				{"role":"user","content":[{"type":"text","text":"hi"}]},
+				{"role":"assistant","content":[{"type":"text","text":"Hello"}]},
+				{"role":"user","content":[{"type":"text","text":"again"}]}]}`,
+		},
+		{
+			name: "images and documents",
+			req: &aisdk.CreateResponseRequest{
+				Model: "claude",
+				Input: []aisdk.Message{{Role: aisdk.RoleUser, Content: []aisdk.InputContent{
+					aisdk.NewImageURLContent("https://example.com/cat.png", ""),
+					aisdk.NewFileDataContent("doc.pdf", "data:application/pdf;base64,JVBERi0="),
+				}}},
+			},
+			want: `{"model":"claude","max_tokens":4096,"messages":[{"role":"user","content":[
+				{"type":"image","source":{"type":"url","url":"https://example.com/cat.png"}},
+				{"type":"document","source":{"type":"base64","media_type":"application/pdf","data":"JVBERi0="}}]}]}`,
+		},
+		{
+			name: "file IDs are unsupported",
+			req: &aisdk.CreateResponseRequest{
+				Model: "claude",
+				Input: []aisdk.Message{{Role: aisdk.RoleUser, Content: []aisdk.InputContent{aisdk.NewFileIDContent("file-1")}}},
+			},
+			wantErr: ErrUnsupportedFeature,
+		},
+		{
+			name: "tools with required choice and no parallel calls",
+			req: &aisdk.CreateResponseRequest{
+				Model:             "claude",
+				Input:             "hi",
+				Tools:             []aisdk.Tool{aisdk.NewFunctionTool("get_weather", "Weather", nil)},
+				ToolChoice:        &aisdk.ToolChoice{Mode: aisdk.ToolChoiceRequired},
+				ParallelToolCalls: &noParallel,
+			},
+			want: `{"model":"claude","max_tokens":4096,"messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}],
+				"tools":[{"name":"get_weather","description":"Weather","input_schema":{"type":"object","properties":{}}}],
+				"tool_choice":{"type":"any","disable_parallel_tool_use":true}}`,
+		},
+		{
+			name: "reasoning raises max_tokens above the thinking budget",
+			req:  &aisdk.CreateResponseRequest{Model: "claude", Input: "hi", MaxTokens: &maxTokens, Reasoning: &aisdk.ReasoningConfig{Effort: "medium"}},
+			want: `{"model":"claude","max_tokens":8192,"messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}],
+				"thinking":{"type":"enabled","budget_tokens":4096}}`,
+		},
+		{
+			name: "text format becomes a forced tool",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "claude",
+				Input:      "hi",
+				TextFormat: &aisdk.TextFormat{Type: "json_schema", Name: "person", Schema: schema},
+			},
+			want: `{"model":"claude","max_tokens":4096,"messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}],
+				"tools":[{"name":"person","description":"Respond with the final answer as the input of this tool, matching its schema exactly.",
+					"input_schema":{"type":"object","properties":{"name":{"type":"string"}}}}],
+				"tool_choice":{"type":"tool","name":"person"}}`,
+		},
+		{
+			name: "text format with reasoning",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "claude",
+				Input:      "hi",
+				Reasoning:  &aisdk.ReasoningConfig{Effort: "low"},
+				TextFormat: &aisdk.TextFormat{Type: "json_schema", Schema: schema},
+			},
+			wantErr: ErrUnsupportedFeature,
+		},
+		{
+			name: "text format named like a tool",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "claude",
+				Input:      "hi",
+				Tools:      []aisdk.Tool{aisdk.NewFunctionTool("person", "Look up a person", nil)},
+				TextFormat: &aisdk.TextFormat{Type: "json_schema", Name: "person", Schema: schema},
+			},
+			wantErr: ErrUnsupportedFeature,
+		},
+		{
+			name: "image in a system message",
+			req: &aisdk.CreateResponseRequest{
+				Model: "claude",
+				Input: []aisdk.Message{
+					{Role: aisdk.RoleSystem, Content: []aisdk.InputContent{aisdk.NewImageURLContent("https://example.com/logo.png", "")}},
+					aisdk.NewUserMessage("hi"),
+				},
+			},
+			wantErr: ErrUnsupportedFeature,
+		},
+		{
+			name: "temperature",
+			req:  &aisdk.CreateResponseRequest{Model: "claude", Input: "hi", Temperature: temperature(0.7)},
+			want: `{"model":"claude","max_tokens":4096,"messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}],"temperature":0.7}`,
+		},
+		{
+			name:    "temperature above 1",
+			req:     &aisdk.CreateResponseRequest{Model: "claude", Input: "hi", Temperature: temperature(1.5)},
+			wantErr: ErrInvalidTemperature,
+		},
+		{
+			name: "temperature 1 with reasoning",
+			req:  &aisdk.CreateResponseRequest{Model: "claude", Input: "hi", Temperature: temperature(1), Reasoning: &aisdk.ReasoningConfig{Effort: "low"}},
+			want: `{"model":"claude","max_tokens":4096,"messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}],"temperature":1,
+				"thinking":{"type":"enabled","budget_tokens":1024}}`,
+		},
+		{
+			name:    "other temperature with reasoning",
+			req:     &aisdk.CreateResponseRequest{Model: "claude", Input: "hi", Temperature: temperature(0.2), Reasoning: &aisdk.ReasoningConfig{Effort: "low"}},
+			wantErr: ErrInvalidTemperature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toAnthropicRequest(tt.req, requestOptions{defaultMaxTokens: 4096, history: tt.history})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("toAnthropicRequest() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toAnthropicRequest() error = %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestToAISDKResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		resp           string
+		structuredTool string
+		wantText       string
+		wantCalls      []aisdk.FunctionCall
+		wantStatus     string
+		wantUsage      aisdk.TokenUsage
+	}{
+		{
+			name:       "text",
+			resp:       `{"id":"msg_1","content":[{"type":"text","text":"Hi"}],"stop_reason":"end_turn","usage":{"input_tokens":3,"output_tokens":4}}`,
+			wantText:   "Hi",
+			wantStatus: "completed",
+			wantUsage:  aisdk.TokenUsage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7},
+		},
+		{
+			name: "tool use after thinking",
+			resp: `{"id":"msg_1","content":[{"type":"thinking","thinking":"hmm"},{"type":"tool_use","id":"t1","name":"get_weather","input":{"city":"Paris"}}],
+				"stop_reason":"tool_use","usage":{"input_tokens":3,"output_tokens":4,"cache_read_input_tokens":2}}`,
+			wantCalls:  []aisdk.FunctionCall{{ID: "t1", CallID: "t1", Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+			wantStatus: "completed",
+			wantUsage:  aisdk.TokenUsage{PromptTokens: 5, CompletionTokens: 4, TotalTokens: 9},
+		},
+		{
+			name:           "structured output tool becomes text",
+			resp:           `{"id":"msg_1","content":[{"type":"tool_use","id":"t1","name":"person","input":{"name":"Ann"}}],"stop_reason":"tool_use","usage":{}}`,
+			structuredTool: "person",
+			wantText:       `{"name":"Ann"}`,
+			wantStatus:     "completed",
+		},
+		{
+			name:       "max tokens is incomplete",
+			resp:       `{"id":"msg_1","content":[{"type":"text","text":"Hi"}],"stop_reason":"max_tokens","usage":{}}`,
+			wantText:   "Hi",
+			wantStatus: "incomplete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mResp messagesResponse
+			if err := json.Unmarshal([]byte(tt.resp), &mResp); err != nil {
+				t.Fatal(err)
+			}
+
+			got := toAISDKResponse(&mResp, tt.structuredTool)
+			if text := got.OutputText(); text != tt.wantText {
+				t.Errorf("OutputText() = %q, want %q", text, tt.wantText)
+			}
+			if calls := got.ToolCalls(); !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("ToolCalls() = %+v, want %+v", calls, tt.wantCalls)
+			}
+			if status := got.Output[len(got.Output)-1].Status; status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", status, tt.wantStatus)
+			}
+			if got.Usage != tt.wantUsage {
+				t.Errorf("Usage = %+v, want %+v", got.Usage, tt.wantUsage)
+			}
+		})
+	}
+}
+
+// assertJSON fails t unless v encodes to the same JSON as want.
+func assertJSON(t *testing.T, v interface{}, want string) {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got, expected interface{}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got  %s\nwant %s", raw, want)
+	}
+}
@@ -0,0 +1,13 @@
+package gemini
+
+import (
+	"net/http"
+)
+
+// addAuthHeaders adds Gemini authentication headers to the request.
+// The key is sent as a header rather than the ?key= query parameter so it
+// does not appear in logged URLs.
+// Reference: FR-004 (authentication)
+func addAuthHeaders(req *http.Request, apiKey string) {
+	req.Header.Set("x-goog-api-key", apiKey)
+}
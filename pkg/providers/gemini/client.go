@@ -0,0 +1,211 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/amannhq/go-ai-sdk/internal/history"
+	internalhttp "github.com/amannhq/go-ai-sdk/internal/http"
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// Client implements the Provider interface for the Gemini API.
+// Reference: architecture.md (Provider Interface Pattern)
+type Client struct {
+	config    *Config
+	transport *transport.Transport
+	history   *history.History[content]
+}
+
+// New creates a new Gemini client with the given configuration.
+// Options are applied to a copy of config (DefaultConfig when nil).
+func New(config *Config, opts ...aisdk.Option) (*Client, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	configured := *config
+	config = &configured
+	overrides := aisdk.NewOptions(opts...).ClientConfig()
+	config.override(overrides)
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := overrides.Validate(); err != nil {
+		return nil, err
+	}
+	settings := config.settings()
+	settings.Apply(overrides)
+
+	return &Client{
+		config:    config,
+		transport: transport.New("gemini", settings, mapGeminiError),
+		history:   history.New[content](config.HistorySize),
+	}, nil
+}
+
+// NewFromEnv creates a new Gemini client loading configuration from environment.
+func NewFromEnv(opts ...aisdk.Option) (*Client, error) {
+	config, err := NewConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return New(config, opts...)
+}
+
+// Configure implements aisdk.ConfigurableProvider. The copy shares the
+// conversation history of c.
+func (c *Client) Configure(config *aisdk.ClientConfig) aisdk.Provider {
+	configured := *c.config
+	configured.override(config)
+	return &Client{
+		config:    &configured,
+		transport: c.transport.Configure(config),
+		history:   c.history,
+	}
+}
+
+// CreateResponse implements Provider.CreateResponse for Gemini.
+// POSTs to models/{model}:generateContent and converts the first candidate
+// to an aisdk.Response.
+func (c *Client) CreateResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (*aisdk.Response, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, aisdk.WrapError(err, "gemini.CreateResponse")
+	}
+
+	// Convert to Gemini format
+	gReq, err := c.toRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "gemini.CreateResponse")
+	}
+
+	// Every attempt is traced under the same correlation ID
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	httpResp, err := c.send(ctx, req, gReq, false, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	// Parse response
+	var gResp generateContentResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&gResp); err != nil {
+		return nil, c.transport.Fail(ctx, http.MethodPost, httpResp.Request.URL.String(), aisdk.WrapError(err, "decode response"))
+	}
+
+	// Convert to SDK format (assigns missing response and call IDs)
+	resp := toAISDKResponse(&gResp, req.Model)
+	c.remember(gReq, &gResp)
+
+	// Attach rate limit info
+	resp.RateLimitInfo = transport.RateLimitInfo(internalhttp.ExtractRateLimitHeaders(httpResp.Header))
+
+	return resp, nil
+}
+
+// StreamResponse implements Provider.StreamResponse for Gemini.
+// POSTs to models/{model}:streamGenerateContent?alt=sse and returns a
+// StreamReader translating the response chunks into response.* events.
+// The caller must Close the reader to release the connection.
+func (c *Client) StreamResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (aisdk.StreamReader, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, aisdk.WrapError(err, "gemini.StreamResponse")
+	}
+
+	// Convert to Gemini format
+	gReq, err := c.toRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "gemini.StreamResponse")
+	}
+
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	// Open the stream (retrying connection setup); the body stays open
+	// until the reader is closed
+	httpResp, err := c.send(ctx, req, gReq, true, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStreamReader(ctx, httpResp.Body, req.Model, func(gResp *generateContentResponse) {
+		c.remember(gReq, gResp)
+	}), nil
+}
+
+// toRequest converts req, replaying the conversation named by PreviousResponseID.
+func (c *Client) toRequest(req *aisdk.CreateResponseRequest) (*generateContentRequest, error) {
+	var prior []content
+	if req.PreviousResponseID != "" {
+		var ok bool
+		prior, ok = c.history.Get(req.PreviousResponseID)
+		if !ok {
+			return nil, ErrUnknownPreviousResponse
+		}
+	}
+	return toGeminiRequest(req, prior)
+}
+
+// remember records the conversation so later requests can chain on it.
+func (c *Client) remember(gReq *generateContentRequest, gResp *generateContentResponse) {
+	conversation := append([]content{}, gReq.Contents...)
+	if len(gResp.Candidates) > 0 && len(gResp.Candidates[0].Content.Parts) > 0 {
+		reply := gResp.Candidates[0].Content
+		reply.Role = roleModel
+		conversation = append(conversation, reply)
+	}
+	c.history.Put(gResp.ResponseID, conversation)
+}
+
+// send POSTs gReq to the model's generate endpoint through the shared
+// transport. Streaming requests are sent without the overall client timeout.
+func (c *Client) send(ctx context.Context, req *aisdk.CreateResponseRequest, gReq *generateContentRequest, stream bool, correlationID string) (*http.Response, error) {
+	// Marshal request
+	body, err := json.Marshal(gReq)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "marshal request")
+	}
+
+	// Create HTTP request
+	url := c.config.BaseURL + "/" + modelPath(req.Model) + ":generateContent"
+	if stream {
+		url = c.config.BaseURL + "/" + modelPath(req.Model) + ":streamGenerateContent?alt=sse"
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, aisdk.WrapError(err, "create http request")
+	}
+
+	// Add headers
+	addAuthHeaders(httpReq, c.config.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	if stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+
+	return c.transport.Send(ctx, &transport.Request{
+		HTTP:          httpReq,
+		Stream:        stream,
+		CorrelationID: correlationID,
+		APIKey:        c.config.APIKey,
+		Authorize:     addAuthHeaders,
+		Model:         req.Model,
+		Tokens:        transport.EstimateTokens(req, body),
+		RetryDelay:    retryDelay,
+	})
+}
+
+// modelPath returns the resource name of model, accepting both bare IDs
+// ("gemini-2.5-flash") and resource names ("models/...", "tunedModels/...").
+func modelPath(model string) string {
+	if strings.Contains(model, "/") {
+		return model
+	}
+	return "models/" + model
+}
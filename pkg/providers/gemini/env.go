@@ -0,0 +1,22 @@
+package gemini
+
+import (
+	"os"
+)
+
+// NewConfigFromEnv creates a Config loading the API key from environment.
+// Reads GEMINI_API_KEY, falling back to GOOGLE_API_KEY.
+// Reference: FR-013 (environment-based configuration)
+func NewConfigFromEnv() (*Config, error) {
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("GOOGLE_API_KEY")
+	}
+	if apiKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	config := DefaultConfig()
+	config.APIKey = apiKey
+	return config, nil
+}
@@ -0,0 +1,97 @@
+package gemini
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	internalhttp "github.com/amannhq/go-ai-sdk/internal/http"
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+var (
+	// ErrUnknownPreviousResponse indicates that PreviousResponseID does not
+	// name a response remembered by this client
+	ErrUnknownPreviousResponse = errors.New("previous response not found; Gemini is stateless, so only responses created by this client (within HistorySize) can be chained")
+
+	// ErrUnknownCallID indicates a function_call_output whose call is not in
+	// the input or the chained conversation (Gemini needs the function name)
+	ErrUnknownCallID = errors.New("function_call_output does not match any function_call in the conversation")
+)
+
+// retryInfoType is the error detail carrying the server-suggested retry delay
+const retryInfoType = "type.googleapis.com/google.rpc.RetryInfo"
+
+// geminiError represents an error response from Gemini (google.rpc.Status)
+type geminiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type       string `json:"@type"`
+			RetryDelay string `json:"retryDelay,omitempty"`
+		} `json:"details,omitempty"`
+	} `json:"error"`
+}
+
+// mapGeminiError converts an HTTP error response to an *aisdk.APIError,
+// or an *aisdk.RateLimitError for 429 responses. The RPC status (e.g.
+// "RESOURCE_EXHAUSTED") becomes the Code, and a RetryInfo detail sets
+// RetryAfter when no Retry-After header was sent. It consumes the response body.
+// Reference: FR-005 (error handling), research.md decision #6
+func mapGeminiError(resp *http.Response, correlationID string) error {
+	var apiErr geminiError
+	body, err := io.ReadAll(resp.Body)
+	if err == nil {
+		json.Unmarshal(body, &apiErr)
+	}
+
+	// Missing details fall back to the HTTP status
+	mapped := transport.StatusError(resp, apiErr.Error.Status, apiErr.Error.Message, correlationID)
+
+	var rateLimitErr *aisdk.RateLimitError
+	if errors.As(mapped, &rateLimitErr) && rateLimitErr.RateLimitInfo != nil && rateLimitErr.RateLimitInfo.RetryAfter == 0 {
+		rateLimitErr.RateLimitInfo.RetryAfter = apiErr.retryDelay()
+	}
+	return mapped
+}
+
+// retryDelay returns the delay of the error's RetryInfo detail, or 0.
+func (e *geminiError) retryDelay() time.Duration {
+	for _, detail := range e.Error.Details {
+		if detail.Type != retryInfoType {
+			continue
+		}
+		if delay, err := time.ParseDuration(detail.RetryDelay); err == nil && delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// retryDelay returns the delay a retryable response asks for: the
+// Retry-After or rate-limit headers', or else its RetryInfo detail's. The
+// body is buffered so it stays readable.
+func retryDelay(resp *http.Response) time.Duration {
+	if delay := internalhttp.ExtractRateLimitHeaders(resp.Header).RetryDelay(); delay > 0 {
+		return delay
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return 0
+	}
+
+	var apiErr geminiError
+	if json.Unmarshal(body, &apiErr) != nil {
+		return 0
+	}
+	return apiErr.retryDelay()
+}
@@ -0,0 +1,82 @@
+package gemini
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+const quotaError = `{"error":{"code":429,"message":"Quota exceeded","status":"RESOURCE_EXHAUSTED","details":[
+	{"@type":"type.googleapis.com/google.rpc.QuotaFailure"},
+	{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"17s"}]}}`
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		body    string
+		want    time.Duration
+	}{
+		{
+			name: "retry info",
+			body: quotaError,
+			want: 17 * time.Second,
+		},
+		{
+			name:    "retry-after header wins",
+			headers: map[string]string{"Retry-After": "3"},
+			body:    quotaError,
+			want:    3 * time.Second,
+		},
+		{
+			name: "no retry info",
+			body: `{"error":{"code":503,"message":"overloaded","status":"UNAVAILABLE"}}`,
+		},
+		{
+			name: "invalid body",
+			body: "<html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(tt.body))}
+			for key, value := range tt.headers {
+				resp.Header.Set(key, value)
+			}
+
+			if got := retryDelay(resp); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+			if body, _ := io.ReadAll(resp.Body); string(body) != tt.body {
+				t.Errorf("body after retryDelay() = %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestMapGeminiError(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(quotaError)),
+	}
+
+	err := mapGeminiError(resp, "req-1")
+	var rateLimitErr *aisdk.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("mapGeminiError() = %T, want *aisdk.RateLimitError", err)
+	}
+	if rateLimitErr.Code != "RESOURCE_EXHAUSTED" || rateLimitErr.Message != "Quota exceeded" {
+		t.Errorf("Code, Message = %q, %q, want RESOURCE_EXHAUSTED, Quota exceeded", rateLimitErr.Code, rateLimitErr.Message)
+	}
+	if got := rateLimitErr.RateLimitInfo.RetryAfter; got != 17*time.Second {
+		t.Errorf("RetryAfter = %v, want 17s", got)
+	}
+}
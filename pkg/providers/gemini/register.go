@@ -0,0 +1,53 @@
+package gemini
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func init() {
+	aisdk.Register("gemini", openConnection)
+}
+
+// openConnection is the aisdk.ProviderFactory for "gemini://" connections.
+// APIKey defaults to GEMINI_API_KEY, then GOOGLE_API_KEY. Options:
+// history_size.
+func openConnection(conn *aisdk.ConnectionConfig, opts ...aisdk.Option) (aisdk.Provider, error) {
+	config := DefaultConfig()
+	config.APIKey = os.Getenv("GEMINI_API_KEY")
+	if config.APIKey == "" {
+		config.APIKey = os.Getenv("GOOGLE_API_KEY")
+	}
+	if conn.APIKey != "" {
+		config.APIKey = conn.APIKey
+	}
+	config.Credentials = conn.Credentials
+	if conn.BaseURL != "" {
+		config.BaseURL = conn.BaseURL
+	}
+	if conn.Timeout > 0 {
+		config.Timeout = conn.Timeout
+	}
+
+	for key, value := range conn.Options {
+		switch key {
+		case "history_size":
+			size, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: history_size: %v", aisdk.ErrInvalidConnectionString, err)
+			}
+			config.HistorySize = size
+		default:
+			return nil, fmt.Errorf("%w: unknown option %q", aisdk.ErrInvalidConnectionString, key)
+		}
+	}
+
+	client, err := New(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
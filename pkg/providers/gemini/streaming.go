@@ -0,0 +1,208 @@
+package gemini
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/output"
+	"github.com/amannhq/go-ai-sdk/internal/sse"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// streamChunk is one SSE payload of streamGenerateContent: a partial
+// generateContentResponse, or an error
+type streamChunk struct {
+	generateContentResponse
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error,omitempty"`
+}
+
+// geminiStreamReader implements aisdk.StreamReader over an SSE response body
+// (streamGenerateContent?alt=sse), translating response chunks into the
+// Responses-style event stream. Gemini sends no terminal event, so the
+// response.completed event is produced when the server closes the stream.
+type geminiStreamReader struct {
+	ctx     context.Context
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	model   string
+
+	// response accumulates the chunks; its first candidate holds every part
+	response generateContentResponse
+
+	// builder assembles the output and queues events; nil until the first chunk
+	builder *output.Builder
+
+	// onComplete is called with the accumulated response at the end of the stream
+	onComplete func(*generateContentResponse)
+
+	// done is set once a terminal event has been queued, or by Close,
+	// which may run on another goroutine
+	done atomic.Bool
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newStreamReader wraps an SSE response body in a geminiStreamReader.
+func newStreamReader(ctx context.Context, body io.ReadCloser, model string, onComplete func(*generateContentResponse)) *geminiStreamReader {
+	return &geminiStreamReader{
+		ctx:        ctx,
+		body:       body,
+		scanner:    sse.NewScanner(body),
+		model:      model,
+		response:   generateContentResponse{Candidates: []candidate{{Content: content{Role: roleModel}}}},
+		onComplete: onComplete,
+	}
+}
+
+// Next returns the next event from the stream.
+// Returns io.EOF once response.completed (or another terminal event) has been
+// delivered.
+func (r *geminiStreamReader) Next() (*aisdk.StreamEvent, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			r.Close()
+			return nil, err
+		}
+
+		if r.builder != nil {
+			if event := r.builder.Pop(); event != nil {
+				return event, nil
+			}
+		}
+
+		if r.done.Load() {
+			return nil, io.EOF
+		}
+
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				if ctxErr := r.ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				return nil, aisdk.WrapError(err, "read stream")
+			}
+			r.finish()
+			continue
+		}
+
+		_, data := sse.ParseFrame(r.scanner.Bytes())
+		if len(data) == 0 {
+			// Comment or keep-alive frame
+			continue
+		}
+
+		var chunk streamChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return nil, aisdk.WrapError(err, "decode stream event")
+		}
+
+		r.handle(&chunk)
+	}
+}
+
+// Close terminates the stream and releases the HTTP response body.
+// It is safe to call Close multiple times.
+func (r *geminiStreamReader) Close() error {
+	r.closeOnce.Do(func() {
+		r.done.Store(true)
+		r.closeErr = r.body.Close()
+	})
+	return r.closeErr
+}
+
+// handle translates one chunk and queues the resulting events.
+func (r *geminiStreamReader) handle(chunk *streamChunk) {
+	r.start(chunk)
+
+	if chunk.Error != nil {
+		r.builder.Emit(&aisdk.StreamEvent{Type: aisdk.EventError, Error: &aisdk.StreamError{
+			Code:    chunk.Error.Status,
+			Message: chunk.Error.Message,
+		}})
+		r.done.Store(true)
+		return
+	}
+
+	// Usage metadata is cumulative; the last chunk carries the totals
+	if chunk.UsageMetadata != (usageMetadata{}) {
+		r.response.UsageMetadata = chunk.UsageMetadata
+	}
+	if chunk.PromptFeedback != nil {
+		r.response.PromptFeedback = chunk.PromptFeedback
+	}
+	if len(chunk.Candidates) == 0 {
+		return
+	}
+
+	c := &chunk.Candidates[0]
+	if c.FinishReason != "" {
+		r.response.Candidates[0].FinishReason = c.FinishReason
+	}
+	for i := range c.Content.Parts {
+		p := c.Content.Parts[i]
+		addPart(r.builder, &p)
+		r.response.Candidates[0].Content.Parts = append(r.response.Candidates[0].Content.Parts, p)
+	}
+}
+
+// start creates the builder and queues response.created on the first chunk.
+func (r *geminiStreamReader) start(chunk *streamChunk) {
+	if r.builder != nil {
+		return
+	}
+
+	r.response.ResponseID = chunk.ResponseID
+	if r.response.ResponseID == "" {
+		r.response.ResponseID = newResponseID()
+	}
+	if chunk.ModelVersion != "" {
+		r.model = chunk.ModelVersion
+	}
+
+	r.builder = output.NewBuilder(r.response.ResponseID, true)
+	r.builder.Emit(&aisdk.StreamEvent{Type: aisdk.EventResponseCreated, Response: &aisdk.Response{
+		ID:      r.response.ResponseID,
+		Object:  "response",
+		Model:   r.model,
+		Created: time.Now().Unix(),
+		Output:  []aisdk.OutputItem{},
+	}})
+}
+
+// finish completes the output and queues response.completed (or
+// response.incomplete when output was truncated) once the stream ends.
+func (r *geminiStreamReader) finish() {
+	r.done.Store(true)
+	if r.builder == nil {
+		return
+	}
+
+	var blockReason string
+	if r.response.PromptFeedback != nil {
+		blockReason = r.response.PromptFeedback.BlockReason
+	}
+	finishReason := r.response.Candidates[0].FinishReason
+	status := finishOutput(r.builder, finishReason, blockReason)
+	resp := newResponse(&r.response, r.model, r.builder.Output(status))
+
+	eventType := aisdk.EventResponseCompleted
+	if finishReason == finishMaxTokens {
+		eventType = aisdk.EventResponseIncomplete
+	}
+	usage := resp.Usage
+	r.builder.Emit(&aisdk.StreamEvent{Type: eventType, Response: resp, Usage: &usage})
+
+	if r.onComplete != nil {
+		r.onComplete(&r.response)
+	}
+}
@@ -0,0 +1,189 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// readStream returns the events of an alt=sse body made of chunks, and the
+// response passed to onComplete (nil if it was not called).
+func readStream(t *testing.T, chunks ...string) ([]*aisdk.StreamEvent, *generateContentResponse) {
+	t.Helper()
+
+	var body strings.Builder
+	for _, chunk := range chunks {
+		body.WriteString("data: " + chunk + "\r\n\r\n")
+	}
+
+	var completed *generateContentResponse
+	reader := newStreamReader(context.Background(), io.NopCloser(strings.NewReader(body.String())), "gemini-2.5-flash", func(r *generateContentResponse) {
+		completed = r
+	})
+	defer reader.Close()
+
+	var events []*aisdk.StreamEvent
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return events, completed
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		events = append(events, event)
+	}
+}
+
+func eventTypes(events []*aisdk.StreamEvent) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func TestStreamReaderCompletesWhenTheStreamEnds(t *testing.T) {
+	// Gemini sends no terminal event: the last chunk only carries the
+	// finish reason and cumulative usage
+	events, completed := readStream(t,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"Comparing routes","thought":true}]}}],"modelVersion":"gemini-2.5-flash-001"}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"Take the A1"}]}}],"modelVersion":"gemini-2.5-flash-001"}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"."}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":10,"candidatesTokenCount":4,"thoughtsTokenCount":20,"totalTokenCount":34},"modelVersion":"gemini-2.5-flash-001"}`,
+	)
+
+	want := []string{
+		aisdk.EventResponseCreated,
+		aisdk.EventOutputItemAdded,
+		aisdk.EventReasoningSummaryTextDelta,
+		aisdk.EventReasoningSummaryTextDone,
+		aisdk.EventOutputItemDone,
+		aisdk.EventOutputItemAdded,
+		aisdk.EventOutputTextDelta,
+		aisdk.EventOutputTextDelta,
+		aisdk.EventOutputTextDone,
+		aisdk.EventOutputItemDone,
+		aisdk.EventResponseCompleted,
+	}
+	if got := eventTypes(events); !reflect.DeepEqual(got, want) {
+		t.Fatalf("event types = %v, want %v", got, want)
+	}
+
+	created, last := events[0].Response, events[len(events)-1]
+	if !strings.HasPrefix(created.ID, "resp_") || last.Response.ID != created.ID {
+		t.Errorf("response IDs = %q and %q, want one generated resp_ ID", created.ID, last.Response.ID)
+	}
+	if last.Response.Model != "gemini-2.5-flash-001" {
+		t.Errorf("Model = %q, want the modelVersion", last.Response.Model)
+	}
+	if text := last.Response.OutputText(); text != "Take the A1." {
+		t.Errorf("OutputText() = %q, want %q", text, "Take the A1.")
+	}
+	wantUsage := aisdk.TokenUsage{PromptTokens: 10, CompletionTokens: 24, TotalTokens: 34}
+	if last.Response.Usage != wantUsage || last.Usage == nil || *last.Usage != wantUsage {
+		t.Errorf("Usage = %+v (event %+v), want %+v with thinking tokens as completion tokens", last.Response.Usage, last.Usage, wantUsage)
+	}
+
+	if completed == nil {
+		t.Fatal("onComplete not called")
+	}
+	if completed.ResponseID != created.ID || len(completed.Candidates[0].Content.Parts) != 3 {
+		t.Errorf("onComplete response = %+v, want the 3 parts under the generated ID", completed)
+	}
+}
+
+func TestStreamReaderAssignsFunctionCallIDs(t *testing.T) {
+	events, completed := readStream(t,
+		`{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"functionCall":{"name":"get_weather","args":{"city":"Paris"}}},{"functionCall":{"name":"get_time"}}]},"finishReason":"STOP"}]}`,
+	)
+
+	calls := events[len(events)-1].Response.ToolCalls()
+	if len(calls) != 2 || calls[0].CallID == "" || calls[0].CallID == calls[1].CallID {
+		t.Fatalf("ToolCalls() = %+v, want two calls with distinct generated IDs", calls)
+	}
+	if calls[0].Arguments != `{"city":"Paris"}` || calls[1].Arguments != "{}" {
+		t.Errorf("Arguments = %q and %q, want the args and {}", calls[0].Arguments, calls[1].Arguments)
+	}
+
+	// The stored turn carries the same IDs, so function outputs can answer it
+	parts := completed.Candidates[0].Content.Parts
+	for i, call := range calls {
+		if parts[i].FunctionCall.ID != call.CallID {
+			t.Errorf("stored call %d ID = %q, want %q", i, parts[i].FunctionCall.ID, call.CallID)
+		}
+	}
+}
+
+func TestStreamReaderBlockedOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		chunks      []string
+		wantRefusal string
+		wantType    string
+	}{
+		{
+			name:        "safety finish reason",
+			chunks:      []string{`{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"text":"Sure, "}]},"finishReason":"SAFETY"}]}`},
+			wantRefusal: "Response blocked by Gemini: SAFETY",
+			wantType:    aisdk.EventResponseCompleted,
+		},
+		{
+			name:        "blocked prompt",
+			chunks:      []string{`{"responseId":"r1","promptFeedback":{"blockReason":"PROHIBITED_CONTENT"}}`},
+			wantRefusal: "Response blocked by Gemini: PROHIBITED_CONTENT",
+			wantType:    aisdk.EventResponseCompleted,
+		},
+		{
+			name:     "max tokens",
+			chunks:   []string{`{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"text":"The A1 is"}]},"finishReason":"MAX_TOKENS"}]}`},
+			wantType: aisdk.EventResponseIncomplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, _ := readStream(t, tt.chunks...)
+
+			last := events[len(events)-1]
+			if last.Type != tt.wantType {
+				t.Fatalf("last event = %s, want %s", last.Type, tt.wantType)
+			}
+			if refusal := last.Response.Refusal(); refusal != tt.wantRefusal {
+				t.Errorf("Refusal() = %q, want %q", refusal, tt.wantRefusal)
+			}
+		})
+	}
+}
+
+func TestStreamReaderErrorChunk(t *testing.T) {
+	events, completed := readStream(t,
+		`{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"text":"Take"}]}}]}`,
+		`{"error":{"code":503,"message":"The model is overloaded.","status":"UNAVAILABLE"}}`,
+		`{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"text":" the A1"}]}}]}`,
+	)
+
+	last := events[len(events)-1]
+	if last.Type != aisdk.EventError || last.Error == nil || last.Error.Code != "UNAVAILABLE" || last.Error.Message != "The model is overloaded." {
+		t.Fatalf("last event = %+v, want an UNAVAILABLE error", last)
+	}
+	for _, event := range events {
+		if event.Type == aisdk.EventResponseCompleted || event.Delta == " the A1" {
+			t.Errorf("unexpected event after the error: %+v", event)
+		}
+	}
+	if completed != nil {
+		t.Error("onComplete called after an error")
+	}
+}
+
+func TestStreamReaderEmptyStream(t *testing.T) {
+	events, completed := readStream(t)
+	if len(events) != 0 || completed != nil {
+		t.Errorf("empty stream = %d events, onComplete %v, want none", len(events), completed)
+	}
+}
@@ -0,0 +1,491 @@
+package gemini
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/output"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// Gemini content roles
+const (
+	roleUser  = "user"
+	roleModel = "model"
+)
+
+// Finish reasons that change how the response is reported.
+// Reference: Gemini API (GenerateContentResponse.Candidate.FinishReason)
+const (
+	finishMaxTokens = "MAX_TOKENS"
+)
+
+// blockedFinishReasons are finish reasons meaning the output was withheld;
+// they are reported as refusals
+var blockedFinishReasons = map[string]bool{
+	"SAFETY":             true,
+	"RECITATION":         true,
+	"BLOCKLIST":          true,
+	"PROHIBITED_CONTENT": true,
+	"SPII":               true,
+	"IMAGE_SAFETY":       true,
+}
+
+// thinkingBudgets maps ReasoningConfig.Effort to thinking token budgets
+var thinkingBudgets = map[string]int{
+	"low":    1024,
+	"medium": 8192,
+	"high":   24576,
+}
+
+// generateContentRequest represents the Gemini wire format for requests.
+// Maps from aisdk.CreateResponseRequest to generateContent.
+type generateContentRequest struct {
+	Contents          []content         `json:"contents"`
+	SystemInstruction *content          `json:"systemInstruction,omitempty"`
+	GenerationConfig  *generationConfig `json:"generationConfig,omitempty"`
+	Tools             []tool            `json:"tools,omitempty"`
+	ToolConfig        *toolConfig       `json:"toolConfig,omitempty"`
+}
+
+// content represents a user or model turn
+type content struct {
+	Role  string `json:"role,omitempty"`
+	Parts []part `json:"parts"`
+}
+
+// part represents a content part in requests and responses.
+// Exactly one of Text, InlineData, FileData, FunctionCall or
+// FunctionResponse is set (a thought signature may accompany any of them).
+type part struct {
+	Text             string            `json:"text,omitempty"`
+	Thought          bool              `json:"thought,omitempty"`
+	ThoughtSignature string            `json:"thoughtSignature,omitempty"`
+	InlineData       *blob             `json:"inlineData,omitempty"`
+	FileData         *fileData         `json:"fileData,omitempty"`
+	FunctionCall     *functionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *functionResponse `json:"functionResponse,omitempty"`
+}
+
+// blob represents inline base64 data
+type blob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+// fileData references a file by URI (Files API or URL)
+type fileData struct {
+	MimeType string `json:"mimeType,omitempty"`
+	FileURI  string `json:"fileUri"`
+}
+
+// functionCall represents a function call predicted by the model
+type functionCall struct {
+	ID   string          `json:"id,omitempty"`
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// functionResponse returns a function result to the model
+type functionResponse struct {
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name"`
+	Response json.RawMessage `json:"response"`
+}
+
+// generationConfig represents Gemini generation options
+type generationConfig struct {
+	Temperature        *float64               `json:"temperature,omitempty"`
+	MaxOutputTokens    *int                   `json:"maxOutputTokens,omitempty"`
+	ResponseMimeType   string                 `json:"responseMimeType,omitempty"`
+	ResponseJSONSchema map[string]interface{} `json:"responseJsonSchema,omitempty"`
+	ThinkingConfig     *thinkingConfig        `json:"thinkingConfig,omitempty"`
+}
+
+// thinkingConfig represents the thinking budget for thinking models
+type thinkingConfig struct {
+	ThinkingBudget  int  `json:"thinkingBudget"`
+	IncludeThoughts bool `json:"includeThoughts,omitempty"`
+}
+
+// tool represents a group of function declarations
+type tool struct {
+	FunctionDeclarations []functionDeclaration `json:"functionDeclarations"`
+}
+
+// functionDeclaration represents a tool definition in Gemini format.
+// Parameters are sent as plain JSON Schema (parametersJsonSchema) so that
+// the same schemas work unchanged across providers.
+type functionDeclaration struct {
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description,omitempty"`
+	ParametersJSONSchema map[string]interface{} `json:"parametersJsonSchema,omitempty"`
+}
+
+// toolConfig represents the Gemini function calling configuration
+type toolConfig struct {
+	FunctionCallingConfig functionCallingConfig `json:"functionCallingConfig"`
+}
+
+// functionCallingConfig controls function calling ("AUTO", "ANY" or "NONE")
+type functionCallingConfig struct {
+	Mode                 string   `json:"mode"`
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+}
+
+// generateContentResponse represents the Gemini wire format for responses
+// and for each chunk of a streamed response
+type generateContentResponse struct {
+	Candidates     []candidate     `json:"candidates"`
+	PromptFeedback *promptFeedback `json:"promptFeedback,omitempty"`
+	UsageMetadata  usageMetadata   `json:"usageMetadata"`
+	ModelVersion   string          `json:"modelVersion,omitempty"`
+	ResponseID     string          `json:"responseId,omitempty"`
+}
+
+// candidate represents one generated candidate (only the first is used)
+type candidate struct {
+	Content      content `json:"content"`
+	FinishReason string  `json:"finishReason,omitempty"`
+	Index        int     `json:"index"`
+}
+
+// promptFeedback reports whether the prompt itself was blocked
+type promptFeedback struct {
+	BlockReason string `json:"blockReason,omitempty"`
+}
+
+// usageMetadata represents token usage in Gemini format
+type usageMetadata struct {
+	PromptTokenCount        int `json:"promptTokenCount"`
+	CandidatesTokenCount    int `json:"candidatesTokenCount"`
+	ThoughtsTokenCount      int `json:"thoughtsTokenCount"`
+	CachedContentTokenCount int `json:"cachedContentTokenCount"`
+	TotalTokenCount         int `json:"totalTokenCount"`
+}
+
+// toGeminiRequest converts aisdk.CreateResponseRequest to generateContentRequest.
+// history holds the prior turns when PreviousResponseID is set.
+func toGeminiRequest(req *aisdk.CreateResponseRequest, history []content) (*generateContentRequest, error) {
+	system, contents, err := toGeminiContents(req.Input, history)
+	if err != nil {
+		return nil, err
+	}
+	if req.Instructions != "" {
+		system = append([]string{req.Instructions}, system...)
+	}
+
+	gReq := &generateContentRequest{
+		Contents: mergeTurns(append(append([]content{}, history...), contents...)),
+	}
+	if len(system) > 0 {
+		gReq.SystemInstruction = &content{Parts: []part{{Text: strings.Join(system, "\n\n")}}}
+	}
+
+	config := &generationConfig{
+		Temperature:     req.Temperature,
+		MaxOutputTokens: req.MaxTokens,
+	}
+
+	// Convert TextFormat to a JSON response schema
+	if req.TextFormat != nil && req.TextFormat.Type == "json_schema" {
+		config.ResponseMimeType = "application/json"
+		config.ResponseJSONSchema = req.TextFormat.Schema
+	}
+
+	// Convert Reasoning to a thinking budget
+	if req.Reasoning != nil {
+		config.ThinkingConfig = &thinkingConfig{
+			ThinkingBudget:  thinkingBudgets[req.Reasoning.Effort],
+			IncludeThoughts: true,
+		}
+	}
+
+	if config.Temperature != nil || config.MaxOutputTokens != nil || config.ResponseMimeType != "" || config.ThinkingConfig != nil {
+		gReq.GenerationConfig = config
+	}
+
+	// Convert Tools if present
+	if len(req.Tools) > 0 {
+		declarations := make([]functionDeclaration, len(req.Tools))
+		for i, t := range req.Tools {
+			declarations[i] = functionDeclaration{
+				Name:                 t.Name,
+				Description:          t.Description,
+				ParametersJSONSchema: t.Parameters,
+			}
+		}
+		gReq.Tools = []tool{{FunctionDeclarations: declarations}}
+	}
+
+	// Convert ToolChoice if present (Gemini has no parallel_tool_calls switch)
+	if req.ToolChoice != nil {
+		switch req.ToolChoice.Mode {
+		case aisdk.ToolChoiceAuto:
+			gReq.ToolConfig = &toolConfig{FunctionCallingConfig: functionCallingConfig{Mode: "AUTO"}}
+		case aisdk.ToolChoiceNone:
+			gReq.ToolConfig = &toolConfig{FunctionCallingConfig: functionCallingConfig{Mode: "NONE"}}
+		case aisdk.ToolChoiceRequired:
+			gReq.ToolConfig = &toolConfig{FunctionCallingConfig: functionCallingConfig{Mode: "ANY"}}
+		case aisdk.ToolChoiceFunction:
+			gReq.ToolConfig = &toolConfig{FunctionCallingConfig: functionCallingConfig{
+				Mode:                 "ANY",
+				AllowedFunctionNames: []string{req.ToolChoice.Name},
+			}}
+		}
+	}
+
+	return gReq, nil
+}
+
+// toGeminiContents converts aisdk input (string, Message, []Message or
+// []InputItem) to system instruction parts and user/model contents.
+// Function names for function_call_output items are resolved from the
+// calls in the input or the chained history.
+func toGeminiContents(input interface{}, history []content) ([]string, []content, error) {
+	items, ok := aisdk.InputItems(input)
+	if !ok {
+		// Plain string prompt
+		text, _ := input.(string)
+		return nil, []content{{Role: roleUser, Parts: []part{{Text: text}}}}, nil
+	}
+
+	// Map call IDs to function names
+	callNames := make(map[string]string)
+	for _, c := range history {
+		for _, p := range c.Parts {
+			if p.FunctionCall != nil && p.FunctionCall.ID != "" {
+				callNames[p.FunctionCall.ID] = p.FunctionCall.Name
+			}
+		}
+	}
+	for _, item := range items {
+		if call, ok := item.(aisdk.FunctionCall); ok {
+			callNames[call.CallID] = call.Name
+		}
+	}
+
+	var system []string
+	var contents []content
+	for i, item := range items {
+		switch v := item.(type) {
+		case aisdk.Message:
+			if v.Role == aisdk.RoleSystem || v.Role == aisdk.RoleDeveloper {
+				for _, p := range v.Content {
+					system = append(system, p.Text)
+				}
+				continue
+			}
+			parts, err := toParts(v.Content)
+			if err != nil {
+				return nil, nil, fmt.Errorf("input[%d]: %w", i, err)
+			}
+			role := roleUser
+			if v.Role == aisdk.RoleAssistant {
+				role = roleModel
+			}
+			contents = append(contents, content{Role: role, Parts: parts})
+		case aisdk.FunctionCall:
+			arguments := v.Arguments
+			if arguments == "" {
+				arguments = "{}"
+			}
+			contents = append(contents, content{Role: roleModel, Parts: []part{{
+				FunctionCall: &functionCall{ID: v.CallID, Name: v.Name, Args: json.RawMessage(arguments)},
+			}}})
+		case aisdk.FunctionCallOutput:
+			name, ok := callNames[v.CallID]
+			if !ok {
+				return nil, nil, fmt.Errorf("input[%d]: %w (call_id %q)", i, ErrUnknownCallID, v.CallID)
+			}
+			contents = append(contents, content{Role: roleUser, Parts: []part{{
+				FunctionResponse: &functionResponse{ID: v.CallID, Name: name, Response: toFunctionResponse(v.Output)},
+			}}})
+		}
+	}
+	return system, contents, nil
+}
+
+// toFunctionResponse converts a tool output to the response object Gemini
+// expects. JSON objects are sent as-is; anything else is wrapped as
+// {"result": ...}.
+func toFunctionResponse(toolOutput string) json.RawMessage {
+	trimmed := strings.TrimSpace(toolOutput)
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+
+	var result interface{} = toolOutput
+	if json.Valid([]byte(trimmed)) && trimmed != "" {
+		result = json.RawMessage(trimmed)
+	}
+	wrapped, _ := json.Marshal(map[string]interface{}{"result": result})
+	return wrapped
+}
+
+// toParts converts message content parts to Gemini parts.
+func toParts(contentParts []aisdk.InputContent) ([]part, error) {
+	parts := make([]part, 0, len(contentParts))
+	for i, c := range contentParts {
+		switch c.Type {
+		case aisdk.ContentTypeInputText, aisdk.ContentTypeOutputText:
+			parts = append(parts, part{Text: c.Text})
+		case aisdk.ContentTypeRefusal:
+			parts = append(parts, part{Text: c.Refusal})
+		case aisdk.ContentTypeInputImage:
+			parts = append(parts, toMediaPart(c.ImageURL, c.FileID))
+		case aisdk.ContentTypeInputFile:
+			ref := c.FileURL
+			if c.FileData != "" {
+				ref = c.FileData
+			}
+			parts = append(parts, toMediaPart(ref, c.FileID))
+		case aisdk.ContentTypeInputAudio:
+			parts = append(parts, part{InlineData: &blob{MimeType: "audio/" + c.Audio.Format, Data: c.Audio.Data}})
+		default:
+			return nil, fmt.Errorf("content[%d]: %w: unsupported content type %q", i, aisdk.ErrInvalidContentPart, c.Type)
+		}
+	}
+	return parts, nil
+}
+
+// toMediaPart converts a URL, base64 data URL or uploaded file ID to a part.
+func toMediaPart(ref, fileID string) part {
+	if ref == "" {
+		// Files API URI or name (e.g. "files/abc123")
+		return part{FileData: &fileData{FileURI: fileID}}
+	}
+
+	// data:<media type>;base64,<data>
+	if rest, ok := strings.CutPrefix(ref, "data:"); ok {
+		meta, data, _ := strings.Cut(rest, ",")
+		mediaType, _ := strings.CutSuffix(meta, ";base64")
+		return part{InlineData: &blob{MimeType: mediaType, Data: data}}
+	}
+
+	// Infer the media type from the URL path extension when possible
+	var mediaType string
+	if u, err := url.Parse(ref); err == nil {
+		mediaType, _, _ = mime.ParseMediaType(mime.TypeByExtension(path.Ext(u.Path)))
+	}
+	return part{FileData: &fileData{MimeType: mediaType, FileURI: ref}}
+}
+
+// mergeTurns joins consecutive contents from the same role, since Gemini
+// expects user and model turns to alternate (e.g. several function
+// responses answering one model turn).
+func mergeTurns(contents []content) []content {
+	merged := make([]content, 0, len(contents))
+	for _, c := range contents {
+		if n := len(merged); n > 0 && merged[n-1].Role == c.Role {
+			last := &merged[n-1]
+			last.Parts = append(append([]part{}, last.Parts...), c.Parts...)
+			continue
+		}
+		merged = append(merged, c)
+	}
+	return merged
+}
+
+// addPart appends a response part to b. Function calls without an ID are
+// assigned one in place so the call can be answered.
+func addPart(b *output.Builder, p *part) {
+	switch {
+	case p.FunctionCall != nil:
+		arguments := string(p.FunctionCall.Args)
+		if arguments == "" {
+			arguments = "{}"
+		}
+		index := b.Call(p.FunctionCall.ID, p.FunctionCall.Name, arguments)
+		p.FunctionCall.ID = b.Item(index).CallID
+	case p.Thought:
+		b.Text(output.Reasoning, p.Text)
+	default:
+		b.Text(output.Text, p.Text)
+	}
+}
+
+// finishOutput completes b, reporting withheld output as a refusal, and
+// returns the final item status.
+func finishOutput(b *output.Builder, finishReason, blockReason string) string {
+	reason := blockReason
+	if reason == "" && blockedFinishReasons[finishReason] {
+		reason = finishReason
+	}
+	if reason != "" {
+		b.Text(output.Refusal, "Response blocked by Gemini: "+reason)
+	}
+	b.CloseAll()
+
+	if finishReason == finishMaxTokens {
+		return "incomplete"
+	}
+	return "completed"
+}
+
+// toAISDKResponse converts generateContentResponse to aisdk.Response.
+// Missing response and function call IDs are assigned in resp itself.
+func toAISDKResponse(resp *generateContentResponse, model string) *aisdk.Response {
+	if resp.ResponseID == "" {
+		resp.ResponseID = newResponseID()
+	}
+
+	b := output.NewBuilder(resp.ResponseID, false)
+	var finishReason, blockReason string
+	if len(resp.Candidates) > 0 {
+		c := &resp.Candidates[0]
+		for i := range c.Content.Parts {
+			addPart(b, &c.Content.Parts[i])
+		}
+		finishReason = c.FinishReason
+	}
+	if resp.PromptFeedback != nil {
+		blockReason = resp.PromptFeedback.BlockReason
+	}
+
+	return newResponse(resp, model, b.Output(finishOutput(b, finishReason, blockReason)))
+}
+
+// newResponse builds the aisdk.Response for resp with the given output.
+func newResponse(resp *generateContentResponse, model string, out []aisdk.OutputItem) *aisdk.Response {
+	if resp.ModelVersion != "" {
+		model = resp.ModelVersion
+	}
+	return &aisdk.Response{
+		ID:      resp.ResponseID,
+		Object:  "response",
+		Model:   model,
+		Created: time.Now().Unix(),
+		Output:  out,
+		Usage:   toTokenUsage(&resp.UsageMetadata),
+	}
+}
+
+// toTokenUsage converts Gemini usage metadata; thinking tokens count as
+// completion tokens.
+func toTokenUsage(u *usageMetadata) aisdk.TokenUsage {
+	usage := aisdk.TokenUsage{
+		PromptTokens:     u.PromptTokenCount,
+		CompletionTokens: u.CandidatesTokenCount + u.ThoughtsTokenCount,
+		TotalTokens:      u.TotalTokenCount,
+	}
+	if usage.TotalTokens == 0 {
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	}
+	return usage
+}
+
+// newResponseID generates a response ID for responses that carry none, so
+// they can still be chained with PreviousResponseID.
+func newResponseID() string {
+	var b [12]byte
+	rand.Read(b[:])
+	return "resp_" + hex.EncodeToString(b[:])
+}
@@ -0,0 +1,221 @@
+package gemini
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestToGeminiRequest(t *testing.T) {
+	maxTokens := 100
+	temperature := 0.5
+	schema := map[string]interface{}{"type": "object", "properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}}}
+
+	tests := []struct {
+		name    string
+		req     *aisdk.CreateResponseRequest
+		history []content
+		want    string
+		wantErr error
+	}{
+		{
+			name: "string input",
+			req:  &aisdk.CreateResponseRequest{Model: "gemini", Input: "hi"},
+			want: `{"contents":[{"role":"user","parts":[{"text":"hi"}]}]}`,
+		},
+		{
+			name: "instructions, system messages and generation config",
+			req: &aisdk.CreateResponseRequest{
+				Model:        "gemini",
+				Instructions: "be nice",
+				Input:        []aisdk.Message{aisdk.NewSystemMessage("sys"), aisdk.NewUserMessage("hi"), aisdk.NewAssistantMessage("Hello")},
+				Temperature:  &temperature,
+				MaxTokens:    &maxTokens,
+			},
+			want: `{"contents":[{"role":"user","parts":[{"text":"hi"}]},{"role":"model","parts":[{"text":"Hello"}]}],
+				"systemInstruction":{"parts":[{"text":"be nice\n\nsys"}]},
+				"generationConfig":{"temperature":0.5,"maxOutputTokens":100}}`,
+		},
+		{
+			name: "function outputs are named after their calls",
+			req: &aisdk.CreateResponseRequest{
+				Model: "gemini",
+				Input: []aisdk.InputItem{
+					aisdk.FunctionCall{CallID: "c1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+					aisdk.FunctionCall{CallID: "c2", Name: "get_time"},
+					aisdk.NewFunctionCallOutput("c1", `{"sky":"clear"}`),
+					aisdk.NewFunctionCallOutput("c2", "noon"),
+				},
+			},
+			want: `{"contents":[
+				{"role":"model","parts":[
+					{"functionCall":{"id":"c1","name":"get_weather","args":{"city":"Paris"}}},
+					{"functionCall":{"id":"c2","name":"get_time","args":{}}}]},
+				{"role":"user","parts":[
+					{"functionResponse":{"id":"c1","name":"get_weather","response":{"sky":"clear"}}},
+					{"functionResponse":{"id":"c2","name":"get_time","response":{"result":"noon"}}}]}]}`,
+		},
+		{
+			name:    "function output answering a call in the history",
+			req:     &aisdk.CreateResponseRequest{Model: "gemini", Input: []aisdk.InputItem{aisdk.NewFunctionCallOutput("c1", "42")}},
+			history: []content{{Role: roleModel, Parts: []part{{FunctionCall: &functionCall{ID: "c1", Name: "answer", Args: json.RawMessage(`{}`)}}}}},
+			want: `{"contents":[
+				{"role":"model","parts":[{"functionCall":{"id":"c1","name":"answer","args":{}}}]},
+				{"role":"user","parts":[{"functionResponse":{"id":"c1","name":"answer","response":{"result":42}}}]}]}`,
+		},
+		{
+			name:    "function output with an unknown call ID",
+			req:     &aisdk.CreateResponseRequest{Model: "gemini", Input: []aisdk.InputItem{aisdk.NewFunctionCallOutput("missing", "x")}},
+			wantErr: ErrUnknownCallID,
+		},
+		{
+			name: "media parts",
+			req: &aisdk.CreateResponseRequest{
+				Model: "gemini",
+				Input: []aisdk.Message{{Role: aisdk.RoleUser, Content: []aisdk.InputContent{
+					aisdk.NewImageURLContent("https://example.com/cat.png", ""),
+					aisdk.NewFileDataContent("doc.pdf", "data:application/pdf;base64,JVBERi0="),
+					aisdk.NewFileIDContent("files/abc"),
+					aisdk.NewAudioContent("AAAA", "wav"),
+				}}},
+			},
+			want: `{"contents":[{"role":"user","parts":[
+				{"fileData":{"mimeType":"image/png","fileUri":"https://example.com/cat.png"}},
+				{"inlineData":{"mimeType":"application/pdf","data":"JVBERi0="}},
+				{"fileData":{"fileUri":"files/abc"}},
+				{"inlineData":{"mimeType":"audio/wav","data":"AAAA"}}]}]}`,
+		},
+		{
+			name: "tools with a forced function",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "gemini",
+				Input:      "hi",
+				Tools:      []aisdk.Tool{aisdk.NewFunctionTool("get_weather", "Weather", schema)},
+				ToolChoice: &aisdk.ToolChoice{Mode: aisdk.ToolChoiceFunction, Name: "get_weather"},
+			},
+			want: `{"contents":[{"role":"user","parts":[{"text":"hi"}]}],
+				"tools":[{"functionDeclarations":[{"name":"get_weather","description":"Weather",
+					"parametersJsonSchema":{"type":"object","properties":{"name":{"type":"string"}}}}]}],
+				"toolConfig":{"functionCallingConfig":{"mode":"ANY","allowedFunctionNames":["get_weather"]}}}`,
+		},
+		{
+			name: "text format and reasoning",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "gemini",
+				Input:      "hi",
+				TextFormat: &aisdk.TextFormat{Type: "json_schema", Name: "person", Schema: schema},
+				Reasoning:  &aisdk.ReasoningConfig{Effort: "low"},
+			},
+			want: `{"contents":[{"role":"user","parts":[{"text":"hi"}]}],
+				"generationConfig":{"responseMimeType":"application/json",
+					"responseJsonSchema":{"type":"object","properties":{"name":{"type":"string"}}},
+					"thinkingConfig":{"thinkingBudget":1024,"includeThoughts":true}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toGeminiRequest(tt.req, tt.history)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("toGeminiRequest() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toGeminiRequest() error = %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestToAISDKResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       string
+		wantText   string
+		wantCalls  []aisdk.FunctionCall
+		wantStatus string
+		wantUsage  aisdk.TokenUsage
+	}{
+		{
+			name: "text after thoughts",
+			resp: `{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"text":"hmm","thought":true},{"text":"Hi"}]},"finishReason":"STOP"}],
+				"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":4,"thoughtsTokenCount":2,"totalTokenCount":9}}`,
+			wantText:   "Hi",
+			wantStatus: "completed",
+			wantUsage:  aisdk.TokenUsage{PromptTokens: 3, CompletionTokens: 6, TotalTokens: 9},
+		},
+		{
+			name:       "function call",
+			resp:       `{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"functionCall":{"id":"c1","name":"get_weather","args":{"city":"Paris"}}}]},"finishReason":"STOP"}]}`,
+			wantCalls:  []aisdk.FunctionCall{{ID: "c1", CallID: "c1", Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+			wantStatus: "completed",
+		},
+		{
+			name:       "max tokens is incomplete",
+			resp:       `{"responseId":"r1","candidates":[{"content":{"role":"model","parts":[{"text":"Hi"}]},"finishReason":"MAX_TOKENS"}],"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":1}}`,
+			wantText:   "Hi",
+			wantStatus: "incomplete",
+			wantUsage:  aisdk.TokenUsage{PromptTokens: 3, CompletionTokens: 1, TotalTokens: 4},
+		},
+		{
+			name:       "blocked prompt is a refusal",
+			resp:       `{"responseId":"r1","promptFeedback":{"blockReason":"SAFETY"}}`,
+			wantStatus: "completed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gResp generateContentResponse
+			if err := json.Unmarshal([]byte(tt.resp), &gResp); err != nil {
+				t.Fatal(err)
+			}
+
+			got := toAISDKResponse(&gResp, "gemini")
+			if got.ID != "r1" {
+				t.Errorf("ID = %q, want r1", got.ID)
+			}
+			if text := got.OutputText(); text != tt.wantText {
+				t.Errorf("OutputText() = %q, want %q", text, tt.wantText)
+			}
+			if calls := got.ToolCalls(); !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("ToolCalls() = %+v, want %+v", calls, tt.wantCalls)
+			}
+			if len(got.Output) == 0 {
+				t.Fatal("Output is empty")
+			}
+			if status := got.Output[len(got.Output)-1].Status; status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", status, tt.wantStatus)
+			}
+			if got.Usage != tt.wantUsage {
+				t.Errorf("Usage = %+v, want %+v", got.Usage, tt.wantUsage)
+			}
+		})
+	}
+}
+
+// assertJSON fails t unless v encodes to the same JSON as want.
+func assertJSON(t *testing.T, v interface{}, want string) {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got, expected interface{}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got  %s\nwant %s", raw, want)
+	}
+}
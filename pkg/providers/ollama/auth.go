@@ -0,0 +1,13 @@
+package ollama
+
+import (
+	"net/http"
+)
+
+// addAuthHeaders adds the bearer token to the request when one is configured.
+// Reference: FR-004 (authentication)
+func addAuthHeaders(req *http.Request, apiKey string) {
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
@@ -0,0 +1,203 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/amannhq/go-ai-sdk/internal/history"
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// Client implements the Provider interface for Ollama's /api/chat.
+// Reference: architecture.md (Provider Interface Pattern)
+type Client struct {
+	config    *Config
+	transport *transport.Transport
+	history   *history.History[chatMessage]
+}
+
+// New creates a new Ollama client with the given configuration.
+// Options are applied to a copy of config (DefaultConfig when nil).
+func New(config *Config, opts ...aisdk.Option) (*Client, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	configured := *config
+	config = &configured
+	overrides := aisdk.NewOptions(opts...).ClientConfig()
+	config.override(overrides)
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := overrides.Validate(); err != nil {
+		return nil, err
+	}
+	settings := config.settings()
+	settings.Apply(overrides)
+
+	return &Client{
+		config:    config,
+		transport: transport.New("ollama", settings, mapOllamaError),
+		history:   history.New[chatMessage](config.HistorySize),
+	}, nil
+}
+
+// NewFromEnv creates a new Ollama client loading configuration from environment.
+func NewFromEnv(opts ...aisdk.Option) (*Client, error) {
+	config, err := NewConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return New(config, opts...)
+}
+
+// Configure implements aisdk.ConfigurableProvider. The copy shares the
+// conversation history of c.
+func (c *Client) Configure(config *aisdk.ClientConfig) aisdk.Provider {
+	configured := *c.config
+	configured.override(config)
+	return &Client{
+		config:    &configured,
+		transport: c.transport.Configure(config),
+		history:   c.history,
+	}
+}
+
+// CreateResponse implements Provider.CreateResponse for Ollama.
+// POSTs to /api/chat with stream: false.
+func (c *Client) CreateResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (*aisdk.Response, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, aisdk.WrapError(err, "ollama.CreateResponse")
+	}
+
+	// Convert to Ollama format
+	oReq, err := c.toRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "ollama.CreateResponse")
+	}
+	oReq.Stream = false
+
+	// Every attempt is traced under the same correlation ID
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	httpResp, err := c.send(ctx, req, oReq, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	// Parse response
+	var oResp chatResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&oResp); err != nil {
+		return nil, c.transport.Fail(ctx, http.MethodPost, httpResp.Request.URL.String(), aisdk.WrapError(err, "decode response"))
+	}
+
+	// Convert to SDK format (assigns response and call IDs)
+	b := newReplyBuilder(false)
+	b.add(&oResp.Message)
+	resp := b.response(&oResp)
+	c.remember(oReq, resp.ID, b.message)
+
+	return resp, nil
+}
+
+// StreamResponse implements Provider.StreamResponse for Ollama.
+// POSTs to /api/chat with stream: true and returns a StreamReader translating
+// the NDJSON chunks into response.* events. The caller must Close the reader
+// to release the connection.
+func (c *Client) StreamResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (aisdk.StreamReader, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, aisdk.WrapError(err, "ollama.StreamResponse")
+	}
+
+	// Convert to Ollama format with streaming enabled
+	oReq, err := c.toRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "ollama.StreamResponse")
+	}
+	oReq.Stream = true
+
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	// Open the stream (retrying connection setup); the body stays open
+	// until the reader is closed
+	httpResp, err := c.send(ctx, req, oReq, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStreamReader(ctx, httpResp.Body, func(responseID string, reply chatMessage) {
+		c.remember(oReq, responseID, reply)
+	}), nil
+}
+
+// toRequest converts req, replaying the conversation named by PreviousResponseID.
+func (c *Client) toRequest(req *aisdk.CreateResponseRequest) (*chatRequest, error) {
+	var prior []chatMessage
+	if req.PreviousResponseID != "" {
+		var ok bool
+		prior, ok = c.history.Get(req.PreviousResponseID)
+		if !ok {
+			return nil, ErrUnknownPreviousResponse
+		}
+	}
+
+	oReq, err := toOllamaRequest(req, prior)
+	if err != nil {
+		return nil, err
+	}
+	oReq.KeepAlive = c.config.KeepAlive
+	return oReq, nil
+}
+
+// remember records the conversation so later requests can chain on it.
+// System messages are not carried over, matching Instructions semantics.
+func (c *Client) remember(oReq *chatRequest, responseID string, reply chatMessage) {
+	conversation := make([]chatMessage, 0, len(oReq.Messages)+1)
+	for _, m := range oReq.Messages {
+		if m.Role != roleSystem {
+			conversation = append(conversation, m)
+		}
+	}
+	c.history.Put(responseID, append(conversation, reply))
+}
+
+// send POSTs oReq to /api/chat through the shared transport.
+// Streaming requests are sent without the overall client timeout.
+func (c *Client) send(ctx context.Context, req *aisdk.CreateResponseRequest, oReq *chatRequest, correlationID string) (*http.Response, error) {
+	// Marshal request
+	body, err := json.Marshal(oReq)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "marshal request")
+	}
+
+	// Create HTTP request
+	url := c.config.BaseURL + "/api/chat"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, aisdk.WrapError(err, "create http request")
+	}
+
+	// Add headers
+	addAuthHeaders(httpReq, c.config.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	if oReq.Stream {
+		httpReq.Header.Set("Accept", "application/x-ndjson")
+	}
+
+	return c.transport.Send(ctx, &transport.Request{
+		HTTP:          httpReq,
+		Stream:        oReq.Stream,
+		CorrelationID: correlationID,
+		APIKey:        c.config.APIKey,
+		Authorize:     addAuthHeaders,
+		Model:         req.Model,
+		Tokens:        transport.EstimateTokens(req, body),
+	})
+}
@@ -0,0 +1,137 @@
+package ollama
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// Config holds the configuration for the Ollama provider.
+// Reference: data-model.md Entity #1 (ClientConfig)
+type Config struct {
+	// BaseURL is the Ollama server URL (default: http://localhost:11434)
+	BaseURL string
+
+	// APIKey is sent as a bearer token when set (optional; a local Ollama
+	// server needs none, authenticating proxies and hosted Ollama do)
+	APIKey string
+
+	// Credentials supplies the API key per request instead of APIKey, for
+	// rotated keys and short-lived tokens (optional)
+	Credentials aisdk.CredentialProvider
+
+	// Timeout is the HTTP request timeout (default: 5m, since local models
+	// may be loaded on the first request)
+	Timeout time.Duration
+
+	// MaxRetries is the maximum retry attempts for transient failures
+	// (default: 3; ignored when Retry is set)
+	MaxRetries int
+
+	// KeepAlive controls how long the model stays loaded after a request,
+	// as an Ollama duration such as "5m" or "-1" (optional, server default)
+	KeepAlive string
+
+	// HistorySize is the number of conversations remembered for
+	// PreviousResponseID chaining; /api/chat is stateless, so the client
+	// replays prior turns itself (default: 1000, 0 disables chaining)
+	HistorySize int
+
+	// Logger receives structured retry and error events (optional)
+	Logger aisdk.Logger
+
+	// TelemetryHooks are called for every HTTP attempt (optional)
+	TelemetryHooks *middleware.TelemetryHooks
+
+	// RateLimiter paces every HTTP attempt per model (optional)
+	RateLimiter *middleware.RateLimiter
+
+	// Retry sets the retry policy, including its MaxRetries (optional,
+	// default: middleware.DefaultRetryConfig)
+	Retry *middleware.RetryConfig
+
+	// HTTPClient replaces the default pooled http.Client (optional);
+	// Timeout applies when the client sets none
+	HTTPClient *http.Client
+
+	// Transport configures the HTTP transport: a custom RoundTripper, proxy,
+	// TLS (custom CA pool, client certificates) and RoundTripper middleware
+	// (optional)
+	Transport *aisdk.TransportConfig
+
+	// Headers are sent with every request, replacing headers of the same
+	// name set by the provider (optional)
+	Headers http.Header
+}
+
+// DefaultConfig returns a Config with default values
+func DefaultConfig() *Config {
+	return &Config{
+		BaseURL:     "http://localhost:11434",
+		Timeout:     5 * time.Minute,
+		MaxRetries:  3,
+		HistorySize: 1000,
+	}
+}
+
+// Validate checks the Config for required fields and constraints.
+// Returns descriptive error per SC-006 (actionable error messages).
+func (c *Config) Validate() error {
+	if c.BaseURL == "" {
+		return errors.New("BaseURL cannot be empty")
+	}
+
+	// Validate BaseURL is a valid URL
+	if _, err := url.Parse(c.BaseURL); err != nil {
+		return errors.New("BaseURL must be a valid URL")
+	}
+
+	if c.Timeout <= 0 {
+		return errors.New("Timeout must be positive duration")
+	}
+
+	if c.MaxRetries < 0 {
+		return errors.New("MaxRetries cannot be negative")
+	}
+
+	if c.HistorySize < 0 {
+		return errors.New("HistorySize cannot be negative")
+	}
+
+	return nil
+}
+
+// override sets the provider fields config sets; Settings.Apply sets the
+// shared ones.
+func (c *Config) override(config *aisdk.ClientConfig) {
+	if config.APIKey != "" {
+		c.APIKey = config.APIKey
+	}
+	if config.Credentials != nil {
+		c.Credentials = config.Credentials
+	}
+	if config.BaseURL != "" {
+		c.BaseURL = config.BaseURL
+	}
+}
+
+// settings returns the transport settings of the Config.
+func (c *Config) settings() transport.Settings {
+	return transport.Settings{
+		Timeout:        c.Timeout,
+		MaxRetries:     c.MaxRetries,
+		Retry:          c.Retry,
+		HTTPClient:     c.HTTPClient,
+		Transport:      c.Transport,
+		Headers:        c.Headers,
+		Logger:         c.Logger,
+		TelemetryHooks: c.TelemetryHooks,
+		RateLimiter:    c.RateLimiter,
+		Credentials:    c.Credentials,
+	}
+}
@@ -0,0 +1,22 @@
+package ollama
+
+import (
+	"os"
+	"strings"
+)
+
+// NewConfigFromEnv creates a Config from environment.
+// Reads OLLAMA_HOST (host:port or URL) and the optional OLLAMA_API_KEY;
+// neither is required, so the error is always nil.
+// Reference: FR-013 (environment-based configuration)
+func NewConfigFromEnv() (*Config, error) {
+	config := DefaultConfig()
+	if host := strings.TrimSuffix(os.Getenv("OLLAMA_HOST"), "/"); host != "" {
+		if !strings.Contains(host, "://") {
+			host = "http://" + host
+		}
+		config.BaseURL = host
+	}
+	config.APIKey = os.Getenv("OLLAMA_API_KEY")
+	return config, nil
+}
@@ -0,0 +1,43 @@
+package ollama
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+)
+
+var (
+	// ErrUnknownPreviousResponse indicates that PreviousResponseID does not
+	// name a response remembered by this client
+	ErrUnknownPreviousResponse = errors.New("previous response not found; Ollama is stateless, so only responses created by this client (within HistorySize) can be chained")
+
+	// ErrUnknownCallID indicates a function_call_output whose call is not in
+	// the input or the chained conversation (Ollama needs the function name)
+	ErrUnknownCallID = errors.New("function_call_output does not match any function_call in the conversation")
+
+	// ErrUnsupportedFeature indicates a request feature /api/chat cannot express
+	ErrUnsupportedFeature = errors.New("unsupported by the Ollama chat API")
+)
+
+// ollamaError represents an error response from Ollama
+type ollamaError struct {
+	Error string `json:"error"`
+}
+
+// mapOllamaError converts an HTTP error response to an *aisdk.APIError,
+// or an *aisdk.RateLimitError for 429 responses. Ollama errors carry only a
+// message; the Code is the HTTP status text. It consumes the response body.
+// Reference: FR-005 (error handling), research.md decision #6
+func mapOllamaError(resp *http.Response, correlationID string) error {
+	var apiErr ollamaError
+	body, err := io.ReadAll(resp.Body)
+	if err == nil {
+		json.Unmarshal(body, &apiErr)
+	}
+
+	// Missing details fall back to the HTTP status
+	return transport.StatusError(resp, "", apiErr.Error, correlationID)
+}
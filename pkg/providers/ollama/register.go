@@ -0,0 +1,52 @@
+package ollama
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func init() {
+	aisdk.Register("ollama", openConnection)
+}
+
+// openConnection is the aisdk.ProviderFactory for "ollama://" connections.
+// Defaults come from the environment (see NewConfigFromEnv); a plain-HTTP
+// server is addressed with base_url, e.g.
+// ollama://?base_url=http://gpu-box:11434&model=llama3.2. Options:
+// keep_alive and history_size.
+func openConnection(conn *aisdk.ConnectionConfig, opts ...aisdk.Option) (aisdk.Provider, error) {
+	config, _ := NewConfigFromEnv()
+	if conn.APIKey != "" {
+		config.APIKey = conn.APIKey
+	}
+	config.Credentials = conn.Credentials
+	if conn.BaseURL != "" {
+		config.BaseURL = conn.BaseURL
+	}
+	if conn.Timeout > 0 {
+		config.Timeout = conn.Timeout
+	}
+
+	for key, value := range conn.Options {
+		switch key {
+		case "keep_alive":
+			config.KeepAlive = value
+		case "history_size":
+			size, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: history_size: %v", aisdk.ErrInvalidConnectionString, err)
+			}
+			config.HistorySize = size
+		default:
+			return nil, fmt.Errorf("%w: unknown option %q", aisdk.ErrInvalidConnectionString, key)
+		}
+	}
+
+	client, err := New(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
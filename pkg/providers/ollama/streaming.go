@@ -0,0 +1,143 @@
+package ollama
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// maxLineSize caps the size of a single NDJSON line
+const maxLineSize = 8 * 1024 * 1024
+
+// ollamaStreamReader implements aisdk.StreamReader over an NDJSON response
+// body, translating /api/chat chunks into the Responses-style event stream.
+type ollamaStreamReader struct {
+	ctx     context.Context
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+
+	// reply assembles the output and queues events
+	reply   *replyBuilder
+	started bool
+
+	// onComplete is called with the assistant reply on the final line
+	onComplete func(responseID string, reply chatMessage)
+
+	// done is set once a terminal event has been queued, or by Close,
+	// which may run on another goroutine
+	done atomic.Bool
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newStreamReader wraps an NDJSON response body in an ollamaStreamReader.
+func newStreamReader(ctx context.Context, body io.ReadCloser, onComplete func(string, chatMessage)) *ollamaStreamReader {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &ollamaStreamReader{
+		ctx:        ctx,
+		body:       body,
+		scanner:    scanner,
+		reply:      newReplyBuilder(true),
+		onComplete: onComplete,
+	}
+}
+
+// Next returns the next event from the stream.
+// Returns io.EOF once response.completed (or another terminal event) has been
+// delivered, or when the server closes the stream.
+func (r *ollamaStreamReader) Next() (*aisdk.StreamEvent, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			r.Close()
+			return nil, err
+		}
+
+		if event := r.reply.output.Pop(); event != nil {
+			return event, nil
+		}
+
+		if r.done.Load() {
+			return nil, io.EOF
+		}
+
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				if ctxErr := r.ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				return nil, aisdk.WrapError(err, "read stream")
+			}
+			r.done.Store(true)
+			return nil, io.EOF
+		}
+
+		line := r.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk chatResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return nil, aisdk.WrapError(err, "decode stream event")
+		}
+
+		r.handle(&chunk)
+	}
+}
+
+// Close terminates the stream and releases the HTTP response body.
+// It is safe to call Close multiple times.
+func (r *ollamaStreamReader) Close() error {
+	r.closeOnce.Do(func() {
+		r.done.Store(true)
+		r.closeErr = r.body.Close()
+	})
+	return r.closeErr
+}
+
+// handle translates one NDJSON chunk and queues the resulting events.
+func (r *ollamaStreamReader) handle(chunk *chatResponse) {
+	out := r.reply.output
+
+	if chunk.Error != "" {
+		out.Emit(&aisdk.StreamEvent{Type: aisdk.EventError, Error: &aisdk.StreamError{Message: chunk.Error}})
+		r.done.Store(true)
+		return
+	}
+
+	if !r.started {
+		r.started = true
+		out.Emit(&aisdk.StreamEvent{Type: aisdk.EventResponseCreated, Response: &aisdk.Response{
+			ID:      out.ResponseID(),
+			Object:  "response",
+			Model:   chunk.Model,
+			Created: time.Now().Unix(),
+			Output:  []aisdk.OutputItem{},
+		}})
+	}
+
+	r.reply.add(&chunk.Message)
+
+	if chunk.Done {
+		resp := r.reply.response(chunk)
+		eventType := aisdk.EventResponseCompleted
+		if chunk.DoneReason == doneReasonLength {
+			eventType = aisdk.EventResponseIncomplete
+		}
+		usage := resp.Usage
+		out.Emit(&aisdk.StreamEvent{Type: eventType, Response: resp, Usage: &usage})
+		r.done.Store(true)
+		if r.onComplete != nil {
+			r.onComplete(resp.ID, r.reply.message)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// readStream reads an NDJSON /api/chat body, returning its events and the
+// reply passed to onComplete.
+func readStream(t *testing.T, body string) ([]*aisdk.StreamEvent, string, *chatMessage) {
+	t.Helper()
+
+	var completedID string
+	var completed *chatMessage
+	reader := newStreamReader(context.Background(), io.NopCloser(strings.NewReader(body)), func(id string, reply chatMessage) {
+		completedID, completed = id, &reply
+	})
+	defer reader.Close()
+
+	var events []*aisdk.StreamEvent
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return events, completedID, completed
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		events = append(events, event)
+	}
+}
+
+func TestStreamReaderThinkingAndText(t *testing.T) {
+	body := `{"model":"qwen3","created_at":"2025-06-01T10:00:00Z","message":{"role":"assistant","content":"","thinking":"The user "},"done":false}
+{"model":"qwen3","created_at":"2025-06-01T10:00:00Z","message":{"role":"assistant","content":"","thinking":"wants a greeting."},"done":false}
+
+{"model":"qwen3","created_at":"2025-06-01T10:00:01Z","message":{"role":"assistant","content":"Hello!"},"done":false}
+{"model":"qwen3","created_at":"2025-06-01T10:00:02Z","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","total_duration":812000000,"prompt_eval_count":12,"eval_count":9}
+`
+	events, completedID, completed := readStream(t, body)
+
+	var types []string
+	var thinking string
+	for _, event := range events {
+		types = append(types, event.Type)
+		if event.Type == aisdk.EventReasoningSummaryTextDelta {
+			thinking += event.Delta
+		}
+	}
+	want := []string{
+		aisdk.EventResponseCreated,
+		aisdk.EventOutputItemAdded,
+		aisdk.EventReasoningSummaryTextDelta,
+		aisdk.EventReasoningSummaryTextDelta,
+		aisdk.EventReasoningSummaryTextDone,
+		aisdk.EventOutputItemDone,
+		aisdk.EventOutputItemAdded,
+		aisdk.EventOutputTextDelta,
+		aisdk.EventOutputTextDone,
+		aisdk.EventOutputItemDone,
+		aisdk.EventResponseCompleted,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("event types = %v, want %v", types, want)
+	}
+	if thinking != "The user wants a greeting." {
+		t.Errorf("reasoning deltas = %q", thinking)
+	}
+
+	resp := events[len(events)-1].Response
+	if resp.ID != events[0].Response.ID || resp.Model != "qwen3" || resp.OutputText() != "Hello!" {
+		t.Errorf("response = %+v, want qwen3 saying Hello! under the created ID", resp)
+	}
+	if created := time.Unix(resp.Created, 0).UTC(); !created.Equal(time.Date(2025, 6, 1, 10, 0, 2, 0, time.UTC)) {
+		t.Errorf("Created = %v, want the final line's created_at", created)
+	}
+	if want := (aisdk.TokenUsage{PromptTokens: 12, CompletionTokens: 9, TotalTokens: 21}); resp.Usage != want {
+		t.Errorf("Usage = %+v, want %+v", resp.Usage, want)
+	}
+
+	// The stored turn merges the partial messages
+	if completedID != resp.ID || completed == nil || completed.Thinking != "The user wants a greeting." || completed.Content != "Hello!" {
+		t.Errorf("onComplete(%q, %+v), want the merged reply under %q", completedID, completed, resp.ID)
+	}
+}
+
+func TestStreamReaderToolCalls(t *testing.T) {
+	// Tool calls arrive whole, without IDs, usually before the final line
+	body := `{"model":"llama3.1","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"get_weather","arguments":{"city":"Paris"}}},{"function":{"name":"get_time","arguments":null}}]},"done":false}
+{"model":"llama3.1","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop"}
+`
+	events, _, completed := readStream(t, body)
+
+	var deltas []string
+	for _, event := range events {
+		if event.Type == aisdk.EventFunctionCallArgumentsDelta {
+			deltas = append(deltas, event.Delta)
+		}
+	}
+	if want := []string{`{"city":"Paris"}`, "{}"}; !reflect.DeepEqual(deltas, want) {
+		t.Errorf("argument deltas = %q, want %q", deltas, want)
+	}
+
+	calls := events[len(events)-1].Response.ToolCalls()
+	if len(calls) != 2 || calls[0].CallID == "" || calls[0].CallID == calls[1].CallID {
+		t.Fatalf("ToolCalls() = %+v, want two calls with distinct generated IDs", calls)
+	}
+	if completed == nil || len(completed.ToolCalls) != 2 {
+		t.Fatalf("onComplete reply = %+v, want both tool calls", completed)
+	}
+	for i, call := range calls {
+		if completed.ToolCalls[i].ID != call.CallID {
+			t.Errorf("stored call %d ID = %q, want %q", i, completed.ToolCalls[i].ID, call.CallID)
+		}
+	}
+	if string(completed.ToolCalls[1].Function.Arguments) != "{}" {
+		t.Errorf("stored null arguments = %s, want {}", completed.ToolCalls[1].Function.Arguments)
+	}
+}
+
+func TestStreamReaderLength(t *testing.T) {
+	body := `{"model":"llama3","message":{"role":"assistant","content":"Once upon"},"done":true,"done_reason":"length","prompt_eval_count":5,"eval_count":2}
+`
+	events, _, _ := readStream(t, body)
+
+	last := events[len(events)-1]
+	if last.Type != aisdk.EventResponseIncomplete || last.Response.Output[0].Status != "incomplete" {
+		t.Errorf("last event = %s with status %q, want response.incomplete", last.Type, last.Response.Output[0].Status)
+	}
+}
+
+func TestStreamReaderErrorLine(t *testing.T) {
+	// Ollama reports failures after the stream started as an error line
+	body := `{"model":"llama3","message":{"role":"assistant","content":"Hel"},"done":false}
+{"error":"model runner has unexpectedly stopped"}
+{"model":"llama3","message":{"role":"assistant","content":"lo"},"done":true}
+`
+	events, completedID, _ := readStream(t, body)
+
+	last := events[len(events)-1]
+	if last.Type != aisdk.EventError || last.Error.Message != "model runner has unexpectedly stopped" {
+		t.Fatalf("last event = %+v, want the error line", last)
+	}
+	if completedID != "" {
+		t.Error("onComplete called after an error")
+	}
+}
+
+func TestStreamReaderInvalidLine(t *testing.T) {
+	reader := newStreamReader(context.Background(), io.NopCloser(strings.NewReader("not json\n")), nil)
+	defer reader.Close()
+
+	if _, err := reader.Next(); err == nil || !strings.Contains(err.Error(), "decode stream event") {
+		t.Errorf("Next() error = %v, want a decode error", err)
+	}
+}
@@ -0,0 +1,304 @@
+package ollama
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/output"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// Chat message roles
+const (
+	roleSystem    = "system"
+	roleUser      = "user"
+	roleAssistant = "assistant"
+	roleTool      = "tool"
+)
+
+// doneReasonLength is the done_reason of responses cut off by num_predict
+const doneReasonLength = "length"
+
+// chatRequest represents the Ollama wire format for /api/chat requests.
+// Stream is always sent because Ollama streams by default.
+type chatRequest struct {
+	Model     string                 `json:"model"`
+	Messages  []chatMessage          `json:"messages"`
+	Stream    bool                   `json:"stream"`
+	Format    map[string]interface{} `json:"format,omitempty"`
+	Tools     []tool                 `json:"tools,omitempty"`
+	Think     bool                   `json:"think,omitempty"`
+	Options   *options               `json:"options,omitempty"`
+	KeepAlive string                 `json:"keep_alive,omitempty"`
+}
+
+// chatMessage represents a chat message in requests and responses
+type chatMessage struct {
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	Thinking  string     `json:"thinking,omitempty"`
+	Images    []string   `json:"images,omitempty"`
+	ToolCalls []toolCall `json:"tool_calls,omitempty"`
+	ToolName  string     `json:"tool_name,omitempty"`
+}
+
+// toolCall represents a tool call predicted by the model. Ollama does not
+// always assign IDs; the client assigns one so the call can be answered.
+type toolCall struct {
+	ID       string `json:"id,omitempty"`
+	Function struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	} `json:"function"`
+}
+
+// tool represents a tool definition in Ollama format
+type tool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description,omitempty"`
+		Parameters  map[string]interface{} `json:"parameters,omitempty"`
+	} `json:"function"`
+}
+
+// options represents Ollama model options
+type options struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	NumPredict  *int     `json:"num_predict,omitempty"`
+}
+
+// chatResponse represents a non-streaming response, and each NDJSON line of
+// a streamed one (where Message holds the delta and Done marks the last line)
+type chatResponse struct {
+	Model           string      `json:"model"`
+	CreatedAt       time.Time   `json:"created_at"`
+	Message         chatMessage `json:"message"`
+	Done            bool        `json:"done"`
+	DoneReason      string      `json:"done_reason,omitempty"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+	EvalCount       int         `json:"eval_count"`
+	Error           string      `json:"error,omitempty"`
+}
+
+// toOllamaRequest converts aisdk.CreateResponseRequest to chatRequest.
+// history holds the prior turns when PreviousResponseID is set.
+// Ollama has no tool_choice: "none" omits the tools and "function" offers
+// only the named tool, but a call cannot be forced.
+func toOllamaRequest(req *aisdk.CreateResponseRequest, history []chatMessage) (*chatRequest, error) {
+	messages, err := toOllamaMessages(req.Input, history)
+	if err != nil {
+		return nil, err
+	}
+
+	oReq := &chatRequest{
+		Model:    req.Model,
+		Messages: append([]chatMessage{}, history...),
+		Stream:   req.Stream,
+		Think:    req.Reasoning != nil,
+	}
+	if req.Instructions != "" {
+		oReq.Messages = append(oReq.Messages, chatMessage{Role: roleSystem, Content: req.Instructions})
+	}
+	oReq.Messages = append(oReq.Messages, messages...)
+
+	if req.Temperature != nil || req.MaxTokens != nil {
+		oReq.Options = &options{Temperature: req.Temperature, NumPredict: req.MaxTokens}
+	}
+
+	// Convert TextFormat to a format schema
+	if req.TextFormat != nil && req.TextFormat.Type == "json_schema" {
+		oReq.Format = req.TextFormat.Schema
+	}
+
+	// Convert Tools, applying ToolChoice as far as Ollama allows
+	for _, t := range req.Tools {
+		if req.ToolChoice != nil {
+			if req.ToolChoice.Mode == aisdk.ToolChoiceNone {
+				break
+			}
+			if req.ToolChoice.Mode == aisdk.ToolChoiceFunction && t.Name != req.ToolChoice.Name {
+				continue
+			}
+		}
+		var ot tool
+		ot.Type = aisdk.ToolTypeFunction
+		ot.Function.Name = t.Name
+		ot.Function.Description = t.Description
+		ot.Function.Parameters = t.Parameters
+		oReq.Tools = append(oReq.Tools, ot)
+	}
+
+	return oReq, nil
+}
+
+// toOllamaMessages converts aisdk input (string, Message, []Message or
+// []InputItem) to chat messages. Function names for function_call_output
+// items are resolved from the calls in the input or the chained history.
+func toOllamaMessages(input interface{}, history []chatMessage) ([]chatMessage, error) {
+	items, ok := aisdk.InputItems(input)
+	if !ok {
+		// Plain string prompt
+		text, _ := input.(string)
+		return []chatMessage{{Role: roleUser, Content: text}}, nil
+	}
+
+	// Map call IDs to function names
+	callNames := make(map[string]string)
+	for _, m := range history {
+		for _, call := range m.ToolCalls {
+			callNames[call.ID] = call.Function.Name
+		}
+	}
+	for _, item := range items {
+		if call, ok := item.(aisdk.FunctionCall); ok {
+			callNames[call.CallID] = call.Name
+		}
+	}
+
+	var messages []chatMessage
+	for i, item := range items {
+		switch v := item.(type) {
+		case aisdk.Message:
+			m, err := toChatMessage(v)
+			if err != nil {
+				return nil, fmt.Errorf("input[%d]: %w", i, err)
+			}
+			messages = append(messages, m)
+		case aisdk.FunctionCall:
+			arguments := v.Arguments
+			if arguments == "" {
+				arguments = "{}"
+			}
+			var call toolCall
+			call.ID = v.CallID
+			call.Function.Name = v.Name
+			call.Function.Arguments = json.RawMessage(arguments)
+
+			// Consecutive calls belong to the same assistant turn
+			if n := len(messages); n > 0 && messages[n-1].Role == roleAssistant && len(messages[n-1].ToolCalls) > 0 {
+				messages[n-1].ToolCalls = append(messages[n-1].ToolCalls, call)
+				continue
+			}
+			messages = append(messages, chatMessage{Role: roleAssistant, ToolCalls: []toolCall{call}})
+		case aisdk.FunctionCallOutput:
+			name, ok := callNames[v.CallID]
+			if !ok {
+				return nil, fmt.Errorf("input[%d]: %w (call_id %q)", i, ErrUnknownCallID, v.CallID)
+			}
+			messages = append(messages, chatMessage{Role: roleTool, Content: v.Output, ToolName: name})
+		}
+	}
+	return messages, nil
+}
+
+// toChatMessage converts a Message. Text parts are joined and images must
+// be base64 data URLs, since Ollama cannot fetch URLs or uploaded files.
+func toChatMessage(m aisdk.Message) (chatMessage, error) {
+	role := m.Role
+	if role == aisdk.RoleDeveloper {
+		role = roleSystem
+	}
+
+	msg := chatMessage{Role: role}
+	var text []string
+	for i, c := range m.Content {
+		switch c.Type {
+		case aisdk.ContentTypeInputText, aisdk.ContentTypeOutputText:
+			text = append(text, c.Text)
+		case aisdk.ContentTypeRefusal:
+			text = append(text, c.Refusal)
+		case aisdk.ContentTypeInputImage:
+			rest, ok := strings.CutPrefix(c.ImageURL, "data:")
+			_, data, found := strings.Cut(rest, ";base64,")
+			if !ok || !found {
+				return chatMessage{}, fmt.Errorf("content[%d]: %w: images must be base64 data URLs", i, ErrUnsupportedFeature)
+			}
+			msg.Images = append(msg.Images, data)
+		default:
+			return chatMessage{}, fmt.Errorf("content[%d]: %w: %s content", i, ErrUnsupportedFeature, c.Type)
+		}
+	}
+	msg.Content = strings.Join(text, "\n")
+	return msg, nil
+}
+
+// replyBuilder accumulates a reply into output items and, for history, the
+// assistant message. Streaming and non-streaming responses use the same
+// builder so item IDs and indexes match.
+type replyBuilder struct {
+	output  *output.Builder
+	message chatMessage
+}
+
+// newReplyBuilder creates a replyBuilder for a new response.
+func newReplyBuilder(stream bool) *replyBuilder {
+	return &replyBuilder{
+		output:  output.NewBuilder(newResponseID(), stream),
+		message: chatMessage{Role: roleAssistant},
+	}
+}
+
+// add appends the content of a (possibly partial) reply message. Tool calls
+// arrive whole and are assigned an ID if they have none.
+func (b *replyBuilder) add(m *chatMessage) {
+	if m.Thinking != "" {
+		b.output.Text(output.Reasoning, m.Thinking)
+		b.message.Thinking += m.Thinking
+	}
+	if m.Content != "" {
+		b.output.Text(output.Text, m.Content)
+		b.message.Content += m.Content
+	}
+	for _, call := range m.ToolCalls {
+		arguments := string(call.Function.Arguments)
+		if arguments == "" || arguments == "null" {
+			arguments = "{}"
+			call.Function.Arguments = json.RawMessage(arguments)
+		}
+		index := b.output.Call(call.ID, call.Function.Name, arguments)
+		call.ID = b.output.Item(index).CallID
+		b.message.ToolCalls = append(b.message.ToolCalls, call)
+	}
+}
+
+// response completes the output and builds the aisdk.Response from it and
+// the final line's metadata.
+func (b *replyBuilder) response(final *chatResponse) *aisdk.Response {
+	b.output.CloseAll()
+
+	status := "completed"
+	if final.DoneReason == doneReasonLength {
+		status = "incomplete"
+	}
+
+	created := time.Now().Unix()
+	if !final.CreatedAt.IsZero() {
+		created = final.CreatedAt.Unix()
+	}
+
+	return &aisdk.Response{
+		ID:      b.output.ResponseID(),
+		Object:  "response",
+		Model:   final.Model,
+		Created: created,
+		Output:  b.output.Output(status),
+		Usage: aisdk.TokenUsage{
+			PromptTokens:     final.PromptEvalCount,
+			CompletionTokens: final.EvalCount,
+			TotalTokens:      final.PromptEvalCount + final.EvalCount,
+		},
+	}
+}
+
+// newResponseID generates a response ID; Ollama responses carry none, but
+// one is needed to chain with PreviousResponseID.
+func newResponseID() string {
+	var b [12]byte
+	rand.Read(b[:])
+	return "resp_" + hex.EncodeToString(b[:])
+}
@@ -0,0 +1,216 @@
+package ollama
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestToOllamaRequest(t *testing.T) {
+	maxTokens := 100
+	schema := map[string]interface{}{"type": "object", "properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}}}
+	tools := []aisdk.Tool{aisdk.NewFunctionTool("get_weather", "Weather", schema), aisdk.NewFunctionTool("get_time", "", nil)}
+
+	tests := []struct {
+		name    string
+		req     *aisdk.CreateResponseRequest
+		history []chatMessage
+		want    string
+		wantErr error
+	}{
+		{
+			name: "string input",
+			req:  &aisdk.CreateResponseRequest{Model: "llama3", Input: "hi"},
+			want: `{"model":"llama3","messages":[{"role":"user","content":"hi"}],"stream":false}`,
+		},
+		{
+			name: "instructions follow the history",
+			req: &aisdk.CreateResponseRequest{
+				Model:        "llama3",
+				Instructions: "be nice",
+				Input:        []aisdk.Message{aisdk.NewDeveloperMessage("dev"), aisdk.NewUserMessage("again")},
+				Stream:       true,
+				MaxTokens:    &maxTokens,
+			},
+			history: []chatMessage{{Role: roleUser, Content: "hi"}, {Role: roleAssistant, Content: "Hello"}},
+			want: `{"model":"llama3","stream":true,"options":{"num_predict":100},"messages":[
+				{"role":"user","content":"hi"},
+				{"role":"assistant","content":"Hello"},
+				{"role":"system","content":"be nice"},
+				{"role":"system","content":"dev"},
+				{"role":"user","content":"again"}]}`,
+		},
+		{
+			name: "function calls share an assistant turn",
+			req: &aisdk.CreateResponseRequest{
+				Model: "llama3",
+				Input: []aisdk.InputItem{
+					aisdk.FunctionCall{CallID: "c1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+					aisdk.FunctionCall{CallID: "c2", Name: "get_time"},
+					aisdk.NewFunctionCallOutput("c1", "sunny"),
+					aisdk.NewFunctionCallOutput("c2", "noon"),
+				},
+			},
+			want: `{"model":"llama3","stream":false,"messages":[
+				{"role":"assistant","content":"","tool_calls":[
+					{"id":"c1","function":{"name":"get_weather","arguments":{"city":"Paris"}}},
+					{"id":"c2","function":{"name":"get_time","arguments":{}}}]},
+				{"role":"tool","content":"sunny","tool_name":"get_weather"},
+				{"role":"tool","content":"noon","tool_name":"get_time"}]}`,
+		},
+		{
+			name:    "function output with an unknown call ID",
+			req:     &aisdk.CreateResponseRequest{Model: "llama3", Input: []aisdk.InputItem{aisdk.NewFunctionCallOutput("missing", "x")}},
+			wantErr: ErrUnknownCallID,
+		},
+		{
+			name: "base64 images",
+			req: &aisdk.CreateResponseRequest{
+				Model: "llava",
+				Input: []aisdk.Message{{Role: aisdk.RoleUser, Content: []aisdk.InputContent{
+					aisdk.NewTextContent("what is this?"),
+					aisdk.NewImageURLContent("data:image/png;base64,iVBORw0=", ""),
+				}}},
+			},
+			want: `{"model":"llava","stream":false,"messages":[{"role":"user","content":"what is this?","images":["iVBORw0="]}]}`,
+		},
+		{
+			name: "image URLs are unsupported",
+			req: &aisdk.CreateResponseRequest{
+				Model: "llava",
+				Input: []aisdk.Message{{Role: aisdk.RoleUser, Content: []aisdk.InputContent{aisdk.NewImageURLContent("https://example.com/cat.png", "")}}},
+			},
+			wantErr: ErrUnsupportedFeature,
+		},
+		{
+			name: "tools, format and thinking",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "qwen3",
+				Input:      "hi",
+				Tools:      tools,
+				TextFormat: &aisdk.TextFormat{Type: "json_schema", Schema: schema},
+				Reasoning:  &aisdk.ReasoningConfig{Effort: "low"},
+			},
+			want: `{"model":"qwen3","stream":false,"think":true,"messages":[{"role":"user","content":"hi"}],
+				"format":{"type":"object","properties":{"name":{"type":"string"}}},
+				"tools":[
+					{"type":"function","function":{"name":"get_weather","description":"Weather","parameters":{"type":"object","properties":{"name":{"type":"string"}}}}},
+					{"type":"function","function":{"name":"get_time"}}]}`,
+		},
+		{
+			name: "tool choice none omits the tools",
+			req:  &aisdk.CreateResponseRequest{Model: "qwen3", Input: "hi", Tools: tools, ToolChoice: &aisdk.ToolChoice{Mode: aisdk.ToolChoiceNone}},
+			want: `{"model":"qwen3","stream":false,"messages":[{"role":"user","content":"hi"}]}`,
+		},
+		{
+			name: "tool choice function offers only that tool",
+			req:  &aisdk.CreateResponseRequest{Model: "qwen3", Input: "hi", Tools: tools, ToolChoice: &aisdk.ToolChoice{Mode: aisdk.ToolChoiceFunction, Name: "get_time"}},
+			want: `{"model":"qwen3","stream":false,"messages":[{"role":"user","content":"hi"}],
+				"tools":[{"type":"function","function":{"name":"get_time"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toOllamaRequest(tt.req, tt.history)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("toOllamaRequest() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toOllamaRequest() error = %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestReplyBuilder(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       string
+		wantText   string
+		wantCalls  []string
+		wantStatus string
+		wantUsage  aisdk.TokenUsage
+	}{
+		{
+			name:       "text after thinking",
+			resp:       `{"model":"qwen3","message":{"role":"assistant","content":"Hi","thinking":"hmm"},"done":true,"done_reason":"stop","prompt_eval_count":3,"eval_count":4}`,
+			wantText:   "Hi",
+			wantStatus: "completed",
+			wantUsage:  aisdk.TokenUsage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7},
+		},
+		{
+			name:       "tool calls without IDs",
+			resp:       `{"model":"qwen3","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"get_weather","arguments":{"city":"Paris"}}},{"function":{"name":"get_time","arguments":null}}]},"done":true}`,
+			wantCalls:  []string{`get_weather {"city":"Paris"}`, `get_time {}`},
+			wantStatus: "completed",
+		},
+		{
+			name:       "length is incomplete",
+			resp:       `{"model":"qwen3","message":{"role":"assistant","content":"Hi"},"done":true,"done_reason":"length","prompt_eval_count":3,"eval_count":1}`,
+			wantText:   "Hi",
+			wantStatus: "incomplete",
+			wantUsage:  aisdk.TokenUsage{PromptTokens: 3, CompletionTokens: 1, TotalTokens: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var final chatResponse
+			if err := json.Unmarshal([]byte(tt.resp), &final); err != nil {
+				t.Fatal(err)
+			}
+
+			b := newReplyBuilder(false)
+			b.add(&final.Message)
+			got := b.response(&final)
+
+			if text := got.OutputText(); text != tt.wantText {
+				t.Errorf("OutputText() = %q, want %q", text, tt.wantText)
+			}
+			var calls []string
+			for i, call := range got.ToolCalls() {
+				if call.CallID == "" || call.CallID != b.message.ToolCalls[i].ID {
+					t.Errorf("call %d CallID = %q, history ID = %q", i, call.CallID, b.message.ToolCalls[i].ID)
+				}
+				calls = append(calls, call.Name+" "+call.Arguments)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("ToolCalls() = %v, want %v", calls, tt.wantCalls)
+			}
+			if status := got.Output[len(got.Output)-1].Status; status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", status, tt.wantStatus)
+			}
+			if got.Usage != tt.wantUsage {
+				t.Errorf("Usage = %+v, want %+v", got.Usage, tt.wantUsage)
+			}
+		})
+	}
+}
+
+// assertJSON fails t unless v encodes to the same JSON as want.
+func assertJSON(t *testing.T, v interface{}, want string) {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got, expected interface{}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got  %s\nwant %s", raw, want)
+	}
+}
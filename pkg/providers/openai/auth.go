@@ -5,7 +5,21 @@ import (
 )
 
 // addAuthHeaders adds OpenAI authentication headers to the request.
+// No header is sent without a key, for keyless OpenAI-compatible servers.
 // Reference: FR-004 (authentication)
 func addAuthHeaders(req *http.Request, apiKey string) {
-	req.Header.Set("Authorization", "Bearer "+apiKey)
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
+
+// addOrganizationHeaders adds the OpenAI-Organization and OpenAI-Project
+// headers when configured.
+func addOrganizationHeaders(req *http.Request, config *Config) {
+	if config.Organization != "" {
+		req.Header.Set("OpenAI-Organization", config.Organization)
+	}
+	if config.Project != "" {
+		req.Header.Set("OpenAI-Project", config.Project)
+	}
 }
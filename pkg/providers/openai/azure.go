@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultAzureAPIVersion is the api-version sent when AzureConfig.APIVersion
+// is empty; it serves both the Responses and Chat Completions APIs
+const DefaultAzureAPIVersion = "2025-04-01-preview"
+
+// Azure authentication schemes, selected by AzureConfig.Auth
+const (
+	// AzureAuthAPIKey sends the credential in the api-key header
+	AzureAuthAPIKey = "api-key"
+
+	// AzureAuthEntraID sends the credential as an Entra ID bearer token in
+	// the Authorization header
+	AzureAuthEntraID = "entra_id"
+)
+
+// AzureConfig configures the provider for Azure OpenAI. Requests go to the
+// resource endpoint with an api-version query parameter, authenticated with
+// Config.APIKey or Config.Credentials in the header Auth selects. For Entra
+// ID, supply access tokens for the https://cognitiveservices.azure.com/.default
+// scope through Config.Credentials, e.g. wrapped in aisdk.CachingCredentials.
+// Reference: https://learn.microsoft.com/azure/ai-services/openai/reference
+type AzureConfig struct {
+	// Endpoint is the resource endpoint, e.g. https://my-resource.openai.azure.com (required)
+	Endpoint string
+
+	// Deployment is the model deployment to call. When empty, the request
+	// Model is used as the deployment name (optional)
+	Deployment string
+
+	// APIVersion is the api-version query parameter (default: DefaultAzureAPIVersion)
+	APIVersion string
+
+	// Auth is the authentication scheme: AzureAuthAPIKey or AzureAuthEntraID
+	// (default: AzureAuthAPIKey)
+	Auth string
+}
+
+// validate checks the AzureConfig for required fields and constraints.
+func (a *AzureConfig) validate() error {
+	if a.Endpoint == "" {
+		return errors.New("Azure Endpoint required; set AZURE_OPENAI_ENDPOINT environment variable or provide via AzureConfig.Endpoint")
+	}
+
+	u, err := url.Parse(a.Endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.New("Azure Endpoint must be an absolute URL")
+	}
+
+	if a.Auth != "" && a.Auth != AzureAuthAPIKey && a.Auth != AzureAuthEntraID {
+		return errors.New("Azure Auth must be \"api-key\" or \"entra_id\"")
+	}
+
+	return nil
+}
+
+// url returns the URL for an API path ("/responses" or "/chat/completions").
+// Chat Completions is addressed by deployment; the Responses API takes the
+// deployment as the model in the request body (see deployment).
+func (a *AzureConfig) url(path, model string) string {
+	base := strings.TrimSuffix(a.Endpoint, "/") + "/openai"
+	if path != "/responses" {
+		base += "/deployments/" + url.PathEscape(a.deployment(model))
+	}
+
+	version := a.APIVersion
+	if version == "" {
+		version = DefaultAzureAPIVersion
+	}
+	return base + path + "?api-version=" + url.QueryEscape(version)
+}
+
+// deployment returns the deployment to call for the request model.
+func (a *AzureConfig) deployment(model string) string {
+	if a.Deployment != "" {
+		return a.Deployment
+	}
+	return model
+}
+
+// authorize returns the function that sets a credential on a request in
+// the scheme Auth selects.
+func (a *AzureConfig) authorize() func(req *http.Request, credential string) {
+	if a.Auth == AzureAuthEntraID {
+		return addAuthHeaders
+	}
+	return setAzureAPIKey
+}
+
+// setAzureAPIKey sets the api-key header.
+func setAzureAPIKey(req *http.Request, apiKey string) {
+	req.Header.Set("api-key", apiKey)
+}
@@ -0,0 +1,160 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestAzure(t *testing.T) {
+	tests := []struct {
+		name        string
+		api         string
+		azure       AzureConfig
+		apiKey      string
+		credentials aisdk.CredentialProvider
+		wantPath    string
+		wantVersion string
+		wantModel   string
+		wantAPIKey  string
+		wantBearer  string
+	}{
+		{
+			name:        "responses with api key",
+			azure:       AzureConfig{},
+			apiKey:      "key",
+			wantPath:    "/openai/responses",
+			wantVersion: DefaultAzureAPIVersion,
+			wantModel:   "gpt-4o",
+			wantAPIKey:  "key",
+		},
+		{
+			name:        "responses with deployment and api version",
+			azure:       AzureConfig{Deployment: "prod-4o", APIVersion: "2025-03-01-preview"},
+			apiKey:      "key",
+			wantPath:    "/openai/responses",
+			wantVersion: "2025-03-01-preview",
+			wantModel:   "prod-4o",
+			wantAPIKey:  "key",
+		},
+		{
+			name:        "chat completions addressed by deployment",
+			api:         APIChatCompletions,
+			azure:       AzureConfig{Deployment: "prod-4o"},
+			apiKey:      "key",
+			wantPath:    "/openai/deployments/prod-4o/chat/completions",
+			wantVersion: DefaultAzureAPIVersion,
+			wantModel:   "prod-4o",
+			wantAPIKey:  "key",
+		},
+		{
+			name:        "entra id token from credentials",
+			azure:       AzureConfig{Auth: AzureAuthEntraID},
+			credentials: aisdk.StaticCredentials("token"),
+			wantPath:    "/openai/responses",
+			wantVersion: DefaultAzureAPIVersion,
+			wantModel:   "gpt-4o",
+			wantBearer:  "Bearer token",
+		},
+		{
+			name:        "chat completions with the model as deployment",
+			api:         APIChatCompletions,
+			apiKey:      "key",
+			wantPath:    "/openai/deployments/gpt-4o/chat/completions",
+			wantVersion: DefaultAzureAPIVersion,
+			wantModel:   "gpt-4o",
+			wantAPIKey:  "key",
+		},
+		{
+			name:        "api key from credentials",
+			api:         APIChatCompletions,
+			credentials: aisdk.StaticCredentials("rotated"),
+			wantPath:    "/openai/deployments/gpt-4o/chat/completions",
+			wantVersion: DefaultAzureAPIVersion,
+			wantModel:   "gpt-4o",
+			wantAPIKey:  "rotated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body struct {
+				Model string `json:"model"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				raw, _ := io.ReadAll(r.Body)
+				json.Unmarshal(raw, &body)
+
+				w.Header().Set("Content-Type", "application/json")
+				if tt.api == APIChatCompletions {
+					io.WriteString(w, `{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"message":{"role":"assistant","content":"Hi"},"finish_reason":"stop"}]}`)
+					return
+				}
+				io.WriteString(w, `{"id":"resp_1","object":"response","model":"gpt-4o","status":"completed","output":[]}`)
+			}))
+			defer server.Close()
+
+			config := DefaultConfig()
+			config.API = tt.api
+			config.APIKey = tt.apiKey
+			config.Credentials = tt.credentials
+			azure := tt.azure
+			azure.Endpoint = server.URL + "/"
+			config.Azure = &azure
+
+			client, err := New(config)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if _, err := client.CreateResponse(context.Background(), &aisdk.CreateResponseRequest{Model: "gpt-4o", Input: "hi"}); err != nil {
+				t.Fatalf("CreateResponse() error = %v", err)
+			}
+
+			if got.URL.Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", got.URL.Path, tt.wantPath)
+			}
+			if version := got.URL.Query().Get("api-version"); version != tt.wantVersion {
+				t.Errorf("api-version = %q, want %q", version, tt.wantVersion)
+			}
+			if body.Model != tt.wantModel {
+				t.Errorf("model = %q, want %q", body.Model, tt.wantModel)
+			}
+			if key := got.Header.Get("api-key"); key != tt.wantAPIKey {
+				t.Errorf("api-key = %q, want %q", key, tt.wantAPIKey)
+			}
+			if auth := got.Header.Get("Authorization"); auth != tt.wantBearer {
+				t.Errorf("Authorization = %q, want %q", auth, tt.wantBearer)
+			}
+		})
+	}
+}
+
+func TestAzureConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		azure   AzureConfig
+		wantErr bool
+	}{
+		{"valid", AzureConfig{Endpoint: "https://my-resource.openai.azure.com"}, false},
+		{"entra id", AzureConfig{Endpoint: "https://my-resource.openai.azure.com", Auth: AzureAuthEntraID}, false},
+		{"missing endpoint", AzureConfig{}, true},
+		{"relative endpoint", AzureConfig{Endpoint: "my-resource.openai.azure.com"}, true},
+		{"endpoint without host", AzureConfig{Endpoint: "https://"}, true},
+		{"unknown auth", AzureConfig{Endpoint: "https://my-resource.openai.azure.com", Auth: "basic"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.azure.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package openai
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/output"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// chatRoleTool is the role of tool result messages
+const chatRoleTool = "tool"
+
+// Chat Completions finish reasons that mark a response incomplete
+const (
+	finishLength        = "length"
+	finishContentFilter = "content_filter"
+)
+
+// chatRequest represents the Chat Completions wire format for requests.
+// Reference: https://platform.openai.com/docs/api-reference/chat/create
+type chatRequest struct {
+	Model               string          `json:"model"`
+	Messages            []chatMessage   `json:"messages"`
+	Temperature         *float64        `json:"temperature,omitempty"`
+	MaxTokens           *int            `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int            `json:"max_completion_tokens,omitempty"`
+	Stream              bool            `json:"stream,omitempty"`
+	StreamOptions       *streamOptions  `json:"stream_options,omitempty"`
+	ResponseFormat      *responseFormat `json:"response_format,omitempty"`
+	Tools               []chatTool      `json:"tools,omitempty"`
+	ToolChoice          interface{}     `json:"tool_choice,omitempty"` // string mode or chatToolChoice
+	ParallelToolCalls   *bool           `json:"parallel_tool_calls,omitempty"`
+	ReasoningEffort     string          `json:"reasoning_effort,omitempty"`
+}
+
+// streamOptions requests a final usage chunk when streaming
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// chatMessage represents a message in requests and the message of a choice.
+// Content is a string, a []chatContentPart, or nil for assistant messages
+// that only call tools. ReasoningContent is the reasoning text some
+// OpenAI-compatible servers (vLLM, DeepSeek, llama.cpp) return; it is never
+// sent back.
+type chatMessage struct {
+	Role             string         `json:"role"`
+	Content          interface{}    `json:"content"`
+	Refusal          string         `json:"refusal,omitempty"`
+	ReasoningContent string         `json:"reasoning_content,omitempty"`
+	ToolCalls        []chatToolCall `json:"tool_calls,omitempty"`
+	ToolCallID       string         `json:"tool_call_id,omitempty"`
+}
+
+// chatContentPart represents a content part of a user message
+type chatContentPart struct {
+	Type       string            `json:"type"`
+	Text       string            `json:"text,omitempty"`
+	ImageURL   *chatImageURL     `json:"image_url,omitempty"`
+	InputAudio *openAIInputAudio `json:"input_audio,omitempty"`
+	File       *chatFile         `json:"file,omitempty"`
+}
+
+// chatImageURL represents an image_url content part
+type chatImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// chatFile represents a file content part
+type chatFile struct {
+	FileID   string `json:"file_id,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// chatToolCall represents a tool call in an assistant message. In stream
+// chunks, Index identifies the call a fragment belongs to and only the first
+// fragment carries the ID and name.
+type chatToolCall struct {
+	Index    *int             `json:"index,omitempty"`
+	ID       string           `json:"id,omitempty"`
+	Type     string           `json:"type,omitempty"`
+	Function chatFunctionCall `json:"function"`
+}
+
+// chatFunctionCall represents the function of a tool call; Arguments is a
+// JSON-encoded string
+type chatFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+// chatTool represents a function tool definition in Chat Completions format
+type chatTool struct {
+	Type     string       `json:"type"`
+	Function chatFunction `json:"function"`
+}
+
+// chatFunction represents the function of a tool definition
+type chatFunction struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters"`
+	Strict      bool                   `json:"strict,omitempty"`
+}
+
+// chatToolChoice forces a specific function in Chat Completions format
+type chatToolChoice struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name string `json:"name"`
+	} `json:"function"`
+}
+
+// responseFormat represents the Chat Completions response_format
+type responseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *jsonSchema `json:"json_schema,omitempty"`
+}
+
+// jsonSchema represents a json_schema response format
+type jsonSchema struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema,omitempty"`
+	Strict bool                   `json:"strict,omitempty"`
+}
+
+// chatCompletion represents a non-streaming response, and each SSE chunk of
+// a streamed one (where choices carry a Delta instead of a Message)
+type chatCompletion struct {
+	ID      string       `json:"id"`
+	Object  string       `json:"object"`
+	Created int64        `json:"created"`
+	Model   string       `json:"model"`
+	Choices []chatChoice `json:"choices"`
+	Usage   *openAIUsage `json:"usage,omitempty"`
+
+	// Error is set by servers that report failures inside the stream
+	Error *openAIResponseError `json:"error,omitempty"`
+}
+
+// chatChoice represents a completion choice; only the first is used
+type chatChoice struct {
+	Index        int         `json:"index"`
+	Message      chatMessage `json:"message"`
+	Delta        chatMessage `json:"delta"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+// toChatRequest converts aisdk.CreateResponseRequest to chatRequest.
+// history holds the prior turns when PreviousResponseID is set; Instructions
+// become a leading system message.
+func toChatRequest(req *aisdk.CreateResponseRequest, history []chatMessage) (*chatRequest, error) {
+	messages, err := toChatMessages(req.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	cReq := &chatRequest{
+		Model:             req.Model,
+		Temperature:       req.Temperature,
+		MaxTokens:         req.MaxTokens,
+		Stream:            req.Stream,
+		ParallelToolCalls: req.ParallelToolCalls,
+	}
+	if req.Instructions != "" {
+		cReq.Messages = append(cReq.Messages, chatMessage{Role: aisdk.RoleSystem, Content: req.Instructions})
+	}
+	cReq.Messages = append(cReq.Messages, history...)
+	cReq.Messages = append(cReq.Messages, messages...)
+
+	// Convert TextFormat to response_format
+	if req.TextFormat != nil {
+		cReq.ResponseFormat = &responseFormat{Type: req.TextFormat.Type}
+		if req.TextFormat.Type == "json_schema" {
+			cReq.ResponseFormat.JSONSchema = &jsonSchema{
+				Name:   req.TextFormat.Name,
+				Schema: req.TextFormat.Schema,
+				Strict: req.TextFormat.Strict,
+			}
+		}
+	}
+
+	if req.Reasoning != nil {
+		cReq.ReasoningEffort = req.Reasoning.Effort
+	}
+
+	// Convert Tools, reusing the Responses conversion for parameter defaults
+	for _, t := range toOpenAIRequest(&aisdk.CreateResponseRequest{Tools: req.Tools}).Tools {
+		cReq.Tools = append(cReq.Tools, chatTool{
+			Type: aisdk.ToolTypeFunction,
+			Function: chatFunction{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Parameters,
+				Strict:      t.Strict,
+			},
+		})
+	}
+
+	// Convert ToolChoice if present
+	if req.ToolChoice != nil {
+		if req.ToolChoice.Mode == aisdk.ToolChoiceFunction {
+			choice := &chatToolChoice{Type: aisdk.ToolTypeFunction}
+			choice.Function.Name = req.ToolChoice.Name
+			cReq.ToolChoice = choice
+		} else {
+			cReq.ToolChoice = req.ToolChoice.Mode
+		}
+	}
+
+	return cReq, nil
+}
+
+// toChatMessages converts aisdk input (string, Message, []Message or
+// []InputItem) to chat messages. Consecutive function calls form a single
+// assistant message.
+func toChatMessages(input interface{}) ([]chatMessage, error) {
+	items, ok := aisdk.InputItems(input)
+	if !ok {
+		// Plain string prompt
+		text, _ := input.(string)
+		return []chatMessage{{Role: aisdk.RoleUser, Content: text}}, nil
+	}
+
+	var messages []chatMessage
+	for i, item := range items {
+		switch v := item.(type) {
+		case aisdk.Message:
+			m, err := toChatMessage(v)
+			if err != nil {
+				return nil, fmt.Errorf("input[%d]: %w", i, err)
+			}
+			messages = append(messages, m)
+		case aisdk.FunctionCall:
+			arguments := v.Arguments
+			if arguments == "" {
+				arguments = "{}"
+			}
+			call := chatToolCall{
+				ID:       v.CallID,
+				Type:     aisdk.ToolTypeFunction,
+				Function: chatFunctionCall{Name: v.Name, Arguments: arguments},
+			}
+
+			if n := len(messages); n > 0 && messages[n-1].Role == aisdk.RoleAssistant && len(messages[n-1].ToolCalls) > 0 {
+				messages[n-1].ToolCalls = append(messages[n-1].ToolCalls, call)
+				continue
+			}
+			messages = append(messages, chatMessage{Role: aisdk.RoleAssistant, ToolCalls: []chatToolCall{call}})
+		case aisdk.FunctionCallOutput:
+			messages = append(messages, chatMessage{Role: chatRoleTool, Content: v.Output, ToolCallID: v.CallID})
+		}
+	}
+	return messages, nil
+}
+
+// toChatMessage converts a Message. Assistant and system content is joined
+// into a string; user content is sent as a plain string when it is only
+// text, and as content parts otherwise. The developer role is sent as
+// system, which OpenAI-compatible servers widely accept.
+func toChatMessage(m aisdk.Message) (chatMessage, error) {
+	role := m.Role
+	if role == aisdk.RoleDeveloper {
+		role = aisdk.RoleSystem
+	}
+	msg := chatMessage{Role: role}
+
+	var text []string
+	var parts []chatContentPart
+	textOnly := true
+	for i, c := range m.Content {
+		switch c.Type {
+		case aisdk.ContentTypeInputText, aisdk.ContentTypeOutputText:
+			text = append(text, c.Text)
+			parts = append(parts, chatContentPart{Type: "text", Text: c.Text})
+			continue
+		case aisdk.ContentTypeRefusal:
+			msg.Refusal += c.Refusal
+			continue
+		}
+
+		if role != aisdk.RoleUser {
+			return chatMessage{}, fmt.Errorf("content[%d]: %w: %s content in %s message", i, ErrUnsupportedFeature, c.Type, m.Role)
+		}
+		textOnly = false
+
+		switch c.Type {
+		case aisdk.ContentTypeInputImage:
+			if c.ImageURL == "" {
+				return chatMessage{}, fmt.Errorf("content[%d]: %w: images must be URLs or data URLs", i, ErrUnsupportedFeature)
+			}
+			parts = append(parts, chatContentPart{Type: "image_url", ImageURL: &chatImageURL{URL: c.ImageURL, Detail: c.Detail}})
+		case aisdk.ContentTypeInputFile:
+			if c.FileURL != "" {
+				return chatMessage{}, fmt.Errorf("content[%d]: %w: files must be uploaded or inline", i, ErrUnsupportedFeature)
+			}
+			parts = append(parts, chatContentPart{Type: "file", File: &chatFile{FileID: c.FileID, FileData: c.FileData, Filename: c.Filename}})
+		case aisdk.ContentTypeInputAudio:
+			parts = append(parts, chatContentPart{Type: "input_audio", InputAudio: &openAIInputAudio{Data: c.Audio.Data, Format: c.Audio.Format}})
+		default:
+			return chatMessage{}, fmt.Errorf("content[%d]: %w: %s content", i, ErrUnsupportedFeature, c.Type)
+		}
+	}
+
+	if textOnly {
+		msg.Content = strings.Join(text, "\n")
+	} else {
+		msg.Content = parts
+	}
+	return msg, nil
+}
+
+// addChatMessage adds the content of a choice's message to b. Tool calls
+// without an ID are assigned one and the IDs are written back to m.
+func addChatMessage(b *output.Builder, m *chatMessage) {
+	if m.ReasoningContent != "" {
+		b.Text(output.Reasoning, m.ReasoningContent)
+	}
+	if text, _ := m.Content.(string); text != "" {
+		b.Text(output.Text, text)
+	}
+	if m.Refusal != "" {
+		b.Text(output.Refusal, m.Refusal)
+	}
+	for i := range m.ToolCalls {
+		call := &m.ToolCalls[i]
+		index := b.Call(call.ID, call.Function.Name, call.Function.Arguments)
+		call.ID = b.Item(index).CallID
+	}
+}
+
+// chatStatus returns the response status for a finish reason.
+func chatStatus(finishReason string) string {
+	if finishReason == finishLength || finishReason == finishContentFilter {
+		return "incomplete"
+	}
+	return "completed"
+}
+
+// newChatResponse builds the aisdk.Response for a completion from its
+// assembled output.
+func newChatResponse(c *chatCompletion, out []aisdk.OutputItem) *aisdk.Response {
+	created := c.Created
+	if created == 0 {
+		created = time.Now().Unix()
+	}
+
+	resp := &aisdk.Response{
+		ID:      c.ID,
+		Object:  "response",
+		Model:   c.Model,
+		Created: created,
+		Output:  out,
+	}
+	if c.Usage != nil {
+		resp.Usage = aisdk.TokenUsage{
+			PromptTokens:     c.Usage.PromptTokens,
+			CompletionTokens: c.Usage.CompletionTokens,
+			TotalTokens:      c.Usage.TotalTokens,
+		}
+	}
+	return resp
+}
+
+// toChatResponse converts a non-streaming completion to aisdk.Response,
+// assigning IDs to tool calls that have none.
+func toChatResponse(c *chatCompletion) *aisdk.Response {
+	if c.ID == "" {
+		c.ID = newResponseID()
+	}
+
+	b := output.NewBuilder(c.ID, false)
+	finishReason := ""
+	if len(c.Choices) > 0 {
+		addChatMessage(b, &c.Choices[0].Message)
+		finishReason = c.Choices[0].FinishReason
+	}
+	b.CloseAll()
+
+	return newChatResponse(c, b.Output(chatStatus(finishReason)))
+}
+
+// newResponseID generates a response ID for servers that return none.
+func newResponseID() string {
+	var b [12]byte
+	rand.Read(b[:])
+	return "resp_" + hex.EncodeToString(b[:])
+}
@@ -0,0 +1,241 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/output"
+	"github.com/amannhq/go-ai-sdk/internal/sse"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// chatStreamReader implements aisdk.StreamReader over a Chat Completions SSE
+// response body, translating chat.completion.chunk deltas into the
+// Responses-style event stream. The stream ends with "data: [DONE]"; the
+// response.completed event is produced then (or at EOF, for servers that
+// omit it).
+type chatStreamReader struct {
+	ctx     context.Context
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+
+	// completion accumulates the chunk metadata: ID, model, usage
+	completion   chatCompletion
+	finishReason string
+
+	// builder assembles the output and queues events; nil until the first chunk
+	builder *output.Builder
+
+	// reply is the accumulated assistant message, for history
+	reply chatMessage
+
+	// calls maps a tool call's chunk index to its builder and reply indexes
+	calls map[int][2]int
+
+	// onComplete is called with the response ID and assistant reply at the
+	// end of the stream
+	onComplete func(responseID string, reply chatMessage)
+
+	// done is set once a terminal event has been queued, or by Close,
+	// which may run on another goroutine
+	done atomic.Bool
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newChatStreamReader wraps an SSE response body in a chatStreamReader.
+func newChatStreamReader(ctx context.Context, body io.ReadCloser, onComplete func(string, chatMessage)) *chatStreamReader {
+	return &chatStreamReader{
+		ctx:        ctx,
+		body:       body,
+		scanner:    sse.NewScanner(body),
+		reply:      chatMessage{Role: aisdk.RoleAssistant},
+		calls:      make(map[int][2]int),
+		onComplete: onComplete,
+	}
+}
+
+// Next returns the next event from the stream.
+// Returns io.EOF once response.completed (or another terminal event) has been
+// delivered.
+func (r *chatStreamReader) Next() (*aisdk.StreamEvent, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			r.Close()
+			return nil, err
+		}
+
+		if r.builder != nil {
+			if event := r.builder.Pop(); event != nil {
+				return event, nil
+			}
+		}
+
+		if r.done.Load() {
+			return nil, io.EOF
+		}
+
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				if ctxErr := r.ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				return nil, aisdk.WrapError(err, "read stream")
+			}
+			r.finish()
+			continue
+		}
+
+		_, data := sse.ParseFrame(r.scanner.Bytes())
+		if len(data) == 0 {
+			// Comment or keep-alive frame
+			continue
+		}
+		if string(data) == "[DONE]" {
+			r.finish()
+			continue
+		}
+
+		var chunk chatCompletion
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return nil, aisdk.WrapError(err, "decode stream event")
+		}
+
+		r.handle(&chunk)
+	}
+}
+
+// Close terminates the stream and releases the HTTP response body.
+// It is safe to call Close multiple times.
+func (r *chatStreamReader) Close() error {
+	r.closeOnce.Do(func() {
+		r.done.Store(true)
+		r.closeErr = r.body.Close()
+	})
+	return r.closeErr
+}
+
+// handle translates one chunk and queues the resulting events.
+func (r *chatStreamReader) handle(chunk *chatCompletion) {
+	r.start(chunk)
+
+	if chunk.Error != nil {
+		r.builder.Emit(&aisdk.StreamEvent{Type: aisdk.EventError, Error: &aisdk.StreamError{
+			Code:    chunk.Error.Code,
+			Message: chunk.Error.Message,
+		}})
+		r.done.Store(true)
+		return
+	}
+
+	// With stream_options.include_usage the last chunk carries the usage
+	// and no choices
+	if chunk.Usage != nil {
+		r.completion.Usage = chunk.Usage
+	}
+	if len(chunk.Choices) == 0 {
+		return
+	}
+
+	choice := &chunk.Choices[0]
+	if choice.FinishReason != "" {
+		r.finishReason = choice.FinishReason
+	}
+
+	delta := &choice.Delta
+	r.builder.Text(output.Reasoning, delta.ReasoningContent)
+	if text, _ := delta.Content.(string); text != "" {
+		r.builder.Text(output.Text, text)
+		content, _ := r.reply.Content.(string)
+		r.reply.Content = content + text
+	}
+	if delta.Refusal != "" {
+		r.builder.Text(output.Refusal, delta.Refusal)
+		r.reply.Refusal += delta.Refusal
+	}
+
+	// Tool call arguments arrive in fragments keyed by index; only the
+	// first fragment of a call carries its ID and name
+	for i, call := range delta.ToolCalls {
+		key := i
+		if call.Index != nil {
+			key = *call.Index
+		}
+
+		indexes, ok := r.calls[key]
+		if !ok {
+			index := r.builder.StartCall(call.ID, call.Function.Name)
+			indexes = [2]int{index, len(r.reply.ToolCalls)}
+			r.calls[key] = indexes
+			r.reply.ToolCalls = append(r.reply.ToolCalls, chatToolCall{
+				ID:       r.builder.Item(index).CallID,
+				Type:     aisdk.ToolTypeFunction,
+				Function: chatFunctionCall{Name: call.Function.Name},
+			})
+		}
+
+		r.builder.Arguments(indexes[0], call.Function.Arguments)
+		r.reply.ToolCalls[indexes[1]].Function.Arguments += call.Function.Arguments
+	}
+}
+
+// start creates the builder and queues response.created on the first chunk.
+func (r *chatStreamReader) start(chunk *chatCompletion) {
+	if r.builder != nil {
+		return
+	}
+
+	r.completion.ID = chunk.ID
+	if r.completion.ID == "" {
+		r.completion.ID = newResponseID()
+	}
+	r.completion.Model = chunk.Model
+	r.completion.Created = chunk.Created
+	if r.completion.Created == 0 {
+		r.completion.Created = time.Now().Unix()
+	}
+
+	r.builder = output.NewBuilder(r.completion.ID, true)
+	r.builder.Emit(&aisdk.StreamEvent{Type: aisdk.EventResponseCreated, Response: &aisdk.Response{
+		ID:      r.completion.ID,
+		Object:  "response",
+		Model:   r.completion.Model,
+		Created: r.completion.Created,
+		Output:  []aisdk.OutputItem{},
+	}})
+}
+
+// finish completes the output and queues response.completed (or
+// response.incomplete when output was truncated) once the stream ends.
+func (r *chatStreamReader) finish() {
+	r.done.Store(true)
+	if r.builder == nil {
+		return
+	}
+
+	r.builder.CloseAll()
+	status := chatStatus(r.finishReason)
+	resp := newChatResponse(&r.completion, r.builder.Output(status))
+
+	eventType := aisdk.EventResponseCompleted
+	if status == "incomplete" {
+		eventType = aisdk.EventResponseIncomplete
+	}
+	usage := resp.Usage
+	r.builder.Emit(&aisdk.StreamEvent{Type: eventType, Response: resp, Usage: &usage})
+
+	if r.onComplete != nil {
+		for i := range r.reply.ToolCalls {
+			if r.reply.ToolCalls[i].Function.Arguments == "" {
+				r.reply.ToolCalls[i].Function.Arguments = "{}"
+			}
+		}
+		r.onComplete(resp.ID, r.reply)
+	}
+}
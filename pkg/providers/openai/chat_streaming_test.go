@@ -0,0 +1,202 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// readChatStream reads a Chat Completions SSE body, returning its events and
+// the arguments passed to onComplete.
+func readChatStream(t *testing.T, body string) ([]*aisdk.StreamEvent, string, *chatMessage) {
+	t.Helper()
+
+	var completedID string
+	var completed *chatMessage
+	reader := newChatStreamReader(context.Background(), io.NopCloser(strings.NewReader(body)), func(id string, reply chatMessage) {
+		completedID, completed = id, &reply
+	})
+	defer reader.Close()
+
+	var events []*aisdk.StreamEvent
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return events, completedID, completed
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		events = append(events, event)
+	}
+}
+
+func TestChatStreamReaderUsageChunk(t *testing.T) {
+	// With stream_options.include_usage the usage arrives in a chunk with no
+	// choices, after the finish reason and before [DONE]
+	body := `data: {"id":"chatcmpl-1","created":1717000000,"model":"gpt-4o-mini","choices":[{"index":0,"delta":{"role":"assistant","content":""}}]}
+
+data: {"id":"chatcmpl-1","created":1717000000,"model":"gpt-4o-mini","choices":[{"index":0,"delta":{"content":"Hel"}}]}
+
+: keep-alive
+
+data: {"id":"chatcmpl-1","created":1717000000,"model":"gpt-4o-mini","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}
+
+data: {"id":"chatcmpl-1","created":1717000000,"model":"gpt-4o-mini","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}
+
+data: [DONE]
+
+`
+	events, completedID, completed := readChatStream(t, body)
+
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	want := []string{
+		aisdk.EventResponseCreated,
+		aisdk.EventOutputItemAdded,
+		aisdk.EventOutputTextDelta,
+		aisdk.EventOutputTextDelta,
+		aisdk.EventOutputTextDone,
+		aisdk.EventOutputItemDone,
+		aisdk.EventResponseCompleted,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("event types = %v, want %v", types, want)
+	}
+
+	resp := events[len(events)-1].Response
+	if resp.ID != "chatcmpl-1" || resp.Model != "gpt-4o-mini" || resp.Created != 1717000000 {
+		t.Errorf("response = %+v, want the chunk ID, model and created time", resp)
+	}
+	if text := resp.OutputText(); text != "Hello" {
+		t.Errorf("OutputText() = %q, want Hello", text)
+	}
+	if want := (aisdk.TokenUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}); resp.Usage != want {
+		t.Errorf("Usage = %+v, want %+v", resp.Usage, want)
+	}
+
+	if completedID != "chatcmpl-1" || completed == nil {
+		t.Fatalf("onComplete(%q, %+v), want chatcmpl-1", completedID, completed)
+	}
+	assertJSON(t, completed, `{"role":"assistant","content":"Hello"}`)
+}
+
+func TestChatStreamReaderParallelToolCalls(t *testing.T) {
+	// Argument fragments of parallel calls interleave; each is keyed by
+	// index and only the first fragment carries the ID and name
+	body := `data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":null,"tool_calls":[{"index":0,"id":"call_a","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}
+
+data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}}]}
+
+data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_b","type":"function","function":{"name":"get_time","arguments":""}}]}}]}
+
+data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]},"finish_reason":"tool_calls"}]}
+
+data: [DONE]
+
+`
+	events, _, completed := readChatStream(t, body)
+
+	calls := events[len(events)-1].Response.ToolCalls()
+	var got []string
+	for _, call := range calls {
+		got = append(got, call.CallID+" "+call.Name+" "+call.Arguments)
+	}
+	want := []string{`call_a get_weather {"city":"Paris"}`, "call_b get_time {}"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToolCalls() = %q, want %q", got, want)
+	}
+
+	// The stored turn must be valid to send back too
+	assertJSON(t, completed, `{"role":"assistant","content":null,"tool_calls":[
+		{"id":"call_a","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}},
+		{"id":"call_b","type":"function","function":{"name":"get_time","arguments":"{}"}}
+	]}`)
+}
+
+func TestChatStreamReaderReasoningAndRefusal(t *testing.T) {
+	// reasoning_content is the DeepSeek-style extension used by several
+	// compatible servers
+	body := `data: {"id":"chatcmpl-3","model":"deepseek-reasoner","choices":[{"index":0,"delta":{"reasoning_content":"The request is "}}]}
+
+data: {"id":"chatcmpl-3","model":"deepseek-reasoner","choices":[{"index":0,"delta":{"reasoning_content":"unsafe."}}]}
+
+data: {"id":"chatcmpl-3","model":"deepseek-reasoner","choices":[{"index":0,"delta":{"refusal":"I can't help with that."},"finish_reason":"stop"}]}
+
+`
+	events, _, completed := readChatStream(t, body)
+
+	var reasoning string
+	for _, event := range events {
+		if event.Type == aisdk.EventReasoningSummaryTextDelta {
+			reasoning += event.Delta
+		}
+	}
+	if reasoning != "The request is unsafe." {
+		t.Errorf("reasoning deltas = %q", reasoning)
+	}
+
+	// Without [DONE] the stream completes at EOF
+	last := events[len(events)-1]
+	if last.Type != aisdk.EventResponseCompleted {
+		t.Fatalf("last event = %s, want response.completed", last.Type)
+	}
+	if refusal := last.Response.Refusal(); refusal != "I can't help with that." {
+		t.Errorf("Refusal() = %q", refusal)
+	}
+	if completed == nil || completed.Refusal != "I can't help with that." {
+		t.Errorf("onComplete reply = %+v, want the refusal", completed)
+	}
+}
+
+func TestChatStreamReaderLength(t *testing.T) {
+	body := `data: {"id":"chatcmpl-4","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Once upon"},"finish_reason":"length"}]}
+
+data: [DONE]
+
+`
+	events, _, _ := readChatStream(t, body)
+
+	last := events[len(events)-1]
+	if last.Type != aisdk.EventResponseIncomplete || last.Response.Output[0].Status != "incomplete" {
+		t.Errorf("last event = %s with status %q, want response.incomplete", last.Type, last.Response.Output[0].Status)
+	}
+}
+
+func TestChatStreamReaderError(t *testing.T) {
+	// Some compatible servers report failures mid-stream as an error payload
+	body := `data: {"id":"chatcmpl-5","model":"llama","choices":[{"index":0,"delta":{"content":"Hel"}}]}
+
+data: {"error":{"code":"server_error","message":"upstream timed out"}}
+
+data: {"id":"chatcmpl-5","model":"llama","choices":[{"index":0,"delta":{"content":"lo"}}]}
+
+data: [DONE]
+
+`
+	events, completedID, _ := readChatStream(t, body)
+
+	last := events[len(events)-1]
+	if last.Type != aisdk.EventError || last.Error.Code != "server_error" || last.Error.Message != "upstream timed out" {
+		t.Fatalf("last event = %+v, want the server_error", last)
+	}
+	if completedID != "" {
+		t.Error("onComplete called after an error")
+	}
+}
+
+func TestChatStreamReaderInvalidChunk(t *testing.T) {
+	reader := newChatStreamReader(context.Background(), io.NopCloser(strings.NewReader("data: {\"id\":\n\n")), nil)
+	defer reader.Close()
+
+	if _, err := reader.Next(); err == nil || !strings.Contains(err.Error(), "decode stream event") {
+		t.Errorf("Next() error = %v, want a decode error", err)
+	}
+}
@@ -0,0 +1,280 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestToChatRequest(t *testing.T) {
+	maxTokens := 100
+	noParallel := false
+	schema := map[string]interface{}{"type": "object", "properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}}}
+
+	tests := []struct {
+		name    string
+		req     *aisdk.CreateResponseRequest
+		history []chatMessage
+		want    string
+		wantErr error
+	}{
+		{
+			name: "string input",
+			req:  &aisdk.CreateResponseRequest{Model: "llama", Input: "hi", MaxTokens: &maxTokens},
+			want: `{"model":"llama","max_tokens":100,"messages":[{"role":"user","content":"hi"}]}`,
+		},
+		{
+			name: "instructions precede the history",
+			req: &aisdk.CreateResponseRequest{
+				Model:        "llama",
+				Instructions: "be nice",
+				Input:        []aisdk.Message{aisdk.NewDeveloperMessage("dev"), aisdk.NewUserMessage("again")},
+			},
+			history: []chatMessage{{Role: aisdk.RoleUser, Content: "hi"}, {Role: aisdk.RoleAssistant, Content: "Hello"}},
+			want: `{"model":"llama","messages":[
+				{"role":"system","content":"be nice"},
+				{"role":"user","content":"hi"},
+				{"role":"assistant","content":"Hello"},
+				{"role":"system","content":"dev"},
+				{"role":"user","content":"again"}]}`,
+		},
+		{
+			name: "function calls share an assistant message",
+			req: &aisdk.CreateResponseRequest{
+				Model: "llama",
+				Input: []aisdk.InputItem{
+					aisdk.FunctionCall{CallID: "c1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+					aisdk.FunctionCall{CallID: "c2", Name: "get_time"},
+					aisdk.NewFunctionCallOutput("c1", "sunny"),
+					aisdk.NewFunctionCallOutput("c2", "noon"),
+				},
+			},
+			want: `{"model":"llama","messages":[
+				{"role":"assistant","content":null,"tool_calls":[
+					{"id":"c1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}},
+					{"id":"c2","type":"function","function":{"name":"get_time","arguments":"{}"}}]},
+				{"role":"tool","content":"sunny","tool_call_id":"c1"},
+				{"role":"tool","content":"noon","tool_call_id":"c2"}]}`,
+		},
+		{
+			name: "user content parts",
+			req: &aisdk.CreateResponseRequest{
+				Model: "gpt-4o",
+				Input: []aisdk.Message{{Role: aisdk.RoleUser, Content: []aisdk.InputContent{
+					aisdk.NewTextContent("what is this?"),
+					aisdk.NewImageURLContent("https://example.com/cat.png", "low"),
+					aisdk.NewFileIDContent("file-1"),
+					aisdk.NewAudioContent("AAAA", "wav"),
+				}}},
+			},
+			want: `{"model":"gpt-4o","messages":[{"role":"user","content":[
+				{"type":"text","text":"what is this?"},
+				{"type":"image_url","image_url":{"url":"https://example.com/cat.png","detail":"low"}},
+				{"type":"file","file":{"file_id":"file-1"}},
+				{"type":"input_audio","input_audio":{"data":"AAAA","format":"wav"}}]}]}`,
+		},
+		{
+			name: "images in assistant messages are unsupported",
+			req: &aisdk.CreateResponseRequest{
+				Model: "llama",
+				Input: []aisdk.Message{{Role: aisdk.RoleAssistant, Content: []aisdk.InputContent{aisdk.NewImageURLContent("https://example.com/cat.png", "")}}},
+			},
+			wantErr: ErrUnsupportedFeature,
+		},
+		{
+			name: "file URLs are unsupported",
+			req: &aisdk.CreateResponseRequest{
+				Model: "llama",
+				Input: []aisdk.Message{{Role: aisdk.RoleUser, Content: []aisdk.InputContent{aisdk.NewFileURLContent("https://example.com/doc.pdf")}}},
+			},
+			wantErr: ErrUnsupportedFeature,
+		},
+		{
+			name: "tools, forced function and no parallel calls",
+			req: &aisdk.CreateResponseRequest{
+				Model:             "llama",
+				Input:             "hi",
+				Tools:             []aisdk.Tool{aisdk.NewFunctionTool("get_weather", "Weather", schema)},
+				ToolChoice:        &aisdk.ToolChoice{Mode: aisdk.ToolChoiceFunction, Name: "get_weather"},
+				ParallelToolCalls: &noParallel,
+			},
+			want: `{"model":"llama","messages":[{"role":"user","content":"hi"}],
+				"tools":[{"type":"function","function":{"name":"get_weather","description":"Weather",
+					"parameters":{"type":"object","properties":{"name":{"type":"string"}}},"strict":true}}],
+				"tool_choice":{"type":"function","function":{"name":"get_weather"}},
+				"parallel_tool_calls":false}`,
+		},
+		{
+			name: "tool choice mode",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "llama",
+				Input:      "hi",
+				ToolChoice: &aisdk.ToolChoice{Mode: aisdk.ToolChoiceRequired},
+			},
+			want: `{"model":"llama","messages":[{"role":"user","content":"hi"}],"tool_choice":"required"}`,
+		},
+		{
+			name: "text format, reasoning and streaming",
+			req: &aisdk.CreateResponseRequest{
+				Model:      "o4-mini",
+				Input:      "hi",
+				Stream:     true,
+				TextFormat: &aisdk.TextFormat{Type: "json_schema", Name: "person", Schema: schema, Strict: true},
+				Reasoning:  &aisdk.ReasoningConfig{Effort: "low"},
+			},
+			want: `{"model":"o4-mini","stream":true,"messages":[{"role":"user","content":"hi"}],
+				"response_format":{"type":"json_schema","json_schema":{"name":"person","strict":true,
+					"schema":{"type":"object","properties":{"name":{"type":"string"}}}}},
+				"reasoning_effort":"low"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toChatRequest(tt.req, tt.history)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("toChatRequest() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toChatRequest() error = %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestToChatResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		completion string
+		wantText   string
+		wantCalls  []string
+		wantStatus string
+		wantUsage  aisdk.TokenUsage
+	}{
+		{
+			name: "text with reasoning content",
+			completion: `{"id":"chatcmpl-1","model":"llama","choices":[{"message":{"role":"assistant","content":"Hi","reasoning_content":"hmm"},"finish_reason":"stop"}],
+				"usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}`,
+			wantText:   "Hi",
+			wantStatus: "completed",
+			wantUsage:  aisdk.TokenUsage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7},
+		},
+		{
+			name: "tool calls",
+			completion: `{"id":"chatcmpl-1","model":"llama","choices":[{"message":{"role":"assistant","content":null,"tool_calls":[
+				{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}},
+				{"type":"function","function":{"name":"get_time","arguments":"{}"}}]},"finish_reason":"tool_calls"}]}`,
+			wantCalls:  []string{`get_weather {"city":"Paris"}`, `get_time {}`},
+			wantStatus: "completed",
+		},
+		{
+			name:       "length is incomplete",
+			completion: `{"id":"chatcmpl-1","model":"llama","choices":[{"message":{"role":"assistant","content":"Hi"},"finish_reason":"length"}]}`,
+			wantText:   "Hi",
+			wantStatus: "incomplete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var completion chatCompletion
+			if err := json.Unmarshal([]byte(tt.completion), &completion); err != nil {
+				t.Fatal(err)
+			}
+
+			got := toChatResponse(&completion)
+			if got.ID != "chatcmpl-1" {
+				t.Errorf("ID = %q, want chatcmpl-1", got.ID)
+			}
+			if text := got.OutputText(); text != tt.wantText {
+				t.Errorf("OutputText() = %q, want %q", text, tt.wantText)
+			}
+			var calls []string
+			for i, call := range got.ToolCalls() {
+				if call.CallID == "" || call.CallID != completion.Choices[0].Message.ToolCalls[i].ID {
+					t.Errorf("call %d CallID = %q, message ID = %q", i, call.CallID, completion.Choices[0].Message.ToolCalls[i].ID)
+				}
+				calls = append(calls, call.Name+" "+call.Arguments)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("ToolCalls() = %v, want %v", calls, tt.wantCalls)
+			}
+			if status := got.Output[len(got.Output)-1].Status; status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", status, tt.wantStatus)
+			}
+			if got.Usage != tt.wantUsage {
+				t.Errorf("Usage = %+v, want %+v", got.Usage, tt.wantUsage)
+			}
+		})
+	}
+}
+
+// assertJSON fails t unless v encodes to the same JSON as want.
+func assertJSON(t *testing.T, v interface{}, want string) {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got, expected interface{}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got  %s\nwant %s", raw, want)
+	}
+}
+
+func TestChatOutputLimitField(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseURL   string
+		azure     *AzureConfig
+		wantField string
+	}{
+		{name: "openai", baseURL: "https://api.openai.com/v1", wantField: "max_completion_tokens"},
+		{name: "azure", azure: &AzureConfig{Endpoint: "https://my-resource.openai.azure.com"}, wantField: "max_completion_tokens"},
+		{name: "compatible server", baseURL: "http://localhost:8000/v1", wantField: "max_tokens"},
+	}
+
+	maxTokens := 50
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.API = APIChatCompletions
+			config.APIKey = "key"
+			config.BaseURL = tt.baseURL
+			config.Azure = tt.azure
+			client, err := New(config)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			cReq, err := client.toChatRequest(&aisdk.CreateResponseRequest{Model: "o4-mini", Input: "hi", MaxTokens: &maxTokens})
+			if err != nil {
+				t.Fatalf("toChatRequest() error = %v", err)
+			}
+			body, _ := json.Marshal(cReq)
+			var fields map[string]interface{}
+			json.Unmarshal(body, &fields)
+
+			if fields[tt.wantField] != float64(maxTokens) {
+				t.Errorf("request %s, want %s: %d", body, tt.wantField, maxTokens)
+			}
+			if len(fields) != 3 {
+				t.Errorf("request %s, want only model, messages and %s", body, tt.wantField)
+			}
+		})
+	}
+}
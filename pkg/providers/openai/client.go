@@ -4,43 +4,72 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"time"
 
+	"github.com/amannhq/go-ai-sdk/internal/history"
 	internalhttp "github.com/amannhq/go-ai-sdk/internal/http"
+	"github.com/amannhq/go-ai-sdk/internal/transport"
 	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
 	"github.com/amannhq/go-ai-sdk/pkg/middleware"
 )
 
 // Client implements the Provider interface for OpenAI.
+// With Config.API set to APIChatCompletions it speaks /chat/completions
+// instead of /responses, for OpenAI-compatible servers.
 // Reference: architecture.md (Provider Interface Pattern)
 type Client struct {
-	config      *Config
-	httpClient  *internalhttp.HTTPClient
-	retryConfig *middleware.RetryConfig
+	config    *Config
+	transport *transport.Transport
+
+	// history holds Chat Completions conversations for PreviousResponseID
+	history *history.History[chatMessage]
 }
 
 // New creates a new OpenAI client with the given configuration.
-func New(config *Config) (*Client, error) {
+// Options are applied to a copy of config (DefaultConfig when nil).
+func New(config *Config, opts ...aisdk.Option) (*Client, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	configured := *config
+	config = &configured
+	overrides := aisdk.NewOptions(opts...).ClientConfig()
+	config.override(overrides)
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
+	if err := overrides.Validate(); err != nil {
+		return nil, err
+	}
+	settings := config.settings()
+	settings.Apply(overrides)
 
 	return &Client{
-		config:      config,
-		httpClient:  internalhttp.NewHTTPClient(config.Timeout),
-		retryConfig: middleware.DefaultRetryConfig(),
+		config:    config,
+		transport: transport.New("openai", settings, mapOpenAIError),
+		history:   history.New[chatMessage](config.HistorySize),
 	}, nil
 }
 
 // NewFromEnv creates a new OpenAI client loading configuration from environment.
-func NewFromEnv() (*Client, error) {
+func NewFromEnv(opts ...aisdk.Option) (*Client, error) {
 	config, err := NewConfigFromEnv()
 	if err != nil {
 		return nil, err
 	}
-	return New(config)
+	return New(config, opts...)
+}
+
+// Configure implements aisdk.ConfigurableProvider. The copy shares the
+// conversation history of c.
+func (c *Client) Configure(config *aisdk.ClientConfig) aisdk.Provider {
+	configured := *c.config
+	configured.override(config)
+	return &Client{
+		config:    &configured,
+		transport: c.transport.Configure(config),
+		history:   c.history,
+	}
 }
 
 // CreateResponse implements Provider.CreateResponse for OpenAI.
@@ -51,136 +80,215 @@ func (c *Client) CreateResponse(ctx context.Context, req *aisdk.CreateResponseRe
 		return nil, aisdk.WrapError(err, "openai.CreateResponse")
 	}
 
-	// Convert to OpenAI format
-	oaiReq := toOpenAIRequest(req)
+	if c.config.chatCompletions() {
+		return c.createChatCompletion(ctx, req)
+	}
 
-	// Marshal request
-	body, err := json.Marshal(oaiReq)
+	// Every attempt is traced under the same correlation ID
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	// Convert to OpenAI format and execute with retry
+	oaiReq := toOpenAIRequest(req)
+	oaiReq.Model = c.model(req.Model)
+	httpResp, err := c.send(ctx, req, "/responses", oaiReq, false, correlationID)
 	if err != nil {
-		return nil, aisdk.WrapError(err, "marshal request")
+		return nil, err
 	}
+	defer httpResp.Body.Close()
 
-	// Create HTTP request
-	url := c.config.BaseURL + "/responses"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
-	if err != nil {
-		return nil, aisdk.WrapError(err, "create http request")
+	// Parse response
+	var oaiResp openAIResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&oaiResp); err != nil {
+		return nil, c.transport.Fail(ctx, http.MethodPost, httpResp.Request.URL.String(), aisdk.WrapError(err, "decode response"))
 	}
 
-	// Add headers
-	addAuthHeaders(httpReq, c.config.APIKey)
-	httpReq.Header.Set("Content-Type", "application/json")
+	// Convert to SDK format
+	resp := toAISDKResponse(&oaiResp)
 
-	// Execute with retry
-	var httpResp *http.Response
-	var lastErr error
-
-	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
-		// Execute request
-		httpResp, err = c.httpClient.DoRequest(ctx, httpReq)
-		if err != nil {
-			lastErr = err
-			if ctx.Err() != nil {
-				return nil, ctx.Err()
-			}
-			// Retry on network errors
-			if attempt < c.retryConfig.MaxRetries {
-				backoff := c.retryConfig.ExponentialBackoff(attempt)
-				select {
-				case <-time.After(backoff):
-					continue
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				}
-			}
-			break
-		}
+	// Attach rate limit info
+	resp.RateLimitInfo = transport.RateLimitInfo(internalhttp.ExtractRateLimitHeaders(httpResp.Header))
 
-		// Check status code
-		if httpResp.StatusCode >= 200 && httpResp.StatusCode < 300 {
-			// Success
-			break
-		}
+	return resp, nil
+}
 
-		// Extract rate limit info
-		rateLimitInfo := internalhttp.ExtractRateLimitHeaders(httpResp.Header)
+// StreamResponse implements Provider.StreamResponse for OpenAI.
+// POSTs to /responses with stream: true and returns a StreamReader over the
+// server-sent events. The caller must Close the reader to release the connection.
+// Reference: data-model.md Entity #7, docs/providers/openai.md lines 7618-7751
+func (c *Client) StreamResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (aisdk.StreamReader, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, aisdk.WrapError(err, "openai.StreamResponse")
+	}
 
-		// Handle error response
-		apiErr := mapOpenAIError(httpResp, middleware.GetCorrelationID(ctx))
-		httpResp.Body.Close()
+	if c.config.chatCompletions() {
+		return c.streamChatCompletion(ctx, req)
+	}
 
-		// Check if retryable
-		if !middleware.IsRetryableStatus(httpResp.StatusCode) {
-			// Non-retryable error
-			if httpResp.StatusCode == 429 {
-				return nil, aisdk.NewRateLimitError(httpResp.StatusCode, apiErr.Code, apiErr.Message, apiErr.CorrelationID, convertRateLimitInfo(rateLimitInfo))
-			}
-			return nil, apiErr
-		}
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
 
-		// Retry with backoff
-		if attempt < c.retryConfig.MaxRetries {
-			var backoff time.Duration
-			if httpResp.StatusCode == 429 && rateLimitInfo.RetryAfter > 0 {
-				// Use server-provided retry-after
-				backoff = rateLimitInfo.RetryAfter
-			} else {
-				backoff = c.retryConfig.ExponentialBackoff(attempt)
-			}
-			select {
-			case <-time.After(backoff):
-				continue
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			}
-		}
+	// Convert to OpenAI format with streaming enabled
+	oaiReq := toOpenAIRequest(req)
+	oaiReq.Model = c.model(req.Model)
+	oaiReq.Stream = true
 
-		// Max retries exceeded
-		if httpResp.StatusCode == 429 {
-			return nil, aisdk.NewRateLimitError(httpResp.StatusCode, apiErr.Code, apiErr.Message, apiErr.CorrelationID, convertRateLimitInfo(rateLimitInfo))
-		}
-		return nil, apiErr
+	// Open the stream (retrying connection setup); the body stays open
+	// until the reader is closed
+	httpResp, err := c.send(ctx, req, "/responses", oaiReq, true, correlationID)
+	if err != nil {
+		return nil, err
 	}
 
-	if lastErr != nil {
-		return nil, aisdk.WrapError(lastErr, "openai.CreateResponse")
-	}
+	return newStreamReader(ctx, httpResp.Body), nil
+}
 
-	if httpResp == nil {
-		return nil, aisdk.NewAPIError(0, "unknown", "no response received", middleware.GetCorrelationID(ctx))
+// createChatCompletion implements CreateResponse in APIChatCompletions mode.
+// POSTs to /chat/completions and converts the first choice to an aisdk.Response.
+func (c *Client) createChatCompletion(ctx context.Context, req *aisdk.CreateResponseRequest) (*aisdk.Response, error) {
+	// Convert to Chat Completions format
+	cReq, err := c.toChatRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "openai.CreateResponse")
 	}
+	cReq.Stream = false
+
+	// Every attempt is traced under the same correlation ID
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
 
+	httpResp, err := c.send(ctx, req, "/chat/completions", cReq, false, correlationID)
+	if err != nil {
+		return nil, err
+	}
 	defer httpResp.Body.Close()
 
 	// Parse response
-	var oaiResp openAIResponse
-	if err := json.NewDecoder(httpResp.Body).Decode(&oaiResp); err != nil {
-		return nil, aisdk.WrapError(err, "decode response")
+	var completion chatCompletion
+	if err := json.NewDecoder(httpResp.Body).Decode(&completion); err != nil {
+		return nil, c.transport.Fail(ctx, http.MethodPost, httpResp.Request.URL.String(), aisdk.WrapError(err, "decode response"))
 	}
 
-	// Convert to SDK format
-	resp := toAISDKResponse(&oaiResp)
+	// Convert to SDK format (assigns missing response and call IDs)
+	resp := toChatResponse(&completion)
+	if len(completion.Choices) > 0 {
+		c.remember(cReq, resp.ID, completion.Choices[0].Message)
+	}
 
 	// Attach rate limit info
-	resp.RateLimitInfo = convertRateLimitInfo(internalhttp.ExtractRateLimitHeaders(httpResp.Header))
+	resp.RateLimitInfo = transport.RateLimitInfo(internalhttp.ExtractRateLimitHeaders(httpResp.Header))
 
 	return resp, nil
 }
 
-// convertRateLimitInfo converts internal RateLimitInfo to aisdk.RateLimitInfo
-func convertRateLimitInfo(info *internalhttp.RateLimitInfo) *aisdk.RateLimitInfo {
-	if info == nil {
-		return nil
+// streamChatCompletion implements StreamResponse in APIChatCompletions mode.
+// POSTs to /chat/completions with stream: true and returns a StreamReader
+// translating the completion chunks into response.* events.
+func (c *Client) streamChatCompletion(ctx context.Context, req *aisdk.CreateResponseRequest) (aisdk.StreamReader, error) {
+	// Convert to Chat Completions format with streaming enabled
+	cReq, err := c.toChatRequest(req)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "openai.StreamResponse")
+	}
+	cReq.Stream = true
+	cReq.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	ctx, correlationID := middleware.EnsureCorrelationID(ctx)
+
+	// Open the stream (retrying connection setup); the body stays open
+	// until the reader is closed
+	httpResp, err := c.send(ctx, req, "/chat/completions", cReq, true, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newChatStreamReader(ctx, httpResp.Body, func(responseID string, reply chatMessage) {
+		c.remember(cReq, responseID, reply)
+	}), nil
+}
+
+// toChatRequest converts req, replaying the conversation named by PreviousResponseID.
+func (c *Client) toChatRequest(req *aisdk.CreateResponseRequest) (*chatRequest, error) {
+	var prior []chatMessage
+	if req.PreviousResponseID != "" {
+		var ok bool
+		prior, ok = c.history.Get(req.PreviousResponseID)
+		if !ok {
+			return nil, ErrUnknownPreviousResponse
+		}
 	}
-	return &aisdk.RateLimitInfo{
-		Limit:      info.Limit,
-		Remaining:  info.Remaining,
-		ResetAt:    info.ResetAt,
-		RetryAfter: info.RetryAfter,
+	cReq, err := toChatRequest(req, prior)
+	if err != nil {
+		return nil, err
 	}
-} // StreamResponse implements Provider.StreamResponse for OpenAI.
-// Reference: data-model.md Entity #7
-func (c *Client) StreamResponse(ctx context.Context, req *aisdk.CreateResponseRequest) (aisdk.StreamReader, error) {
-	// TODO: Implement streaming (T065-T067)
-	return nil, fmt.Errorf("streaming not yet implemented")
+	cReq.Model = c.model(req.Model)
+	if c.config.maxCompletionTokens() {
+		cReq.MaxCompletionTokens, cReq.MaxTokens = cReq.MaxTokens, nil
+	}
+	return cReq, nil
+}
+
+// model returns the model to send: the Azure deployment, when configured,
+// or the request model.
+func (c *Client) model(model string) string {
+	if c.config.Azure != nil {
+		return c.config.Azure.deployment(model)
+	}
+	return model
+}
+
+// remember records the conversation so later requests can chain on it.
+// System messages are not carried over, matching Instructions semantics,
+// and reasoning text is not sent back.
+func (c *Client) remember(cReq *chatRequest, responseID string, reply chatMessage) {
+	conversation := make([]chatMessage, 0, len(cReq.Messages)+1)
+	for _, m := range cReq.Messages {
+		if m.Role != aisdk.RoleSystem {
+			conversation = append(conversation, m)
+		}
+	}
+	reply.ReasoningContent = ""
+	c.history.Put(responseID, append(conversation, reply))
+}
+
+// send POSTs payload to path (/responses or /chat/completions) through the
+// shared transport. Streaming requests are sent without the overall client
+// timeout.
+func (c *Client) send(ctx context.Context, req *aisdk.CreateResponseRequest, path string, payload interface{}, stream bool, correlationID string) (*http.Response, error) {
+	// Marshal request
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, aisdk.WrapError(err, "marshal request")
+	}
+
+	// Create HTTP request
+	url := c.config.BaseURL + path
+	if c.config.Azure != nil {
+		url = c.config.Azure.url(path, req.Model)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, aisdk.WrapError(err, "create http request")
+	}
+
+	// Add headers
+	authorize := addAuthHeaders
+	if c.config.Azure != nil {
+		authorize = c.config.Azure.authorize()
+	} else {
+		addOrganizationHeaders(httpReq, c.config)
+	}
+	authorize(httpReq, c.config.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	if stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+
+	return c.transport.Send(ctx, &transport.Request{
+		HTTP:          httpReq,
+		Stream:        stream,
+		CorrelationID: correlationID,
+		APIKey:        c.config.APIKey,
+		Authorize:     authorize,
+		Model:         req.Model,
+		Tokens:        transport.EstimateTokens(req, body),
+	})
 }
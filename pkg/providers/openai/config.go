@@ -2,51 +2,208 @@ package openai
 
 import (
 	"errors"
+	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/amannhq/go-ai-sdk/internal/transport"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/pkg/middleware"
+)
+
+// APIs the provider can speak, selected by Config.API
+const (
+	// APIResponses is the OpenAI Responses API (POST /responses)
+	APIResponses = "responses"
+
+	// APIChatCompletions is the Chat Completions API (POST /chat/completions),
+	// for OpenAI-compatible servers such as vLLM, llama.cpp, Groq, Together
+	// and LM Studio that do not implement /responses
+	APIChatCompletions = "chat_completions"
 )
 
 // Config holds the configuration for the OpenAI provider.
 // Reference: data-model.md Entity #1 (ClientConfig)
 type Config struct {
-	// APIKey is the OpenAI API key (required)
+	// APIKey is the OpenAI API key (required for APIResponses; optional for
+	// APIChatCompletions, since local servers often need none)
 	APIKey string
 
+	// Credentials supplies the API key per request instead of APIKey, for
+	// rotated keys and short-lived tokens (optional)
+	Credentials aisdk.CredentialProvider
+
 	// BaseURL is the OpenAI API base URL (default: https://api.openai.com/v1)
 	BaseURL string
 
+	// Organization is sent as the OpenAI-Organization header (optional)
+	Organization string
+
+	// Project is sent as the OpenAI-Project header (optional)
+	Project string
+
+	// API selects the wire API: APIResponses or APIChatCompletions
+	// (default: APIResponses)
+	API string
+
+	// Azure switches to Azure OpenAI: requests go to Azure.Endpoint instead
+	// of BaseURL and APIKey is sent as the api-key header (optional)
+	Azure *AzureConfig
+
 	// Timeout is the HTTP request timeout (default: 60s)
 	Timeout time.Duration
+
+	// MaxRetries is the maximum retry attempts for transient failures
+	// (default: 3; ignored when Retry is set)
+	MaxRetries int
+
+	// HistorySize is the number of conversations remembered for
+	// PreviousResponseID chaining in APIChatCompletions mode; Chat Completions
+	// is stateless, so the client replays prior turns itself
+	// (default: 1000, 0 disables chaining)
+	HistorySize int
+
+	// Logger receives structured retry and error events (optional)
+	Logger aisdk.Logger
+
+	// TelemetryHooks are called for every HTTP attempt (optional)
+	TelemetryHooks *middleware.TelemetryHooks
+
+	// RateLimiter paces every HTTP attempt per API key and model (optional)
+	RateLimiter *middleware.RateLimiter
+
+	// Retry sets the retry policy, including its MaxRetries (optional,
+	// default: middleware.DefaultRetryConfig)
+	Retry *middleware.RetryConfig
+
+	// HTTPClient replaces the default pooled http.Client (optional);
+	// Timeout applies when the client sets none
+	HTTPClient *http.Client
+
+	// Transport configures the HTTP transport: a custom RoundTripper, proxy,
+	// TLS (custom CA pool, client certificates) and RoundTripper middleware
+	// (optional)
+	Transport *aisdk.TransportConfig
+
+	// Headers are sent with every request, replacing headers of the same
+	// name set by the provider (optional)
+	Headers http.Header
 }
 
 // DefaultConfig returns a Config with default values
 func DefaultConfig() *Config {
 	return &Config{
-		BaseURL: "https://api.openai.com/v1",
-		Timeout: 60 * time.Second,
+		BaseURL:     "https://api.openai.com/v1",
+		API:         APIResponses,
+		Timeout:     60 * time.Second,
+		MaxRetries:  3,
+		HistorySize: 1000,
 	}
 }
 
 // Validate checks the Config for required fields and constraints.
 // Returns descriptive error per SC-006 (actionable error messages).
 func (c *Config) Validate() error {
-	if c.APIKey == "" {
-		return errors.New("API key required; set OPENAI_API_KEY environment variable or provide via Config.APIKey")
+	if c.API != "" && c.API != APIResponses && c.API != APIChatCompletions {
+		return errors.New("API must be \"responses\" or \"chat_completions\"")
 	}
 
-	if c.BaseURL == "" {
-		return errors.New("BaseURL cannot be empty")
+	if c.APIKey == "" && c.requiresAPIKey() {
+		if c.Azure != nil {
+			return errors.New("API key required; set AZURE_OPENAI_API_KEY environment variable, provide via Config.APIKey, or supply Entra ID tokens via Config.Credentials")
+		}
+		return errors.New("API key required; set OPENAI_API_KEY environment variable or provide via Config.APIKey")
 	}
 
-	// Validate BaseURL is a valid URL
-	_, err := url.Parse(c.BaseURL)
-	if err != nil {
-		return errors.New("BaseURL must be a valid URL")
+	if c.Azure != nil {
+		if err := c.Azure.validate(); err != nil {
+			return err
+		}
+	} else {
+		if c.BaseURL == "" {
+			return errors.New("BaseURL cannot be empty")
+		}
+
+		// Validate BaseURL is a valid URL
+		_, err := url.Parse(c.BaseURL)
+		if err != nil {
+			return errors.New("BaseURL must be a valid URL")
+		}
 	}
 
 	if c.Timeout <= 0 {
 		return errors.New("Timeout must be positive duration")
 	}
 
+	if c.MaxRetries < 0 {
+		return errors.New("MaxRetries cannot be negative")
+	}
+
+	if c.HistorySize < 0 {
+		return errors.New("HistorySize cannot be negative")
+	}
+
 	return nil
 }
+
+// chatCompletions reports whether the Config selects APIChatCompletions.
+func (c *Config) chatCompletions() bool {
+	return c.API == APIChatCompletions
+}
+
+// maxCompletionTokens reports whether Chat Completions requests limit output
+// with max_completion_tokens: OpenAI and Azure deprecated max_tokens, which
+// their reasoning models reject, while other servers may only accept
+// max_tokens.
+func (c *Config) maxCompletionTokens() bool {
+	if c.Azure != nil {
+		return true
+	}
+	u, err := url.Parse(c.BaseURL)
+	return err == nil && u.Hostname() == "api.openai.com"
+}
+
+// requiresAPIKey reports whether APIKey is required: it is optional with
+// Credentials and, except on Azure, in APIChatCompletions mode.
+func (c *Config) requiresAPIKey() bool {
+	if c.Credentials != nil {
+		return false
+	}
+	return c.Azure != nil || !c.chatCompletions()
+}
+
+// override sets the provider fields config sets; Settings.Apply sets the
+// shared ones.
+func (c *Config) override(config *aisdk.ClientConfig) {
+	if config.APIKey != "" {
+		c.APIKey = config.APIKey
+	}
+	if config.Credentials != nil {
+		c.Credentials = config.Credentials
+	}
+	if config.BaseURL != "" {
+		c.BaseURL = config.BaseURL
+	}
+	if config.Organization != "" {
+		c.Organization = config.Organization
+	}
+	if config.Project != "" {
+		c.Project = config.Project
+	}
+}
+
+// settings returns the transport settings of the Config.
+func (c *Config) settings() transport.Settings {
+	return transport.Settings{
+		Timeout:        c.Timeout,
+		MaxRetries:     c.MaxRetries,
+		Retry:          c.Retry,
+		HTTPClient:     c.HTTPClient,
+		Transport:      c.Transport,
+		Headers:        c.Headers,
+		Logger:         c.Logger,
+		TelemetryHooks: c.TelemetryHooks,
+		RateLimiter:    c.RateLimiter,
+		Credentials:    c.Credentials,
+	}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 // NewConfigFromEnv creates a Config loading the API key from environment.
-// Reads OPENAI_API_KEY environment variable.
+// Reads OPENAI_API_KEY and the optional OPENAI_ORG_ID and OPENAI_PROJECT_ID.
 // Reference: FR-013 (environment-based configuration)
 func NewConfigFromEnv() (*Config, error) {
 	apiKey := os.Getenv("OPENAI_API_KEY")
@@ -17,5 +17,27 @@ func NewConfigFromEnv() (*Config, error) {
 
 	config := DefaultConfig()
 	config.APIKey = apiKey
+	config.Organization = os.Getenv("OPENAI_ORG_ID")
+	config.Project = os.Getenv("OPENAI_PROJECT_ID")
+	return config, nil
+}
+
+// NewAzureConfigFromEnv creates a Config for Azure OpenAI from environment.
+// Reads AZURE_OPENAI_ENDPOINT (required), AZURE_OPENAI_API_KEY,
+// OPENAI_API_VERSION and AZURE_OPENAI_DEPLOYMENT. Without an API key,
+// set Credentials (and Azure.Auth for Entra ID tokens) before use.
+func NewAzureConfigFromEnv() (*Config, error) {
+	endpoint := os.Getenv("AZURE_OPENAI_ENDPOINT")
+	if endpoint == "" {
+		return nil, ErrMissingAzureEndpoint
+	}
+
+	config := DefaultConfig()
+	config.APIKey = os.Getenv("AZURE_OPENAI_API_KEY")
+	config.Azure = &AzureConfig{
+		Endpoint:   endpoint,
+		Deployment: os.Getenv("AZURE_OPENAI_DEPLOYMENT"),
+		APIVersion: os.Getenv("OPENAI_API_VERSION"),
+	}
 	return config, nil
 }
@@ -2,15 +2,30 @@ package openai
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 
-	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+	"github.com/amannhq/go-ai-sdk/internal/transport"
 )
 
-// mapOpenAIError converts an HTTP error response to an APIError.
+var (
+	// ErrUnknownPreviousResponse indicates that PreviousResponseID does not
+	// name a response remembered by this client (APIChatCompletions mode)
+	ErrUnknownPreviousResponse = errors.New("previous response not found; Chat Completions is stateless, so only responses created by this client (within HistorySize) can be chained")
+
+	// ErrUnsupportedFeature indicates a request feature Chat Completions
+	// cannot express
+	ErrUnsupportedFeature = errors.New("unsupported by the Chat Completions API")
+
+	// ErrMissingAzureEndpoint indicates that AZURE_OPENAI_ENDPOINT is not set
+	ErrMissingAzureEndpoint = errors.New("Azure endpoint required; set AZURE_OPENAI_ENDPOINT environment variable or provide via AzureConfig.Endpoint")
+)
+
+// mapOpenAIError converts an HTTP error response to an *aisdk.APIError, or
+// an *aisdk.RateLimitError for 429 responses. It consumes the response body.
 // Reference: FR-005 (error handling), research.md decision #6
-func mapOpenAIError(resp *http.Response, correlationID string) *aisdk.APIError {
+func mapOpenAIError(resp *http.Response, correlationID string) error {
 	// Try to parse OpenAI error format
 	var oaiErr openAIError
 	body, err := io.ReadAll(resp.Body)
@@ -18,16 +33,6 @@ func mapOpenAIError(resp *http.Response, correlationID string) *aisdk.APIError {
 		json.Unmarshal(body, &oaiErr)
 	}
 
-	code := oaiErr.Error.Code
-	message := oaiErr.Error.Message
-
-	// Fallback to status text if no error details
-	if code == "" {
-		code = http.StatusText(resp.StatusCode)
-	}
-	if message == "" {
-		message = "Request failed with status " + resp.Status
-	}
-
-	return aisdk.NewAPIError(resp.StatusCode, code, message, correlationID)
+	// Missing details fall back to the HTTP status
+	return transport.StatusError(resp, oaiErr.Error.Code, oaiErr.Error.Message, correlationID)
 }
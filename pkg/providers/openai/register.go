@@ -0,0 +1,122 @@
+package openai
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func init() {
+	aisdk.Register("openai", openConnection)
+	aisdk.Register("azure", openAzureConnection)
+}
+
+// openConnection is the aisdk.ProviderFactory for "openai://" connections.
+// APIKey, Organization and Project default to OPENAI_API_KEY, OPENAI_ORG_ID
+// and OPENAI_PROJECT_ID. Options: api (responses or chat_completions),
+// organization, project and history_size.
+func openConnection(conn *aisdk.ConnectionConfig, opts ...aisdk.Option) (aisdk.Provider, error) {
+	config := DefaultConfig()
+	config.APIKey = os.Getenv("OPENAI_API_KEY")
+	config.Organization = os.Getenv("OPENAI_ORG_ID")
+	config.Project = os.Getenv("OPENAI_PROJECT_ID")
+
+	err := applyConnection(config, conn, func(key, value string) bool {
+		switch key {
+		case "organization":
+			config.Organization = value
+		case "project":
+			config.Project = value
+		default:
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newFromConnection(config, opts)
+}
+
+// openAzureConnection is the aisdk.ProviderFactory for "azure://"
+// connections, e.g. azure://my-resource.openai.azure.com?deployment=gpt-4o.
+// The host (or base_url) is the resource endpoint, defaulting to
+// AZURE_OPENAI_ENDPOINT; APIKey defaults to AZURE_OPENAI_API_KEY. Options:
+// deployment, api_version, auth (api-key or entra_id), api and history_size.
+func openAzureConnection(conn *aisdk.ConnectionConfig, opts ...aisdk.Option) (aisdk.Provider, error) {
+	config := DefaultConfig()
+	config.APIKey = os.Getenv("AZURE_OPENAI_API_KEY")
+	config.Azure = &AzureConfig{
+		Endpoint:   os.Getenv("AZURE_OPENAI_ENDPOINT"),
+		Deployment: os.Getenv("AZURE_OPENAI_DEPLOYMENT"),
+		APIVersion: os.Getenv("OPENAI_API_VERSION"),
+	}
+	if conn.BaseURL != "" {
+		config.Azure.Endpoint = conn.BaseURL
+	}
+	if config.Azure.Endpoint == "" {
+		return nil, ErrMissingAzureEndpoint
+	}
+
+	err := applyConnection(config, conn, func(key, value string) bool {
+		switch key {
+		case "deployment":
+			config.Azure.Deployment = value
+		case "api_version":
+			config.Azure.APIVersion = value
+		case "auth":
+			config.Azure.Auth = value
+		default:
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newFromConnection(config, opts)
+}
+
+// applyConnection applies the connection settings and the options shared
+// by both factories to config; extra handles factory-specific options.
+func applyConnection(config *Config, conn *aisdk.ConnectionConfig, extra func(key, value string) bool) error {
+	if conn.APIKey != "" {
+		config.APIKey = conn.APIKey
+	}
+	config.Credentials = conn.Credentials
+	if conn.BaseURL != "" && config.Azure == nil {
+		config.BaseURL = conn.BaseURL
+	}
+	if conn.Timeout > 0 {
+		config.Timeout = conn.Timeout
+	}
+
+	for key, value := range conn.Options {
+		switch key {
+		case "api":
+			config.API = value
+		case "history_size":
+			size, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%w: history_size: %v", aisdk.ErrInvalidConnectionString, err)
+			}
+			config.HistorySize = size
+		default:
+			if extra == nil || !extra(key, value) {
+				return fmt.Errorf("%w: unknown option %q", aisdk.ErrInvalidConnectionString, key)
+			}
+		}
+	}
+	return nil
+}
+
+// newFromConnection is New returning an aisdk.Provider.
+func newFromConnection(config *Config, opts []aisdk.Option) (aisdk.Provider, error) {
+	client, err := New(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
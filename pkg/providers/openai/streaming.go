@@ -0,0 +1,200 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/amannhq/go-ai-sdk/internal/sse"
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+// openAIStreamEvent represents a streaming event in OpenAI wire format.
+// Fields are a union across all event types; only the relevant ones are set.
+// Reference: docs/providers/openai.md lines 7618-7751
+type openAIStreamEvent struct {
+	Type           string             `json:"type"`
+	SequenceNumber int                `json:"sequence_number"`
+	ResponseID     string             `json:"response_id,omitempty"`
+	ItemID         string             `json:"item_id,omitempty"`
+	OutputIndex    int                `json:"output_index"`
+	ContentIndex   int                `json:"content_index"`
+	Delta          string             `json:"delta,omitempty"`
+	Text           string             `json:"text,omitempty"`
+	Refusal        string             `json:"refusal,omitempty"`
+	Arguments      string             `json:"arguments,omitempty"`
+	Code           string             `json:"code,omitempty"`
+	Message        string             `json:"message,omitempty"`
+	Item           *openAIOutputItem  `json:"item,omitempty"`
+	Part           *openAIContentPart `json:"part,omitempty"`
+	Response       *openAIResponse    `json:"response,omitempty"`
+}
+
+// openAIStreamReader implements aisdk.StreamReader over an SSE response body.
+// Reference: research.md decision #1 (bufio.Scanner with custom SplitFunc)
+type openAIStreamReader struct {
+	ctx     context.Context
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+
+	// responseID is remembered from response.created so that events which
+	// omit it can still be linked to their parent response
+	responseID string
+
+	// done is set once a terminal event has been delivered, or by Close,
+	// which may run on another goroutine
+	done atomic.Bool
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newStreamReader wraps an SSE response body in an openAIStreamReader.
+func newStreamReader(ctx context.Context, body io.ReadCloser) *openAIStreamReader {
+	return &openAIStreamReader{
+		ctx:     ctx,
+		body:    body,
+		scanner: sse.NewScanner(body),
+	}
+}
+
+// Next returns the next event from the stream.
+// Returns io.EOF once response.completed (or another terminal event) has been
+// delivered, or when the server closes the stream.
+func (r *openAIStreamReader) Next() (*aisdk.StreamEvent, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			r.Close()
+			return nil, err
+		}
+
+		if r.done.Load() {
+			return nil, io.EOF
+		}
+
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				if ctxErr := r.ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				return nil, aisdk.WrapError(err, "read stream")
+			}
+			r.done.Store(true)
+			return nil, io.EOF
+		}
+
+		eventName, data := sse.ParseFrame(r.scanner.Bytes())
+		if len(data) == 0 {
+			// Comment or keep-alive frame
+			continue
+		}
+		if string(data) == "[DONE]" {
+			r.done.Store(true)
+			return nil, io.EOF
+		}
+
+		var oaiEvent openAIStreamEvent
+		if err := json.Unmarshal(data, &oaiEvent); err != nil {
+			return nil, aisdk.WrapError(err, "decode stream event")
+		}
+		if oaiEvent.Type == "" {
+			oaiEvent.Type = eventName
+		}
+
+		event := r.toStreamEvent(&oaiEvent)
+		if isTerminalEvent(event.Type) {
+			r.done.Store(true)
+		}
+		return event, nil
+	}
+}
+
+// Close terminates the stream and releases the HTTP response body.
+// It is safe to call Close multiple times.
+func (r *openAIStreamReader) Close() error {
+	r.closeOnce.Do(func() {
+		r.done.Store(true)
+		r.closeErr = r.body.Close()
+	})
+	return r.closeErr
+}
+
+// toStreamEvent converts an openAIStreamEvent to aisdk.StreamEvent
+func (r *openAIStreamReader) toStreamEvent(oaiEvent *openAIStreamEvent) *aisdk.StreamEvent {
+	event := &aisdk.StreamEvent{
+		Type:           oaiEvent.Type,
+		SequenceNumber: oaiEvent.SequenceNumber,
+		ResponseID:     oaiEvent.ResponseID,
+		ItemID:         oaiEvent.ItemID,
+		OutputIndex:    oaiEvent.OutputIndex,
+		ContentIndex:   oaiEvent.ContentIndex,
+		Delta:          oaiEvent.Delta,
+	}
+
+	// Final values of *.done events
+	switch {
+	case oaiEvent.Text != "":
+		event.Text = oaiEvent.Text
+	case oaiEvent.Refusal != "":
+		event.Text = oaiEvent.Refusal
+	case oaiEvent.Arguments != "":
+		event.Text = oaiEvent.Arguments
+	}
+
+	if oaiEvent.Item != nil {
+		item := toAISDKOutputItem(oaiEvent.Item)
+		event.Output = &item
+		if event.ItemID == "" {
+			event.ItemID = item.ID
+		}
+	}
+
+	if oaiEvent.Part != nil {
+		part := toAISDKContentPart(oaiEvent.Part)
+		event.Part = &part
+	}
+
+	if oaiEvent.Response != nil {
+		event.Response = toAISDKResponse(oaiEvent.Response)
+		if oaiEvent.Response.ID != "" {
+			r.responseID = oaiEvent.Response.ID
+		}
+		if oaiEvent.Type == aisdk.EventResponseCompleted || oaiEvent.Type == aisdk.EventResponseIncomplete {
+			usage := event.Response.Usage
+			event.Usage = &usage
+		}
+		if oaiEvent.Response.Error != nil {
+			event.Error = &aisdk.StreamError{
+				Code:    oaiEvent.Response.Error.Code,
+				Message: oaiEvent.Response.Error.Message,
+			}
+		}
+	}
+
+	if event.ResponseID == "" {
+		event.ResponseID = r.responseID
+	}
+
+	if oaiEvent.Type == aisdk.EventError {
+		event.Error = &aisdk.StreamError{
+			Code:    oaiEvent.Code,
+			Message: oaiEvent.Message,
+		}
+	}
+
+	return event
+}
+
+// isTerminalEvent reports whether no further events follow the given type.
+func isTerminalEvent(eventType string) bool {
+	switch eventType {
+	case aisdk.EventResponseCompleted, aisdk.EventResponseFailed,
+		aisdk.EventResponseIncomplete, aisdk.EventError:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,230 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amannhq/go-ai-sdk/pkg/aisdk"
+)
+
+func TestStreamReaderEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []aisdk.StreamEvent
+	}{
+		{
+			name: "text response",
+			body: `event: response.created
+data: {"type":"response.created","sequence_number":0,"response":{"id":"resp_1","object":"response","model":"gpt-4o","output":[]}}
+
+: keep-alive
+
+event: response.output_item.added
+data: {"type":"response.output_item.added","sequence_number":1,"output_index":0,"item":{"id":"msg_1","type":"message","role":"assistant","status":"in_progress","content":[]}}
+
+event: response.content_part.added
+data: {"type":"response.content_part.added","sequence_number":2,"item_id":"msg_1","output_index":0,"content_index":0,"part":{"type":"output_text","text":""}}
+
+event: response.output_text.delta
+data: {"type":"response.output_text.delta","sequence_number":3,"item_id":"msg_1","output_index":0,"content_index":0,"delta":"Hi"}
+
+event: response.output_text.done
+data: {"type":"response.output_text.done","sequence_number":4,"item_id":"msg_1","output_index":0,"content_index":0,"text":"Hi"}
+
+event: response.completed
+data: {"type":"response.completed","sequence_number":5,"response":{"id":"resp_1","object":"response","model":"gpt-4o","output":[{"id":"msg_1","type":"message","role":"assistant","status":"completed","content":[{"type":"output_text","text":"Hi"}]}],"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}}
+
+event: response.output_text.delta
+data: {"type":"response.output_text.delta","delta":"after the terminal event"}
+
+`,
+			want: []aisdk.StreamEvent{
+				{
+					Type:       aisdk.EventResponseCreated,
+					ResponseID: "resp_1",
+					Response:   &aisdk.Response{ID: "resp_1", Object: "response", Model: "gpt-4o", Output: []aisdk.OutputItem{}},
+				},
+				{
+					Type:           aisdk.EventOutputItemAdded,
+					SequenceNumber: 1,
+					ResponseID:     "resp_1",
+					ItemID:         "msg_1",
+					Output:         &aisdk.OutputItem{ID: "msg_1", Type: "message", Role: "assistant", Status: "in_progress", Content: []aisdk.ContentPart{}},
+				},
+				{
+					Type:           aisdk.EventContentPartAdded,
+					SequenceNumber: 2,
+					ResponseID:     "resp_1",
+					ItemID:         "msg_1",
+					Part:           &aisdk.ContentPart{Type: "output_text"},
+				},
+				{Type: aisdk.EventOutputTextDelta, SequenceNumber: 3, ResponseID: "resp_1", ItemID: "msg_1", Delta: "Hi"},
+				{Type: aisdk.EventOutputTextDone, SequenceNumber: 4, ResponseID: "resp_1", ItemID: "msg_1", Text: "Hi"},
+				{
+					Type:           aisdk.EventResponseCompleted,
+					SequenceNumber: 5,
+					ResponseID:     "resp_1",
+					Response: &aisdk.Response{
+						ID:     "resp_1",
+						Object: "response",
+						Model:  "gpt-4o",
+						Output: []aisdk.OutputItem{{
+							ID:      "msg_1",
+							Type:    "message",
+							Role:    "assistant",
+							Status:  "completed",
+							Content: []aisdk.ContentPart{{Type: "output_text", Text: "Hi"}},
+						}},
+						Usage: aisdk.TokenUsage{PromptTokens: 5, CompletionTokens: 1, TotalTokens: 6},
+					},
+					Usage: &aisdk.TokenUsage{PromptTokens: 5, CompletionTokens: 1, TotalTokens: 6},
+				},
+			},
+		},
+		{
+			name: "function call arguments and refusal",
+			body: `data: {"type":"response.function_call_arguments.delta","response_id":"resp_2","item_id":"fc_1","output_index":1,"delta":"{\"city\":"}
+
+data: {"type":"response.function_call_arguments.done","response_id":"resp_2","item_id":"fc_1","output_index":1,"arguments":"{\"city\":\"Paris\"}"}
+
+data: {"type":"response.refusal.done","response_id":"resp_2","item_id":"msg_2","refusal":"I can't help with that."}
+
+data: [DONE]
+
+`,
+			want: []aisdk.StreamEvent{
+				{Type: aisdk.EventFunctionCallArgumentsDelta, ResponseID: "resp_2", ItemID: "fc_1", OutputIndex: 1, Delta: `{"city":`},
+				{Type: aisdk.EventFunctionCallArgumentsDone, ResponseID: "resp_2", ItemID: "fc_1", OutputIndex: 1, Text: `{"city":"Paris"}`},
+				{Type: aisdk.EventRefusalDone, ResponseID: "resp_2", ItemID: "msg_2", Text: "I can't help with that."},
+			},
+		},
+		{
+			name: "event name when the data has no type",
+			body: `event: response.output_text.delta
+data: {"item_id":"msg_1","delta":"x"}
+
+`,
+			want: []aisdk.StreamEvent{
+				{Type: aisdk.EventOutputTextDelta, ItemID: "msg_1", Delta: "x"},
+			},
+		},
+		{
+			name: "failed response",
+			body: `data: {"type":"response.failed","response":{"id":"resp_3","object":"response","output":[],"error":{"code":"server_error","message":"overloaded"}}}
+
+data: {"type":"response.output_text.delta","delta":"ignored"}
+
+`,
+			want: []aisdk.StreamEvent{
+				{
+					Type:       aisdk.EventResponseFailed,
+					ResponseID: "resp_3",
+					Response:   &aisdk.Response{ID: "resp_3", Object: "response", Output: []aisdk.OutputItem{}},
+					Error:      &aisdk.StreamError{Code: "server_error", Message: "overloaded"},
+				},
+			},
+		},
+		{
+			name: "error event",
+			body: `data: {"type":"error","code":"rate_limit_exceeded","message":"slow down"}
+
+`,
+			want: []aisdk.StreamEvent{
+				{Type: aisdk.EventError, Error: &aisdk.StreamError{Code: "rate_limit_exceeded", Message: "slow down"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := newStreamReader(context.Background(), io.NopCloser(strings.NewReader(tt.body)))
+			defer reader.Close()
+
+			var got []aisdk.StreamEvent
+			for {
+				event, err := reader.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				got = append(got, *event)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range tt.want {
+				if !reflect.DeepEqual(got[i], tt.want[i]) {
+					t.Errorf("event %d:\n got  %+v\n want %+v", i, got[i], tt.want[i])
+				}
+			}
+
+			if _, err := reader.Next(); err != io.EOF {
+				t.Errorf("Next() after the end error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestStreamReaderInvalidEvent(t *testing.T) {
+	reader := newStreamReader(context.Background(), io.NopCloser(strings.NewReader("data: {not json}\n\n")))
+	defer reader.Close()
+
+	if _, err := reader.Next(); err == nil || !strings.Contains(err.Error(), "decode stream event") {
+		t.Errorf("Next() error = %v, want a decode error", err)
+	}
+}
+
+func TestStreamReaderCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	body, writer := io.Pipe()
+	defer writer.Close()
+	reader := newStreamReader(ctx, body)
+
+	cancel()
+	if _, err := reader.Next(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Next() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestStreamReaderCloseWhileReading(t *testing.T) {
+	body, writer := io.Pipe()
+	defer writer.Close()
+	reader := newStreamReader(context.Background(), body)
+
+	// Next blocks reading the body until Close, called from another
+	// goroutine, ends it
+	done := make(chan error, 1)
+	go func() {
+		_, err := reader.Next()
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Next() error = nil after Close, want an error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Next() did not return after Close")
+	}
+
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("Next() after Close error = %v, want io.EOF", err)
+	}
+	if err := reader.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
@@ -9,24 +9,81 @@ import (
 // Reference: contracts/openai-responses-v1.json
 type openAIRequest struct {
 	Model              string      `json:"model"`
-	Input              interface{} `json:"input"` // string or []Message
+	Input              interface{} `json:"input"` // string or []openAIInputItem
 	Instructions       string      `json:"instructions,omitempty"`
 	Temperature        *float64    `json:"temperature,omitempty"`
 	MaxTokens          *int        `json:"max_tokens,omitempty"`
 	Stream             bool        `json:"stream,omitempty"`
-	Text               *textFormat `json:"text,omitempty"`
+	Text               *textConfig `json:"text,omitempty"`
 	PreviousResponseID string      `json:"previous_response_id,omitempty"`
 	Reasoning          *reasoning  `json:"reasoning,omitempty"`
+	Tools              []tool      `json:"tools,omitempty"`
+	ToolChoice         interface{} `json:"tool_choice,omitempty"` // string mode or toolChoiceFunction
+	ParallelToolCalls  *bool       `json:"parallel_tool_calls,omitempty"`
+}
+
+// openAIInputItem represents an input list item in OpenAI format.
+// Fields are a union across item types; only the relevant ones are set.
+type openAIInputItem struct {
+	Type      string               `json:"type"`
+	Role      string               `json:"role,omitempty"`
+	Content   []openAIInputContent `json:"content,omitempty"`
+	ID        string               `json:"id,omitempty"`
+	CallID    string               `json:"call_id,omitempty"`
+	Name      string               `json:"name,omitempty"`
+	Arguments *string              `json:"arguments,omitempty"`
+	Output    *string              `json:"output,omitempty"`
+}
+
+// openAIInputContent represents an input content part in OpenAI format
+type openAIInputContent struct {
+	Type       string            `json:"type"`
+	Text       *string           `json:"text,omitempty"`
+	ImageURL   string            `json:"image_url,omitempty"`
+	Detail     string            `json:"detail,omitempty"`
+	FileID     string            `json:"file_id,omitempty"`
+	FileURL    string            `json:"file_url,omitempty"`
+	FileData   string            `json:"file_data,omitempty"`
+	Filename   string            `json:"filename,omitempty"`
+	InputAudio *openAIInputAudio `json:"input_audio,omitempty"`
+	Refusal    string            `json:"refusal,omitempty"`
+}
+
+// openAIInputAudio represents base64 audio input in OpenAI format
+type openAIInputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+// textConfig represents the OpenAI text output configuration
+// Reference: docs/providers/openai.md lines 2316, 2995 (text.format)
+type textConfig struct {
+	Format *textFormat `json:"format"`
 }
 
 // textFormat represents the OpenAI text format configuration
 type textFormat struct {
 	Type   string                 `json:"type"`
 	Name   string                 `json:"name,omitempty"`
-	Schema map[string]interface{} `json:"json_schema,omitempty"`
+	Schema map[string]interface{} `json:"schema,omitempty"`
 	Strict bool                   `json:"strict,omitempty"`
 }
 
+// tool represents a function tool definition in OpenAI format
+type tool struct {
+	Type        string                 `json:"type"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters"`
+	Strict      bool                   `json:"strict"`
+}
+
+// toolChoiceFunction forces a specific function in OpenAI format
+type toolChoiceFunction struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
 // reasoning represents the OpenAI reasoning configuration
 type reasoning struct {
 	Effort string `json:"effort"`
@@ -36,7 +93,8 @@ type reasoning struct {
 func toOpenAIRequest(req *aisdk.CreateResponseRequest) *openAIRequest {
 	oaiReq := &openAIRequest{
 		Model:              req.Model,
-		Input:              req.Input,
+		Input:              toOpenAIInput(req.Input),
+		ParallelToolCalls:  req.ParallelToolCalls,
 		Instructions:       req.Instructions,
 		Temperature:        req.Temperature,
 		MaxTokens:          req.MaxTokens,
@@ -46,11 +104,13 @@ func toOpenAIRequest(req *aisdk.CreateResponseRequest) *openAIRequest {
 
 	// Convert TextFormat if present
 	if req.TextFormat != nil {
-		oaiReq.Text = &textFormat{
-			Type:   req.TextFormat.Type,
-			Name:   req.TextFormat.Name,
-			Schema: req.TextFormat.Schema,
-			Strict: req.TextFormat.Strict,
+		oaiReq.Text = &textConfig{
+			Format: &textFormat{
+				Type:   req.TextFormat.Type,
+				Name:   req.TextFormat.Name,
+				Schema: req.TextFormat.Schema,
+				Strict: req.TextFormat.Strict,
+			},
 		}
 	}
 
@@ -61,9 +121,112 @@ func toOpenAIRequest(req *aisdk.CreateResponseRequest) *openAIRequest {
 		}
 	}
 
+	// Convert Tools if present
+	if len(req.Tools) > 0 {
+		oaiReq.Tools = make([]tool, len(req.Tools))
+		for i, t := range req.Tools {
+			params := t.Parameters
+			if params == nil {
+				// The API requires a parameters object even for no-arg functions
+				params = map[string]interface{}{
+					"type":                 "object",
+					"properties":           map[string]interface{}{},
+					"required":             []string{},
+					"additionalProperties": false,
+				}
+			}
+			oaiReq.Tools[i] = tool{
+				Type:        t.Type,
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  params,
+				Strict:      t.Strict,
+			}
+		}
+	}
+
+	// Convert ToolChoice if present
+	if req.ToolChoice != nil {
+		if req.ToolChoice.Mode == aisdk.ToolChoiceFunction {
+			oaiReq.ToolChoice = &toolChoiceFunction{Type: aisdk.ToolTypeFunction, Name: req.ToolChoice.Name}
+		} else {
+			oaiReq.ToolChoice = req.ToolChoice.Mode
+		}
+	}
+
 	return oaiReq
 }
 
+// toOpenAIInput converts aisdk input (string, Message, []Message or
+// []InputItem) to the OpenAI input format
+func toOpenAIInput(input interface{}) interface{} {
+	items, ok := aisdk.InputItems(input)
+	if !ok {
+		// Plain string prompt
+		return input
+	}
+
+	oaiItems := make([]openAIInputItem, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case aisdk.Message:
+			oaiItems = append(oaiItems, toOpenAIMessage(&v))
+		case aisdk.FunctionCall:
+			arguments := v.Arguments
+			oaiItems = append(oaiItems, openAIInputItem{
+				Type:      aisdk.InputItemTypeFunctionCall,
+				ID:        v.ID,
+				CallID:    v.CallID,
+				Name:      v.Name,
+				Arguments: &arguments,
+			})
+		case aisdk.FunctionCallOutput:
+			output := v.Output
+			oaiItems = append(oaiItems, openAIInputItem{
+				Type:   aisdk.InputItemTypeFunctionCallOutput,
+				CallID: v.CallID,
+				Output: &output,
+			})
+		}
+	}
+	return oaiItems
+}
+
+// toOpenAIMessage converts aisdk.Message to openAIInputItem
+func toOpenAIMessage(msg *aisdk.Message) openAIInputItem {
+	item := openAIInputItem{
+		Type:    aisdk.InputItemTypeMessage,
+		Role:    msg.Role,
+		Content: make([]openAIInputContent, len(msg.Content)),
+	}
+
+	for i, part := range msg.Content {
+		oaiPart := openAIInputContent{
+			Type:     part.Type,
+			ImageURL: part.ImageURL,
+			Detail:   part.Detail,
+			FileID:   part.FileID,
+			FileURL:  part.FileURL,
+			FileData: part.FileData,
+			Filename: part.Filename,
+			Refusal:  part.Refusal,
+		}
+		if part.Type == aisdk.ContentTypeInputText || part.Type == aisdk.ContentTypeOutputText {
+			text := part.Text
+			oaiPart.Text = &text
+		}
+		if part.Audio != nil {
+			oaiPart.InputAudio = &openAIInputAudio{
+				Data:   part.Audio.Data,
+				Format: part.Audio.Format,
+			}
+		}
+		item.Content[i] = oaiPart
+	}
+
+	return item
+}
+
 // openAIResponse represents the OpenAI wire format for responses.
 // Maps from OpenAI API format to aisdk.Response.
 // Reference: contracts/openai-responses-v1.json
@@ -74,14 +237,27 @@ type openAIResponse struct {
 	Usage   openAIUsage        `json:"usage"`
 	Model   string             `json:"model"`
 	Created int64              `json:"created"`
+
+	// Error is populated on failed responses (e.g. response.failed events)
+	Error *openAIResponseError `json:"error,omitempty"`
+}
+
+// openAIResponseError represents the error object embedded in a failed response
+type openAIResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 // openAIOutputItem represents an output item in OpenAI format
 type openAIOutputItem struct {
-	ID      string              `json:"id"`
-	Type    string              `json:"type"`
-	Role    string              `json:"role"`
-	Content []openAIContentPart `json:"content"`
+	ID        string              `json:"id"`
+	Type      string              `json:"type"`
+	Role      string              `json:"role"`
+	Content   []openAIContentPart `json:"content"`
+	Status    string              `json:"status,omitempty"`
+	CallID    string              `json:"call_id,omitempty"`
+	Name      string              `json:"name,omitempty"`
+	Arguments string              `json:"arguments,omitempty"`
 }
 
 // openAIContentPart represents a content part in OpenAI format
@@ -123,40 +299,56 @@ func toAISDKResponse(oaiResp *openAIResponse) *aisdk.Response {
 	}
 
 	// Convert output items
-	for i, oaiItem := range oaiResp.Output {
-		resp.Output[i] = aisdk.OutputItem{
-			ID:      oaiItem.ID,
-			Type:    oaiItem.Type,
-			Role:    oaiItem.Role,
-			Content: make([]aisdk.ContentPart, len(oaiItem.Content)),
-		}
+	for i := range oaiResp.Output {
+		resp.Output[i] = toAISDKOutputItem(&oaiResp.Output[i])
+	}
 
-		// Convert content parts
-		for j, oaiPart := range oaiItem.Content {
-			part := aisdk.ContentPart{
-				Type:    oaiPart.Type,
-				Text:    oaiPart.Text,
-				Refusal: oaiPart.Refusal,
-			}
+	return resp
+}
 
-			// Convert annotations if present
-			if len(oaiPart.Annotations) > 0 {
-				part.Annotations = make([]aisdk.Annotation, len(oaiPart.Annotations))
-				for k, oaiAnnot := range oaiPart.Annotations {
-					part.Annotations[k] = aisdk.Annotation{
-						Type:       oaiAnnot.Type,
-						Text:       oaiAnnot.Text,
-						StartIndex: oaiAnnot.StartIndex,
-						EndIndex:   oaiAnnot.EndIndex,
-					}
-				}
-			}
+// toAISDKOutputItem converts openAIOutputItem to aisdk.OutputItem
+func toAISDKOutputItem(oaiItem *openAIOutputItem) aisdk.OutputItem {
+	item := aisdk.OutputItem{
+		ID:        oaiItem.ID,
+		Type:      oaiItem.Type,
+		Role:      oaiItem.Role,
+		Content:   make([]aisdk.ContentPart, len(oaiItem.Content)),
+		Status:    oaiItem.Status,
+		CallID:    oaiItem.CallID,
+		Name:      oaiItem.Name,
+		Arguments: oaiItem.Arguments,
+	}
+
+	// Convert content parts
+	for j := range oaiItem.Content {
+		item.Content[j] = toAISDKContentPart(&oaiItem.Content[j])
+	}
+
+	return item
+}
 
-			resp.Output[i].Content[j] = part
+// toAISDKContentPart converts openAIContentPart to aisdk.ContentPart
+func toAISDKContentPart(oaiPart *openAIContentPart) aisdk.ContentPart {
+	part := aisdk.ContentPart{
+		Type:    oaiPart.Type,
+		Text:    oaiPart.Text,
+		Refusal: oaiPart.Refusal,
+	}
+
+	// Convert annotations if present
+	if len(oaiPart.Annotations) > 0 {
+		part.Annotations = make([]aisdk.Annotation, len(oaiPart.Annotations))
+		for k, oaiAnnot := range oaiPart.Annotations {
+			part.Annotations[k] = aisdk.Annotation{
+				Type:       oaiAnnot.Type,
+				Text:       oaiAnnot.Text,
+				StartIndex: oaiAnnot.StartIndex,
+				EndIndex:   oaiAnnot.EndIndex,
+			}
 		}
 	}
 
-	return resp
+	return part
 }
 
 // openAIError represents an error response from OpenAI
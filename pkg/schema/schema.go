@@ -0,0 +1,71 @@
+// Package schema derives JSON Schema documents from Go types.
+// It is the public wrapper around the SDK's internal reflection-based converter.
+// Reference: research.md decision #2 (Go struct tags → JSON Schema conversion)
+package schema
+
+import (
+	internalschema "github.com/amannhq/go-ai-sdk/internal/schema"
+)
+
+// ErrStrictSchema indicates a schema that OpenAI strict mode would reject.
+var ErrStrictSchema = internalschema.ErrStrictSchema
+
+// Schemaer is implemented by types that provide their own JSON Schema,
+// overriding reflection (useful for custom json.Marshaler types).
+type Schemaer = internalschema.Schemaer
+
+// For returns the JSON Schema for the struct type T.
+// Field names come from `json` tags. The `jsonschema` tag accepts
+// comma-separated description=, enum= (repeatable), minimum=, maximum=,
+// pattern=, format=, default= and examples= (repeatable) entries; escape
+// literal commas as `\,`. Recursive types are emitted via $defs/$ref.
+func For[T any]() (map[string]interface{}, error) {
+	return FromValue(new(T))
+}
+
+// StrictFor returns the OpenAI strict-mode JSON Schema for the struct type T.
+// Optional fields (omitempty or pointer) are expressed as nullable.
+func StrictFor[T any]() (map[string]interface{}, error) {
+	return FromValueStrict(new(T))
+}
+
+// FromValue returns the JSON Schema for the struct (or pointer to struct) v.
+func FromValue(v interface{}) (map[string]interface{}, error) {
+	return internalschema.StructToJSONSchema(v)
+}
+
+// FromValueStrict returns the strict-mode JSON Schema for the struct (or
+// pointer to struct) v.
+func FromValueStrict(v interface{}) (map[string]interface{}, error) {
+	return internalschema.StrictStructToJSONSchema(v)
+}
+
+// CheckStrict reports every strict-mode violation in s (depth, property and
+// enum limits, missing additionalProperties: false, non-required properties).
+// Each violation wraps ErrStrictSchema.
+func CheckStrict(s map[string]interface{}) error {
+	return internalschema.CheckStrict(s)
+}
+
+// ValidationError describes a single violation at a JSON pointer Path.
+type ValidationError = internalschema.ValidationError
+
+// ValidationErrors lists every violation found in one document.
+type ValidationErrors = internalschema.ValidationErrors
+
+// Validator validates JSON documents against a compiled schema.
+// Compile once and reuse it for repeated validation (e.g. tool arguments).
+type Validator = internalschema.Validator
+
+// Compile prepares s for validation.
+func Compile(s map[string]interface{}) (*Validator, error) {
+	return internalschema.Compile(s)
+}
+
+// Validate checks the JSON document data against s using the draft 2020-12
+// subset supported by OpenAI Structured Outputs (type, properties, required,
+// additionalProperties, enum, anyOf, $ref/$defs, items, bounds, pattern,
+// format). Returns ValidationErrors with JSON-pointer paths, or nil.
+func Validate(s map[string]interface{}, data []byte) error {
+	return internalschema.Validate(s, data)
+}